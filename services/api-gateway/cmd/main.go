@@ -77,6 +77,8 @@ func main() {
 
 	// Instrumentation GraphQL (Expert)
 	srv.Use(otelgqlgen.Middleware())
+	// Erreurs typées : extensions.code dérivé du code gRPC des services (cf. graph.ErrorPresenter)
+	srv.SetErrorPresenter(graph.ErrorPresenter)
 
 	// 6. Chaîne de Middlewares HTTP
 	var h http.Handler = srv
@@ -88,7 +90,7 @@ func main() {
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:19006"},
 		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type", "baggage", "sentry-trace"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "Idempotency-Key", "baggage", "sentry-trace"},
 		AllowCredentials: true,
 	})
 	h = c.Handler(h)
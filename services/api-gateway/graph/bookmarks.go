@@ -0,0 +1,25 @@
+package graph
+
+import (
+	"context"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/api-gateway/graph/model"
+)
+
+// bookmarksFromProto : repostOf n'est résolu que pour les posts encore disponibles (une pierre tombale n'a rien à montrer)
+func (r *Resolver) bookmarksFromProto(ctx context.Context, bookmarks []*postv1.Bookmark, viewerID string) ([]*model.Bookmark, error) {
+	nodes := make([]*model.Bookmark, len(bookmarks))
+	available := make([]*model.Post, 0, len(bookmarks))
+	for i, b := range bookmarks {
+		nodes[i] = mapProtoBookmarkToGraph(b)
+		if !b.Unavailable {
+			available = append(available, nodes[i].Post)
+		}
+	}
+
+	if err := r.attachRepostedPosts(ctx, available, viewerID); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"context"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/api-gateway/graph/model"
+	"github.com/jupiterclapton/cenackle/services/api-gateway/internal/auth"
+)
+
+// Bornes de 'first' sur les listes de commentaires
+const (
+	defaultCommentPageSize = 20
+	maxCommentPageSize     = 100
+)
+
+// commentPageSize ramène 'first' dans [1, maxCommentPageSize] (0 ou négatif : 1)
+func commentPageSize(first *int) int {
+	if first == nil {
+		return defaultCommentPageSize
+	}
+	return min(max(*first, 1), maxCommentPageSize)
+}
+
+// listComments appelle ListComments (racines si parentID est vide) et construit la connexion GraphQL
+func (r *Resolver) listComments(ctx context.Context, postID, parentID string, first *int, after *string) (*model.CommentConnection, error) {
+	req := &postv1.ListCommentsRequest{
+		PostId:   postID,
+		ParentId: parentID,
+		Limit:    int32(commentPageSize(first)),
+	}
+	if user := auth.ForContext(ctx); user != nil {
+		req.ViewerId = user.ID
+	}
+	if after != nil {
+		req.PageToken = *after
+	}
+
+	resp, err := r.PostClient.ListComments(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*model.Comment, len(resp.Comments))
+	for i, c := range resp.Comments {
+		nodes[i] = mapProtoCommentToGraph(c)
+	}
+
+	pageInfo := &model.PageInfo{HasNextPage: resp.NextPageToken != ""}
+	if resp.NextPageToken != "" {
+		pageInfo.EndCursor = &resp.NextPageToken
+	}
+
+	return &model.CommentConnection{Nodes: nodes, PageInfo: pageInfo}, nil
+}
@@ -0,0 +1,26 @@
+package graph
+
+import "testing"
+
+func TestCommentPageSize(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name  string
+		first *int
+		want  int
+	}{
+		{"absent", nil, defaultCommentPageSize},
+		{"zéro", intPtr(0), 1},
+		{"négatif", intPtr(-5), 1},
+		{"dans les bornes", intPtr(42), 42},
+		{"au-delà du maximum", intPtr(1000), maxCommentPageSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commentPageSize(tt.first); got != tt.want {
+				t.Errorf("commentPageSize = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
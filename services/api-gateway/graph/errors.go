@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrUnauthenticated = errors.New("unauthorized: you must be logged in")
+	ErrModeratorsOnly  = errors.New("forbidden: moderators only")
+)
+
+// errorCodes : code gRPC des services -> extensions.code côté client GraphQL
+var errorCodes = map[codes.Code]string{
+	codes.InvalidArgument:    "BAD_USER_INPUT",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "FORBIDDEN",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "CONFLICT", // Ex : requête idempotente encore en cours, réessayer
+	codes.ResourceExhausted:  "RATE_LIMITED",
+	codes.Unavailable:        "SERVICE_UNAVAILABLE",
+	codes.DeadlineExceeded:   "SERVICE_UNAVAILABLE",
+}
+
+// ErrorPresenter ajoute extensions.code à chaque erreur, pour que le client réagisse au type
+// d'erreur plutôt qu'au texte. Le message métier des services est conservé ; une erreur
+// interne n'expose rien de plus que "internal error".
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+
+	switch {
+	case errors.Is(err, ErrUnauthenticated):
+		gqlErr.Extensions["code"] = "UNAUTHENTICATED"
+		return gqlErr
+	case errors.Is(err, ErrModeratorsOnly):
+		gqlErr.Extensions["code"] = "FORBIDDEN"
+		return gqlErr
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return gqlErr // Erreur de validation GraphQL (déjà codée par gqlgen) ou du Gateway lui-même
+	}
+
+	st := grpcErr.GRPCStatus()
+	code, known := errorCodes[st.Code()]
+	if !known {
+		code = "INTERNAL_SERVER_ERROR"
+		gqlErr.Message = "internal error"
+	} else {
+		gqlErr.Message = st.Message() // Sans le préfixe "rpc error: code = ... desc ="
+	}
+	gqlErr.Extensions["code"] = code
+	return gqlErr
+}
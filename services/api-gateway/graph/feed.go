@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"strconv"
+
+	"github.com/jupiterclapton/cenackle/services/api-gateway/graph/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// parseFeedCursor : le curseur du fil est l'offset renvoyé par le Feed Service (next_offset)
+func parseFeedCursor(after string) (int32, error) {
+	offset, err := strconv.ParseInt(after, 10, 32)
+	if err != nil || offset < 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid feed cursor")
+	}
+	return int32(offset), nil
+}
+
+// feedPageInfo : next_offset vaut 0 une fois la timeline épuisée
+func feedPageInfo(nextOffset int32) *model.PageInfo {
+	pageInfo := &model.PageInfo{HasNextPage: nextOffset != 0}
+	if pageInfo.HasNextPage {
+		cursor := strconv.Itoa(int(nextOffset))
+		pageInfo.EndCursor = &cursor
+	}
+	return pageInfo
+}
@@ -1,12 +1,15 @@
 package graph
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	feedv1 "github.com/jupiterclapton/cenackle/gen/feed/v1"
 	identityv1 "github.com/jupiterclapton/cenackle/gen/identity/v1"
 	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
 	"github.com/jupiterclapton/cenackle/services/api-gateway/graph/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // --- IDENTITY MAPPERS ---
@@ -35,11 +38,240 @@ func mapProtoUserToGraph(u *identityv1.User) *model.User {
 		IsActive:  u.IsActive,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
+
+		ShowSensitiveMedia: u.ShowSensitiveMedia,
 	}
 }
 
 // --- CONTENT MAPPERS ---
 
+// mapProtoThreadToGraph : chaque post du fil porte le fil entier, que le resolver 'thread'
+// renvoie tel quel au lieu de le redemander au Post Service.
+func mapProtoThreadToGraph(posts []*postv1.Post) []*model.Post {
+	thread := make([]*model.Post, len(posts))
+	for i, p := range posts {
+		thread[i] = mapProtoPostToGraph(p)
+	}
+	for _, p := range thread {
+		p.Thread = thread
+	}
+	return thread
+}
+
+// mapProtoPostToGraph convertit un Post gRPC en Post GraphQL.
+// Note: On ne mappe pas 'Author' ici, on laisse le resolver Post.Author le faire (à partir de AuthorID)
+func mapProtoPostToGraph(p *postv1.Post) *model.Post {
+	if p == nil {
+		return nil
+	}
+
+	post := &model.Post{
+		ID:       p.Id,
+		AuthorID: p.AuthorId, // ✅ On passe l'ID pour le resolver suivant
+		Content:  p.Content,
+
+		// gqlgen fera le Marshal vers JSON string tout seul.
+		CreatedAt: p.CreatedAt.AsTime(),
+		UpdatedAt: p.UpdatedAt.AsTime(),
+
+		Media:      mapProtoMediaToGraph(p.Media),
+		Entities:   mapProtoEntitiesToGraph(p.Entities),
+		Visibility: mapProtoVisibility(p.Visibility),
+
+		CommentsCount: int(p.CommentsCount),
+		RepostsCount:  int(p.RepostsCount),
+	}
+
+	if p.RepostedPostId != "" {
+		post.RepostedPostID = &p.RepostedPostId
+	}
+	if p.EditedAt != nil {
+		editedAt := p.EditedAt.AsTime()
+		post.EditedAt = &editedAt
+	}
+	post.HiddenAt = optionalTime(p.HiddenAt)
+	post.HeldForReview = p.Status == "held"
+	post.Pinned = p.Pinned
+	if p.Language != "" {
+		post.Language = &p.Language
+	}
+	post.ContentWarning = optionalString(p.ContentWarning)
+	if p.ThreadId != "" {
+		position := int(p.ThreadPosition)
+		post.ThreadID = &p.ThreadId
+		post.PositionInThread = &position
+	}
+
+	post.Poll = mapProtoPollToGraph(p.Poll)
+	post.LinkPreview = mapProtoLinkPreviewToGraph(p.LinkPreview)
+	post.LikesCount, post.Reactions = mapProtoReactionCounts(p.ReactionCounts)
+	if p.ViewerState != nil {
+		post.MyReaction = mapProtoReactionKind(p.ViewerState.Reaction)
+		post.IsLikedByMe = post.MyReaction != nil
+		post.IsBookmarkedByMe = p.ViewerState.Bookmarked
+	}
+	return post
+}
+
+func mapProtoRevisionToGraph(r *postv1.PostRevision) *model.PostRevision {
+	return &model.PostRevision{
+		ID:         r.Id,
+		Content:    r.Content,
+		Media:      mapProtoMediaToGraph(r.Media),
+		CreatedAt:  r.CreatedAt.AsTime(),
+		ReplacedAt: r.ReplacedAt.AsTime(),
+	}
+}
+
+// mapProtoPollToGraph : compteurs à null quand le Post Service les masque
+func mapProtoPollToGraph(p *postv1.Poll) *model.Poll {
+	if p == nil {
+		return nil
+	}
+
+	poll := &model.Poll{
+		Options:        make([]*model.PollOption, len(p.Options)),
+		MultipleChoice: p.MultipleChoice,
+		ClosesAt:       p.ClosesAt.AsTime(),
+		Closed:         p.Closed,
+		MyChoices:      make([]int, len(p.ViewerChoices)),
+	}
+	for i, o := range p.Options {
+		option := &model.PollOption{Index: i, Label: o.Label}
+		if p.ResultsVisible {
+			votes := int(o.VotesCount)
+			option.VotesCount = &votes
+		}
+		poll.Options[i] = option
+	}
+	for i, c := range p.ViewerChoices {
+		poll.MyChoices[i] = int(c)
+	}
+	if p.ResultsVisible {
+		voters := int(p.VotersCount)
+		poll.VotersCount = &voters
+	}
+	return poll
+}
+
+// mapProtoLinkPreviewToGraph : champs vides -> null
+func mapProtoLinkPreviewToGraph(l *postv1.LinkPreview) *model.LinkPreview {
+	if l == nil {
+		return nil
+	}
+	return &model.LinkPreview{
+		URL:         l.Url,
+		Title:       optionalString(l.Title),
+		Description: optionalString(l.Description),
+		ImageURL:    optionalString(l.ImageUrl),
+		SiteName:    optionalString(l.SiteName),
+	}
+}
+
+// mapProtoVisibility : "close_friends" -> CLOSE_FRIENDS (vide ou inconnu = PUBLIC)
+func mapProtoVisibility(v string) model.PostVisibility {
+	vis := model.PostVisibility(strings.ToUpper(v))
+	if !vis.IsValid() {
+		return model.PostVisibilityPublic
+	}
+	return vis
+}
+
+func mapProtoEntitiesToGraph(entities []*postv1.Entity) []*model.PostEntity {
+	res := make([]*model.PostEntity, 0, len(entities))
+	for _, e := range entities {
+		t := model.PostEntityType(strings.ToUpper(e.Type))
+		if !t.IsValid() {
+			continue
+		}
+		entity := &model.PostEntity{
+			Type:  t,
+			Text:  e.Text,
+			Start: int(e.Start),
+			End:   int(e.End),
+		}
+		if e.UserId != "" {
+			entity.UserID = &e.UserId
+		}
+		res = append(res, entity)
+	}
+	return res
+}
+
+// mapReactionPayload : réponse des mutations react/unreact
+func mapReactionPayload(postID, reaction string, counts map[string]int32) *model.ReactionPayload {
+	total, reactions := mapProtoReactionCounts(counts)
+	return &model.ReactionPayload{
+		PostID:     postID,
+		MyReaction: mapProtoReactionKind(reaction),
+		LikesCount: total,
+		Reactions:  reactions,
+	}
+}
+
+// mapProtoReactionCounts renvoie le total et le détail trié (ordre stable pour les clients)
+func mapProtoReactionCounts(counts map[string]int32) (int, []*model.ReactionCount) {
+	total := 0
+	res := make([]*model.ReactionCount, 0, len(counts))
+	for k, v := range counts {
+		kind := mapProtoReactionKind(k)
+		if kind == nil || v <= 0 {
+			continue // Type inconnu du schéma : ignoré plutôt que de casser la réponse
+		}
+		total += int(v)
+		res = append(res, &model.ReactionCount{Kind: *kind, Count: int(v)})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		return res[i].Kind < res[j].Kind
+	})
+	return total, res
+}
+
+// Enum GraphQL (LIKE) <-> valeur gRPC ("like")
+func mapReactionKindToProto(kind model.ReactionKind) string {
+	return strings.ToLower(string(kind))
+}
+
+func mapProtoReactionKind(kind string) *model.ReactionKind {
+	k := model.ReactionKind(strings.ToUpper(kind))
+	if !k.IsValid() {
+		return nil
+	}
+	return &k
+}
+
+// mapProtoCommentToGraph conserve l'aperçu des réponses pour le resolver Comment.replies
+func mapProtoCommentToGraph(c *postv1.Comment) *model.Comment {
+	if c == nil {
+		return nil
+	}
+
+	var parentID *string
+	if c.ParentId != "" {
+		parentID = &c.ParentId
+	}
+
+	replies := make([]*model.Comment, len(c.Replies))
+	for i, reply := range c.Replies {
+		replies[i] = mapProtoCommentToGraph(reply)
+	}
+
+	return &model.Comment{
+		ID:             c.Id,
+		PostID:         c.PostId,
+		ParentID:       parentID,
+		AuthorID:       c.AuthorId,
+		Content:        c.Content,
+		RepliesCount:   int(c.RepliesCount),
+		CreatedAt:      c.CreatedAt.AsTime(),
+		UpdatedAt:      c.UpdatedAt.AsTime(),
+		RepliesPreview: replies,
+	}
+}
+
 // Map pour les médias du Post Service
 func mapProtoMediaToGraph(protoMedia []*postv1.Media) []*model.Media {
 	if protoMedia == nil {
@@ -49,14 +281,46 @@ func mapProtoMediaToGraph(protoMedia []*postv1.Media) []*model.Media {
 	res := make([]*model.Media, len(protoMedia))
 	for i, m := range protoMedia {
 		res[i] = &model.Media{
-			ID:   m.Id,
-			URL:  m.Url,
-			Type: m.Type,
+			ID:          m.Id,
+			URL:         m.Url,
+			Type:        m.Type,
+			IsSensitive: m.Sensitive,
+			Blurhash:    optionalString(m.Blurhash),
+			Blurred:     m.Blurred,
 		}
 	}
 	return res
 }
 
+// mapGraphMediaInputToProto : médias déjà envoyés au stockage par le client
+func mapGraphMediaInputToProto(media []*model.MediaInput) []*postv1.Media {
+	res := make([]*postv1.Media, len(media))
+	for i, m := range media {
+		res[i] = &postv1.Media{Id: m.ID, Url: m.URL, Type: m.Type}
+		if m.IsSensitive != nil {
+			res[i].Sensitive = *m.IsSensitive
+		}
+		if m.Blurhash != nil {
+			res[i].Blurhash = *m.Blurhash
+		}
+	}
+	return res
+}
+
+func mapGraphPollInputToProto(p *model.PollInput) *postv1.PollInput {
+	if p == nil {
+		return nil
+	}
+	poll := &postv1.PollInput{Options: p.Options}
+	if p.MultipleChoice != nil {
+		poll.MultipleChoice = *p.MultipleChoice
+	}
+	if p.ClosesAt != nil {
+		poll.ClosesAt = timestamppb.New(*p.ClosesAt)
+	}
+	return poll
+}
+
 // Helper optionnel si on veut mapper un FeedItem directement (si besoin plus tard)
 func mapFeedItemToPostID(items []*feedv1.FeedItem) []string {
 	ids := make([]string, len(items))
@@ -65,3 +329,145 @@ func mapFeedItemToPostID(items []*feedv1.FeedItem) []string {
 	}
 	return ids
 }
+
+// mapProtoModerationCaseToGraph : "self_harm" -> SELF_HARM, champs absents -> null
+func mapProtoModerationCaseToGraph(c *postv1.ModerationCase) *model.ModerationCase {
+	mc := &model.ModerationCase{
+		ID:              c.Id,
+		TargetType:      model.ReportTarget(strings.ToUpper(c.TargetType)),
+		TargetID:        c.TargetId,
+		TargetAuthorID:  c.TargetAuthorId,
+		Status:          model.ModerationCaseStatus(strings.ToUpper(c.Status)),
+		ReportsCount:    int(c.ReportsCount),
+		Reasons:         make([]*model.ReportReasonCount, 0, len(c.ReasonCounts)),
+		ClaimedBy:       optionalString(c.ClaimedBy),
+		ClaimedAt:       optionalTime(c.ClaimedAt),
+		ResolvedBy:      optionalString(c.ResolvedBy),
+		ResolvedAt:      optionalTime(c.ResolvedAt),
+		FirstReportedAt: c.FirstReportedAt.AsTime(),
+		LastReportedAt:  c.LastReportedAt.AsTime(),
+		Reports:         make([]*model.Report, len(c.Reports)),
+		History:         make([]*model.ModerationDecision, len(c.History)),
+	}
+	if c.Resolution != "" {
+		action := model.ModerationAction(strings.ToUpper(c.Resolution))
+		mc.Resolution = &action
+	}
+
+	// Ordre stable : les motifs les plus cités d'abord
+	for reason, count := range c.ReasonCounts {
+		mc.Reasons = append(mc.Reasons, &model.ReportReasonCount{
+			Reason: model.ReportReason(strings.ToUpper(reason)),
+			Count:  int(count),
+		})
+	}
+	sort.Slice(mc.Reasons, func(i, j int) bool {
+		if mc.Reasons[i].Count != mc.Reasons[j].Count {
+			return mc.Reasons[i].Count > mc.Reasons[j].Count
+		}
+		return mc.Reasons[i].Reason < mc.Reasons[j].Reason
+	})
+
+	for i, r := range c.Reports {
+		mc.Reports[i] = &model.Report{
+			ID:         r.Id,
+			ReporterID: r.ReporterId,
+			Reason:     model.ReportReason(strings.ToUpper(r.Reason)),
+			Details:    optionalString(r.Details),
+			CreatedAt:  r.CreatedAt.AsTime(),
+		}
+	}
+	for i, d := range c.History {
+		mc.History[i] = &model.ModerationDecision{
+			ID:             d.Id,
+			CaseID:         d.CaseId,
+			ModeratorID:    d.ModeratorId,
+			Action:         model.ModerationAction(strings.ToUpper(d.Action)),
+			Note:           optionalString(d.Note),
+			SuspendedUntil: optionalTime(d.SuspendedUntil),
+			ContentWarning: optionalString(d.ContentWarning),
+			CreatedAt:      d.CreatedAt.AsTime(),
+		}
+	}
+	return mc
+}
+
+// optionalTime : timestamp absent -> null GraphQL
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// optionalString : chaîne vide -> null GraphQL
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// mapProtoBookmarkToGraph : collection vide -> null (non classé)
+func mapProtoBookmarkToGraph(b *postv1.Bookmark) *model.Bookmark {
+	bookmark := &model.Bookmark{
+		Post:        mapProtoPostToGraph(b.Post),
+		SavedAt:     b.CreatedAt.AsTime(),
+		Unavailable: b.Unavailable,
+	}
+	if b.CollectionId != "" {
+		bookmark.CollectionID = &b.CollectionId
+	}
+	return bookmark
+}
+
+func mapProtoBookmarkCollectionToGraph(c *postv1.BookmarkCollection) *model.BookmarkCollection {
+	return &model.BookmarkCollection{
+		ID:             c.Id,
+		Name:           c.Name,
+		Position:       int(c.Position),
+		BookmarksCount: int(c.BookmarksCount),
+		CreatedAt:      c.CreatedAt.AsTime(),
+		UpdatedAt:      c.UpdatedAt.AsTime(),
+	}
+}
+
+func mapProtoBookmarkCollectionsToGraph(collections []*postv1.BookmarkCollection) []*model.BookmarkCollection {
+	out := make([]*model.BookmarkCollection, len(collections))
+	for i, c := range collections {
+		out[i] = mapProtoBookmarkCollectionToGraph(c)
+	}
+	return out
+}
+
+// mapProtoPostAnalyticsToGraph : les compteurs int64 tiennent largement dans un Int GraphQL
+func mapProtoPostAnalyticsToGraph(a *postv1.PostAnalytics) *model.PostAnalytics {
+	if a == nil {
+		return nil
+	}
+
+	buckets := make([]*model.PostAnalyticsBucket, len(a.Buckets))
+	for i, b := range a.Buckets {
+		buckets[i] = &model.PostAnalyticsBucket{
+			Start:         b.Start.AsTime(),
+			Impressions:   int(b.Impressions),
+			Views:         int(b.Views),
+			UniqueViewers: int(b.UniqueViewers),
+			Reactions:     int(b.Reactions),
+			Comments:      int(b.Comments),
+		}
+	}
+
+	return &model.PostAnalytics{
+		PostID:        a.PostId,
+		Impressions:   int(a.Impressions),
+		Views:         int(a.Views),
+		UniqueViewers: int(a.UniqueViewers),
+		Reactions:     int(a.Reactions),
+		Comments:      int(a.Comments),
+		From:          a.From.AsTime(),
+		To:            a.To.AsTime(),
+		Buckets:       buckets,
+	}
+}
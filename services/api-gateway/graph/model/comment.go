@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Comment est écrit à la main (et non généré) : il transporte l'aperçu des réponses
+// renvoyé par ListComments, que le resolver 'replies' réutilise pour éviter un appel gRPC.
+type Comment struct {
+	ID           string    `json:"id"`
+	PostID       string    `json:"postId"`
+	ParentID     *string   `json:"parentId,omitempty"`
+	AuthorID     string    `json:"authorId"`
+	Content      string    `json:"content"`
+	RepliesCount int       `json:"repliesCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+
+	// RepliesPreview : premières réponses déjà chargées (nil = inconnu)
+	RepliesPreview []*Comment `json:"-"`
+}
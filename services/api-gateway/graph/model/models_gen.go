@@ -3,6 +3,10 @@
 package model
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
 	"time"
 )
 
@@ -13,51 +17,874 @@ type AuthPayload struct {
 	ExpiresIn    int    `json:"expiresIn"`
 }
 
+type Bookmark struct {
+	Post         *Post     `json:"post"`
+	CollectionID *string   `json:"collectionId,omitempty"`
+	SavedAt      time.Time `json:"savedAt"`
+	Unavailable  bool      `json:"unavailable"`
+}
+
+type BookmarkCollection struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Position       int       `json:"position"`
+	BookmarksCount int       `json:"bookmarksCount"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type BookmarkConnection struct {
+	Nodes    []*Bookmark `json:"nodes"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type CommentConnection struct {
+	Nodes    []*Comment `json:"nodes"`
+	PageInfo *PageInfo  `json:"pageInfo"`
+}
+
+type CreateCommentInput struct {
+	PostID   string  `json:"postId"`
+	ParentID *string `json:"parentId,omitempty"`
+	Content  string  `json:"content"`
+}
+
+type CreatePostInput struct {
+	Content        string          `json:"content"`
+	Media          []*MediaInput   `json:"media,omitempty"`
+	Visibility     *PostVisibility `json:"visibility,omitempty"`
+	Language       *string         `json:"language,omitempty"`
+	Poll           *PollInput      `json:"poll,omitempty"`
+	ContentWarning *string         `json:"contentWarning,omitempty"`
+}
+
+type CreateThreadInput struct {
+	Posts      []*ThreadPostInput `json:"posts"`
+	Visibility *PostVisibility    `json:"visibility,omitempty"`
+}
+
+type DataExport struct {
+	ID          string           `json:"id"`
+	Status      DataExportStatus `json:"status"`
+	RequestedAt time.Time        `json:"requestedAt"`
+}
+
+type LinkPreview struct {
+	URL         string  `json:"url"`
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageURL    *string `json:"imageUrl,omitempty"`
+	SiteName    *string `json:"siteName,omitempty"`
+}
+
 type LoginInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
 type Media struct {
-	ID   string `json:"id"`
-	URL  string `json:"url"`
-	Type string `json:"type"`
+	ID          string  `json:"id"`
+	URL         string  `json:"url"`
+	Type        string  `json:"type"`
+	IsSensitive bool    `json:"isSensitive"`
+	Blurhash    *string `json:"blurhash,omitempty"`
+	Blurred     bool    `json:"blurred"`
+}
+
+type MediaInput struct {
+	ID          string  `json:"id"`
+	URL         string  `json:"url"`
+	Type        string  `json:"type"`
+	IsSensitive *bool   `json:"isSensitive,omitempty"`
+	Blurhash    *string `json:"blurhash,omitempty"`
+}
+
+type ModerationCase struct {
+	ID              string                `json:"id"`
+	TargetType      ReportTarget          `json:"targetType"`
+	TargetID        string                `json:"targetId"`
+	TargetAuthorID  string                `json:"targetAuthorId"`
+	Status          ModerationCaseStatus  `json:"status"`
+	ReportsCount    int                   `json:"reportsCount"`
+	Reasons         []*ReportReasonCount  `json:"reasons"`
+	ClaimedBy       *string               `json:"claimedBy,omitempty"`
+	ClaimedAt       *time.Time            `json:"claimedAt,omitempty"`
+	Resolution      *ModerationAction     `json:"resolution,omitempty"`
+	ResolvedBy      *string               `json:"resolvedBy,omitempty"`
+	ResolvedAt      *time.Time            `json:"resolvedAt,omitempty"`
+	FirstReportedAt time.Time             `json:"firstReportedAt"`
+	LastReportedAt  time.Time             `json:"lastReportedAt"`
+	Reports         []*Report             `json:"reports"`
+	History         []*ModerationDecision `json:"history"`
+}
+
+type ModerationCaseConnection struct {
+	Nodes    []*ModerationCase `json:"nodes"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type ModerationDecision struct {
+	ID             string           `json:"id"`
+	CaseID         string           `json:"caseId"`
+	ModeratorID    string           `json:"moderatorId"`
+	Action         ModerationAction `json:"action"`
+	Note           *string          `json:"note,omitempty"`
+	SuspendedUntil *time.Time       `json:"suspendedUntil,omitempty"`
+	ContentWarning *string          `json:"contentWarning,omitempty"`
+	CreatedAt      time.Time        `json:"createdAt"`
 }
 
 type Mutation struct {
 }
 
+type PageInfo struct {
+	EndCursor   *string `json:"endCursor,omitempty"`
+	HasNextPage bool    `json:"hasNextPage"`
+}
+
+type Poll struct {
+	Options        []*PollOption `json:"options"`
+	MultipleChoice bool          `json:"multipleChoice"`
+	ClosesAt       time.Time     `json:"closesAt"`
+	Closed         bool          `json:"closed"`
+	VotersCount    *int          `json:"votersCount,omitempty"`
+	MyChoices      []int         `json:"myChoices"`
+}
+
+type PollInput struct {
+	Options        []string   `json:"options"`
+	MultipleChoice *bool      `json:"multipleChoice,omitempty"`
+	ClosesAt       *time.Time `json:"closesAt,omitempty"`
+}
+
+type PollOption struct {
+	Index      int    `json:"index"`
+	Label      string `json:"label"`
+	VotesCount *int   `json:"votesCount,omitempty"`
+}
+
 type Post struct {
-	ID        string    `json:"id"`
-	AuthorID  string    `json:"authorId"`
-	Content   string    `json:"content"`
-	Media     []*Media  `json:"media,omitempty"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
-	Author    *User     `json:"author"`
+	ID               string                  `json:"id"`
+	AuthorID         string                  `json:"authorId"`
+	Content          string                  `json:"content"`
+	ContentWarning   *string                 `json:"contentWarning,omitempty"`
+	Media            []*Media                `json:"media,omitempty"`
+	CreatedAt        time.Time               `json:"createdAt"`
+	UpdatedAt        time.Time               `json:"updatedAt"`
+	EditedAt         *time.Time              `json:"editedAt,omitempty"`
+	HiddenAt         *time.Time              `json:"hiddenAt,omitempty"`
+	HeldForReview    bool                    `json:"heldForReview"`
+	Pinned           bool                    `json:"pinned"`
+	Author           *User                   `json:"author"`
+	CommentsCount    int                     `json:"commentsCount"`
+	LikesCount       int                     `json:"likesCount"`
+	Reactions        []*ReactionCount        `json:"reactions"`
+	Visibility       PostVisibility          `json:"visibility"`
+	Language         *string                 `json:"language,omitempty"`
+	Entities         []*PostEntity           `json:"entities"`
+	RepostedPostID   *string                 `json:"repostedPostId,omitempty"`
+	RepostOf         *Post                   `json:"repostOf,omitempty"`
+	RepostsCount     int                     `json:"repostsCount"`
+	IsLikedByMe      bool                    `json:"isLikedByMe"`
+	MyReaction       *ReactionKind           `json:"myReaction,omitempty"`
+	IsBookmarkedByMe bool                    `json:"isBookmarkedByMe"`
+	Comments         *CommentConnection      `json:"comments"`
+	Revisions        *PostRevisionConnection `json:"revisions"`
+	Poll             *Poll                   `json:"poll,omitempty"`
+	LinkPreview      *LinkPreview            `json:"linkPreview,omitempty"`
+	ThreadID         *string                 `json:"threadId,omitempty"`
+	PositionInThread *int                    `json:"positionInThread,omitempty"`
+	Thread           []*Post                 `json:"thread,omitempty"`
+}
+
+type PostAnalytics struct {
+	PostID        string                 `json:"postId"`
+	Impressions   int                    `json:"impressions"`
+	Views         int                    `json:"views"`
+	UniqueViewers int                    `json:"uniqueViewers"`
+	Reactions     int                    `json:"reactions"`
+	Comments      int                    `json:"comments"`
+	From          time.Time              `json:"from"`
+	To            time.Time              `json:"to"`
+	Buckets       []*PostAnalyticsBucket `json:"buckets"`
+}
+
+type PostAnalyticsBucket struct {
+	Start         time.Time `json:"start"`
+	Impressions   int       `json:"impressions"`
+	Views         int       `json:"views"`
+	UniqueViewers int       `json:"uniqueViewers"`
+	Reactions     int       `json:"reactions"`
+	Comments      int       `json:"comments"`
+}
+
+type PostConnection struct {
+	Nodes    []*Post   `json:"nodes"`
+	PageInfo *PageInfo `json:"pageInfo"`
+}
+
+type PostEntity struct {
+	Type   PostEntityType `json:"type"`
+	Text   string         `json:"text"`
+	Start  int            `json:"start"`
+	End    int            `json:"end"`
+	UserID *string        `json:"userId,omitempty"`
+}
+
+type PostEventInput struct {
+	PostID string        `json:"postId"`
+	Kind   PostEventKind `json:"kind"`
+}
+
+type PostRevision struct {
+	ID         string    `json:"id"`
+	Content    string    `json:"content"`
+	Media      []*Media  `json:"media,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	ReplacedAt time.Time `json:"replacedAt"`
+}
+
+type PostRevisionConnection struct {
+	Nodes    []*PostRevision `json:"nodes"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+}
+
+type PostSearchConnection struct {
+	Nodes    []*PostSearchResult `json:"nodes"`
+	PageInfo *PageInfo           `json:"pageInfo"`
+}
+
+type PostSearchFilter struct {
+	AuthorID  *string    `json:"authorId,omitempty"`
+	MediaType *string    `json:"mediaType,omitempty"`
+	Since     *time.Time `json:"since,omitempty"`
+	Until     *time.Time `json:"until,omitempty"`
+}
+
+type PostSearchResult struct {
+	Post    *Post   `json:"post"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
 }
 
 type Query struct {
 }
 
+type ReactionCount struct {
+	Kind  ReactionKind `json:"kind"`
+	Count int          `json:"count"`
+}
+
+type ReactionPayload struct {
+	PostID     string           `json:"postId"`
+	MyReaction *ReactionKind    `json:"myReaction,omitempty"`
+	LikesCount int              `json:"likesCount"`
+	Reactions  []*ReactionCount `json:"reactions"`
+}
+
 type RegisterInput struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Username string `json:"username"`
-	FullName string `json:"fullName"`
+	Email          string `json:"email"`
+	Password       string `json:"password"`
+	Username       string `json:"username"`
+	FullName       string `json:"fullName"`
+	Challenge      string `json:"challenge"`
+	ChallengeNonce string `json:"challengeNonce"`
+}
+
+type RegistrationChallenge struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+type Report struct {
+	ID         string       `json:"id"`
+	ReporterID string       `json:"reporterId"`
+	Reason     ReportReason `json:"reason"`
+	Details    *string      `json:"details,omitempty"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+type ReportContentInput struct {
+	TargetType ReportTarget `json:"targetType"`
+	TargetID   string       `json:"targetId"`
+	Reason     ReportReason `json:"reason"`
+	Details    *string      `json:"details,omitempty"`
+}
+
+type ReportReasonCount struct {
+	Reason ReportReason `json:"reason"`
+	Count  int          `json:"count"`
+}
+
+type ResolveModerationCaseInput struct {
+	CaseID         string           `json:"caseId"`
+	Action         ModerationAction `json:"action"`
+	Note           *string          `json:"note,omitempty"`
+	SuspendUntil   *time.Time       `json:"suspendUntil,omitempty"`
+	ContentWarning *string          `json:"contentWarning,omitempty"`
+}
+
+type ThreadPostInput struct {
+	Content        string        `json:"content"`
+	Media          []*MediaInput `json:"media,omitempty"`
+	Language       *string       `json:"language,omitempty"`
+	ContentWarning *string       `json:"contentWarning,omitempty"`
+}
+
+type UpdatePostInput struct {
+	ID             string        `json:"id"`
+	Content        string        `json:"content"`
+	Media          []*MediaInput `json:"media,omitempty"`
+	ContentWarning *string       `json:"contentWarning,omitempty"`
 }
 
 type UpdateProfileInput struct {
-	FullName *string `json:"fullName,omitempty"`
-	Email    *string `json:"email,omitempty"`
+	FullName           *string `json:"fullName,omitempty"`
+	Email              *string `json:"email,omitempty"`
+	ShowSensitiveMedia *bool   `json:"showSensitiveMedia,omitempty"`
 }
 
 type User struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Username  string    `json:"username"`
-	FullName  string    `json:"fullName"`
-	IsActive  bool      `json:"isActive"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID                 string    `json:"id"`
+	Email              string    `json:"email"`
+	Username           string    `json:"username"`
+	FullName           string    `json:"fullName"`
+	IsActive           bool      `json:"isActive"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+	ShowSensitiveMedia bool      `json:"showSensitiveMedia"`
+}
+
+type DataExportStatus string
+
+const (
+	DataExportStatusPending DataExportStatus = "PENDING"
+	DataExportStatusReady   DataExportStatus = "READY"
+	DataExportStatusFailed  DataExportStatus = "FAILED"
+)
+
+var AllDataExportStatus = []DataExportStatus{
+	DataExportStatusPending,
+	DataExportStatusReady,
+	DataExportStatusFailed,
+}
+
+func (e DataExportStatus) IsValid() bool {
+	switch e {
+	case DataExportStatusPending, DataExportStatusReady, DataExportStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e DataExportStatus) String() string {
+	return string(e)
+}
+
+func (e *DataExportStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DataExportStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DataExportStatus", str)
+	}
+	return nil
+}
+
+func (e DataExportStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DataExportStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DataExportStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ModerationAction string
+
+const (
+	ModerationActionDismiss       ModerationAction = "DISMISS"
+	ModerationActionHide          ModerationAction = "HIDE"
+	ModerationActionDelete        ModerationAction = "DELETE"
+	ModerationActionWarn          ModerationAction = "WARN"
+	ModerationActionSuspend       ModerationAction = "SUSPEND"
+	ModerationActionMarkSensitive ModerationAction = "MARK_SENSITIVE"
+)
+
+var AllModerationAction = []ModerationAction{
+	ModerationActionDismiss,
+	ModerationActionHide,
+	ModerationActionDelete,
+	ModerationActionWarn,
+	ModerationActionSuspend,
+	ModerationActionMarkSensitive,
+}
+
+func (e ModerationAction) IsValid() bool {
+	switch e {
+	case ModerationActionDismiss, ModerationActionHide, ModerationActionDelete, ModerationActionWarn, ModerationActionSuspend, ModerationActionMarkSensitive:
+		return true
+	}
+	return false
+}
+
+func (e ModerationAction) String() string {
+	return string(e)
+}
+
+func (e *ModerationAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModerationAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModerationAction", str)
+	}
+	return nil
+}
+
+func (e ModerationAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ModerationAction) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ModerationAction) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ModerationCaseStatus string
+
+const (
+	ModerationCaseStatusOpen     ModerationCaseStatus = "OPEN"
+	ModerationCaseStatusClaimed  ModerationCaseStatus = "CLAIMED"
+	ModerationCaseStatusResolved ModerationCaseStatus = "RESOLVED"
+)
+
+var AllModerationCaseStatus = []ModerationCaseStatus{
+	ModerationCaseStatusOpen,
+	ModerationCaseStatusClaimed,
+	ModerationCaseStatusResolved,
+}
+
+func (e ModerationCaseStatus) IsValid() bool {
+	switch e {
+	case ModerationCaseStatusOpen, ModerationCaseStatusClaimed, ModerationCaseStatusResolved:
+		return true
+	}
+	return false
+}
+
+func (e ModerationCaseStatus) String() string {
+	return string(e)
+}
+
+func (e *ModerationCaseStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModerationCaseStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModerationCaseStatus", str)
+	}
+	return nil
+}
+
+func (e ModerationCaseStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ModerationCaseStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ModerationCaseStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostEntityType string
+
+const (
+	PostEntityTypeHashtag PostEntityType = "HASHTAG"
+	PostEntityTypeMention PostEntityType = "MENTION"
+)
+
+var AllPostEntityType = []PostEntityType{
+	PostEntityTypeHashtag,
+	PostEntityTypeMention,
+}
+
+func (e PostEntityType) IsValid() bool {
+	switch e {
+	case PostEntityTypeHashtag, PostEntityTypeMention:
+		return true
+	}
+	return false
+}
+
+func (e PostEntityType) String() string {
+	return string(e)
+}
+
+func (e *PostEntityType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostEntityType", str)
+	}
+	return nil
+}
+
+func (e PostEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostEntityType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostEntityType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostEventKind string
+
+const (
+	PostEventKindImpression PostEventKind = "IMPRESSION"
+	PostEventKindView       PostEventKind = "VIEW"
+)
+
+var AllPostEventKind = []PostEventKind{
+	PostEventKindImpression,
+	PostEventKindView,
+}
+
+func (e PostEventKind) IsValid() bool {
+	switch e {
+	case PostEventKindImpression, PostEventKindView:
+		return true
+	}
+	return false
+}
+
+func (e PostEventKind) String() string {
+	return string(e)
+}
+
+func (e *PostEventKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostEventKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostEventKind", str)
+	}
+	return nil
+}
+
+func (e PostEventKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostEventKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostEventKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostVisibility string
+
+const (
+	PostVisibilityPublic       PostVisibility = "PUBLIC"
+	PostVisibilityFollowers    PostVisibility = "FOLLOWERS"
+	PostVisibilityMentioned    PostVisibility = "MENTIONED"
+	PostVisibilityCloseFriends PostVisibility = "CLOSE_FRIENDS"
+)
+
+var AllPostVisibility = []PostVisibility{
+	PostVisibilityPublic,
+	PostVisibilityFollowers,
+	PostVisibilityMentioned,
+	PostVisibilityCloseFriends,
+}
+
+func (e PostVisibility) IsValid() bool {
+	switch e {
+	case PostVisibilityPublic, PostVisibilityFollowers, PostVisibilityMentioned, PostVisibilityCloseFriends:
+		return true
+	}
+	return false
+}
+
+func (e PostVisibility) String() string {
+	return string(e)
+}
+
+func (e *PostVisibility) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostVisibility(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostVisibility", str)
+	}
+	return nil
+}
+
+func (e PostVisibility) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostVisibility) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostVisibility) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReactionKind string
+
+const (
+	ReactionKindLike  ReactionKind = "LIKE"
+	ReactionKindLove  ReactionKind = "LOVE"
+	ReactionKindHaha  ReactionKind = "HAHA"
+	ReactionKindWow   ReactionKind = "WOW"
+	ReactionKindSad   ReactionKind = "SAD"
+	ReactionKindAngry ReactionKind = "ANGRY"
+)
+
+var AllReactionKind = []ReactionKind{
+	ReactionKindLike,
+	ReactionKindLove,
+	ReactionKindHaha,
+	ReactionKindWow,
+	ReactionKindSad,
+	ReactionKindAngry,
+}
+
+func (e ReactionKind) IsValid() bool {
+	switch e {
+	case ReactionKindLike, ReactionKindLove, ReactionKindHaha, ReactionKindWow, ReactionKindSad, ReactionKindAngry:
+		return true
+	}
+	return false
+}
+
+func (e ReactionKind) String() string {
+	return string(e)
+}
+
+func (e *ReactionKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReactionKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReactionKind", str)
+	}
+	return nil
+}
+
+func (e ReactionKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReactionKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReactionKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReportReason string
+
+const (
+	ReportReasonSpam           ReportReason = "SPAM"
+	ReportReasonHarassment     ReportReason = "HARASSMENT"
+	ReportReasonHate           ReportReason = "HATE"
+	ReportReasonViolence       ReportReason = "VIOLENCE"
+	ReportReasonNudity         ReportReason = "NUDITY"
+	ReportReasonMisinformation ReportReason = "MISINFORMATION"
+	ReportReasonSelfHarm       ReportReason = "SELF_HARM"
+	ReportReasonOther          ReportReason = "OTHER"
+)
+
+var AllReportReason = []ReportReason{
+	ReportReasonSpam,
+	ReportReasonHarassment,
+	ReportReasonHate,
+	ReportReasonViolence,
+	ReportReasonNudity,
+	ReportReasonMisinformation,
+	ReportReasonSelfHarm,
+	ReportReasonOther,
+}
+
+func (e ReportReason) IsValid() bool {
+	switch e {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHate, ReportReasonViolence, ReportReasonNudity, ReportReasonMisinformation, ReportReasonSelfHarm, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+func (e ReportReason) String() string {
+	return string(e)
+}
+
+func (e *ReportReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportReason", str)
+	}
+	return nil
+}
+
+func (e ReportReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReportReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReportReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReportTarget string
+
+const (
+	ReportTargetPost    ReportTarget = "POST"
+	ReportTargetComment ReportTarget = "COMMENT"
+	ReportTargetUser    ReportTarget = "USER"
+)
+
+var AllReportTarget = []ReportTarget{
+	ReportTargetPost,
+	ReportTargetComment,
+	ReportTargetUser,
+}
+
+func (e ReportTarget) IsValid() bool {
+	switch e {
+	case ReportTargetPost, ReportTargetComment, ReportTargetUser:
+		return true
+	}
+	return false
+}
+
+func (e ReportTarget) String() string {
+	return string(e)
+}
+
+func (e *ReportTarget) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportTarget(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportTarget", str)
+	}
+	return nil
+}
+
+func (e ReportTarget) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReportTarget) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReportTarget) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
@@ -0,0 +1,20 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/jupiterclapton/cenackle/services/api-gateway/internal/auth"
+)
+
+// requireModerator : la file de modération est réservée aux rôles "moderator" et "admin"
+// (le Post Service fait confiance au Gateway pour ce contrôle)
+func requireModerator(ctx context.Context) (*auth.User, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+	if !user.CanModerate() {
+		return nil, ErrModeratorsOnly
+	}
+	return user, nil
+}
@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"context"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/api-gateway/graph/model"
+	"github.com/jupiterclapton/cenackle/services/api-gateway/internal/auth"
+)
+
+// attachRepostedPosts résout 'repostOf' pour toute une page en UN SEUL appel GetPosts (pas de N+1).
+// Un original supprimé est simplement absent de la réponse : repostOf reste null.
+func (r *Resolver) attachRepostedPosts(ctx context.Context, posts []*model.Post, viewerID string) error {
+	ids := make([]string, 0, len(posts))
+	seen := make(map[string]bool, len(posts))
+	for _, p := range posts {
+		if p.RepostedPostID == nil || seen[*p.RepostedPostID] {
+			continue
+		}
+		seen[*p.RepostedPostID] = true
+		ids = append(ids, *p.RepostedPostID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	resp, err := r.PostClient.GetPosts(ctx, &postv1.GetPostsRequest{
+		PostIds:            ids,
+		ViewerId:           viewerID,
+		ShowSensitiveMedia: auth.ForContext(ctx).WantsSensitiveMedia(),
+	})
+	if err != nil {
+		return err
+	}
+
+	originals := make(map[string]*model.Post, len(resp.Posts))
+	for _, p := range resp.Posts {
+		originals[p.Id] = mapProtoPostToGraph(p)
+	}
+	for _, p := range posts {
+		if p.RepostedPostID != nil {
+			p.RepostOf = originals[*p.RepostedPostID]
+		}
+	}
+	return nil
+}
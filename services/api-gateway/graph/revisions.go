@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"context"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/api-gateway/graph/model"
+	"github.com/jupiterclapton/cenackle/services/api-gateway/internal/auth"
+)
+
+// listRevisions appelle ListPostRevisions et construit la connexion GraphQL
+func (r *Resolver) listRevisions(ctx context.Context, postID, viewerID string, first *int, after *string) (*model.PostRevisionConnection, error) {
+	req := &postv1.ListPostRevisionsRequest{
+		PostId:             postID,
+		ViewerId:           viewerID,
+		Limit:              20,
+		ShowSensitiveMedia: auth.ForContext(ctx).WantsSensitiveMedia(),
+	}
+	if first != nil {
+		req.Limit = int32(*first)
+	}
+	if after != nil {
+		req.PageToken = *after
+	}
+
+	resp, err := r.PostClient.ListPostRevisions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*model.PostRevision, len(resp.Revisions))
+	for i, rev := range resp.Revisions {
+		nodes[i] = mapProtoRevisionToGraph(rev)
+	}
+
+	pageInfo := &model.PageInfo{HasNextPage: resp.NextPageToken != ""}
+	if resp.NextPageToken != "" {
+		pageInfo.EndCursor = &resp.NextPageToken
+	}
+
+	return &model.PostRevisionConnection{Nodes: nodes, PageInfo: pageInfo}, nil
+}
@@ -9,14 +9,53 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	feedv1 "github.com/jupiterclapton/cenackle/gen/feed/v1"
 	identityv1 "github.com/jupiterclapton/cenackle/gen/identity/v1"
 	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
 	"github.com/jupiterclapton/cenackle/services/api-gateway/graph/model"
 	"github.com/jupiterclapton/cenackle/services/api-gateway/internal/auth"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Author is the resolver for the author field.
+func (r *commentResolver) Author(ctx context.Context, obj *model.Comment) (*model.User, error) {
+	resp, err := r.IdentityClient.GetUser(ctx, &identityv1.GetUserRequest{
+		UserId: obj.AuthorID,
+	})
+	if err != nil {
+		// Même stratégie que Post.author : on ne casse pas tout le fil pour un auteur introuvable
+		return &model.User{ID: obj.AuthorID, Username: "Unknown"}, nil
+	}
+	return mapProtoUserToGraph(resp.User), nil
+}
+
+// Replies is the resolver for the replies field.
+func (r *commentResolver) Replies(ctx context.Context, obj *model.Comment, first *int, after *string) (*model.CommentConnection, error) {
+	// Une réponse n'a jamais de réponses (un seul niveau)
+	if obj.ParentID != nil || obj.RepliesCount == 0 {
+		return &model.CommentConnection{Nodes: []*model.Comment{}, PageInfo: &model.PageInfo{}}, nil
+	}
+
+	// Première page : on réutilise l'aperçu déjà chargé si il suffit (évite un appel gRPC par commentaire)
+	limit := commentPageSize(first)
+	preview := obj.RepliesPreview
+	if after == nil && preview != nil && (len(preview) >= limit || len(preview) == obj.RepliesCount) {
+		nodes := preview[:min(limit, len(preview))]
+		pageInfo := &model.PageInfo{HasNextPage: obj.RepliesCount > len(nodes)}
+		if pageInfo.HasNextPage {
+			// Le curseur est la date du dernier élément (même format que le Post Service)
+			cursor := nodes[len(nodes)-1].CreatedAt.Format(time.RFC3339Nano)
+			pageInfo.EndCursor = &cursor
+		}
+		return &model.CommentConnection{Nodes: nodes, PageInfo: pageInfo}, nil
+	}
+
+	return r.listComments(ctx, obj.PostID, obj.ID, first, after)
+}
+
 // Register is the resolver for the register field.
 func (r *mutationResolver) Register(ctx context.Context, input model.RegisterInput) (*model.AuthPayload, error) {
 	// 1. Appel gRPC vers Identity Service
@@ -25,6 +64,11 @@ func (r *mutationResolver) Register(ctx context.Context, input model.RegisterInp
 		Username: input.Username,
 		Password: input.Password,
 		FullName: input.FullName,
+
+		// Anti-bot : le défi est lié à l'IP pour laquelle il a été émis
+		IpAddress:      auth.ClientIPForContext(ctx),
+		Challenge:      input.Challenge,
+		ChallengeNonce: input.ChallengeNonce,
 	})
 	if err != nil {
 		return nil, err // TODO: Mapper les erreurs gRPC vers des erreurs GraphQL propres
@@ -103,7 +147,7 @@ func (r *mutationResolver) UpdateProfile(ctx context.Context, input model.Update
 	// 1. Récupération de l'ID utilisateur depuis le contexte (Middleware)
 	userID := auth.ForContext(ctx)
 	if userID == nil {
-		return nil, errors.New("unauthorized: you must be logged in")
+		return nil, ErrUnauthenticated
 	}
 
 	// 2. Appel gRPC
@@ -112,6 +156,8 @@ func (r *mutationResolver) UpdateProfile(ctx context.Context, input model.Update
 		UserId:   userID.ID,
 		FullName: input.FullName,
 		Email:    input.Email,
+
+		ShowSensitiveMedia: input.ShowSensitiveMedia,
 	})
 	if err != nil {
 		return nil, err
@@ -120,6 +166,541 @@ func (r *mutationResolver) UpdateProfile(ctx context.Context, input model.Update
 	return mapProtoUserToGraph(resp.User), nil
 }
 
+// SetFeedLanguages is the resolver for the setFeedLanguages field.
+func (r *mutationResolver) SetFeedLanguages(ctx context.Context, languages []string) ([]string, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.FeedClient.SetAcceptedLanguages(ctx, &feedv1.SetAcceptedLanguagesRequest{UserId: user.ID, Languages: languages})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Languages, nil
+}
+
+// RequestDataExport is the resolver for the requestDataExport field.
+func (r *mutationResolver) RequestDataExport(ctx context.Context) (*model.DataExport, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.IdentityClient.RequestDataExport(ctx, &identityv1.RequestDataExportRequest{UserId: user.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &model.DataExport{
+		ID:          resp.ExportId,
+		Status:      model.DataExportStatus(strings.ToUpper(resp.Status)),
+		RequestedAt: resp.RequestedAt.AsTime(),
+	}, nil
+}
+
+// CreatePost is the resolver for the createPost field.
+func (r *mutationResolver) CreatePost(ctx context.Context, input model.CreatePostInput) (*model.Post, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	req := &postv1.CreatePostRequest{
+		UserId:         user.ID,
+		Content:        input.Content,
+		Media:          mapGraphMediaInputToProto(input.Media),
+		Poll:           mapGraphPollInputToProto(input.Poll),
+		IdempotencyKey: auth.IdempotencyKeyForContext(ctx),
+	}
+	if input.Visibility != nil {
+		req.Visibility = strings.ToLower(string(*input.Visibility))
+	}
+	if input.Language != nil {
+		req.Language = *input.Language
+	}
+	if input.ContentWarning != nil {
+		req.ContentWarning = *input.ContentWarning
+	}
+
+	resp, err := r.PostClient.CreatePost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoPostToGraph(resp.Post), nil
+}
+
+// CreateThread is the resolver for the createThread field.
+func (r *mutationResolver) CreateThread(ctx context.Context, input model.CreateThreadInput) ([]*model.Post, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	req := &postv1.CreateThreadRequest{
+		UserId:         user.ID,
+		Posts:          make([]*postv1.ThreadPostInput, len(input.Posts)),
+		IdempotencyKey: auth.IdempotencyKeyForContext(ctx),
+	}
+	for i, p := range input.Posts {
+		in := &postv1.ThreadPostInput{
+			Content: p.Content,
+			Media:   mapGraphMediaInputToProto(p.Media),
+		}
+		if p.Language != nil {
+			in.Language = *p.Language
+		}
+		if p.ContentWarning != nil {
+			in.ContentWarning = *p.ContentWarning
+		}
+		req.Posts[i] = in
+	}
+	if input.Visibility != nil {
+		req.Visibility = strings.ToLower(string(*input.Visibility))
+	}
+
+	resp, err := r.PostClient.CreateThread(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoThreadToGraph(resp.Posts), nil
+}
+
+// UpdatePost is the resolver for the updatePost field.
+func (r *mutationResolver) UpdatePost(ctx context.Context, input model.UpdatePostInput) (*model.Post, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	req := &postv1.UpdatePostRequest{
+		PostId:         input.ID,
+		UserId:         user.ID,
+		Content:        input.Content,
+		Media:          mapGraphMediaInputToProto(input.Media),
+		IdempotencyKey: auth.IdempotencyKeyForContext(ctx),
+	}
+	if input.ContentWarning != nil {
+		req.ContentWarning = *input.ContentWarning
+	}
+
+	resp, err := r.PostClient.UpdatePost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	post := mapProtoPostToGraph(resp.Post)
+	if err := r.attachRepostedPosts(ctx, []*model.Post{post}, user.ID); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// DeletePost is the resolver for the deletePost field.
+func (r *mutationResolver) DeletePost(ctx context.Context, id string) (bool, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return false, ErrUnauthenticated
+	}
+
+	_, err := r.PostClient.DeletePost(ctx, &postv1.DeletePostRequest{
+		PostId:         id,
+		UserId:         user.ID,
+		IdempotencyKey: auth.IdempotencyKeyForContext(ctx),
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateComment is the resolver for the createComment field.
+func (r *mutationResolver) CreateComment(ctx context.Context, input model.CreateCommentInput) (*model.Comment, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	req := &postv1.CreateCommentRequest{
+		PostId:  input.PostID,
+		UserId:  user.ID,
+		Content: input.Content,
+	}
+	if input.ParentID != nil {
+		req.ParentId = *input.ParentID
+	}
+
+	resp, err := r.PostClient.CreateComment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoCommentToGraph(resp.Comment), nil
+}
+
+// EditComment is the resolver for the editComment field.
+func (r *mutationResolver) EditComment(ctx context.Context, id string, content string) (*model.Comment, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.PostClient.EditComment(ctx, &postv1.EditCommentRequest{
+		CommentId: id,
+		UserId:    user.ID,
+		Content:   content,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoCommentToGraph(resp.Comment), nil
+}
+
+// DeleteComment is the resolver for the deleteComment field.
+func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (bool, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return false, ErrUnauthenticated
+	}
+
+	_, err := r.PostClient.DeleteComment(ctx, &postv1.DeleteCommentRequest{
+		CommentId: id,
+		UserId:    user.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Repost is the resolver for the repost field.
+func (r *mutationResolver) Repost(ctx context.Context, postID string, content *string) (*model.Post, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	req := &postv1.CreatePostRequest{
+		UserId:         user.ID,
+		RepostedPostId: postID,
+		IdempotencyKey: auth.IdempotencyKeyForContext(ctx),
+	}
+	if content != nil {
+		req.Content = *content
+	}
+
+	resp, err := r.PostClient.CreatePost(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	post := mapProtoPostToGraph(resp.Post)
+	if err := r.attachRepostedPosts(ctx, []*model.Post{post}, user.ID); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// UndoRepost is the resolver for the undoRepost field.
+func (r *mutationResolver) UndoRepost(ctx context.Context, postID string) (bool, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return false, ErrUnauthenticated
+	}
+
+	_, err := r.PostClient.UndoRepost(ctx, &postv1.UndoRepostRequest{
+		PostId: postID,
+		UserId: user.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PinPost is the resolver for the pinPost field.
+func (r *mutationResolver) PinPost(ctx context.Context, id string) (*model.Post, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.PostClient.PinPost(ctx, &postv1.PinPostRequest{
+		PostId: id,
+		UserId: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoPostToGraph(resp.Post), nil
+}
+
+// UnpinPost is the resolver for the unpinPost field.
+func (r *mutationResolver) UnpinPost(ctx context.Context, id string) (*model.Post, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.PostClient.UnpinPost(ctx, &postv1.UnpinPostRequest{
+		PostId: id,
+		UserId: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoPostToGraph(resp.Post), nil
+}
+
+// React is the resolver for the react field.
+func (r *mutationResolver) React(ctx context.Context, postID string, kind *model.ReactionKind) (*model.ReactionPayload, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	k := model.ReactionKindLike
+	if kind != nil {
+		k = *kind
+	}
+
+	resp, err := r.PostClient.React(ctx, &postv1.ReactRequest{
+		PostId: postID,
+		UserId: user.ID,
+		Kind:   mapReactionKindToProto(k),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapReactionPayload(postID, resp.Reaction, resp.ReactionCounts), nil
+}
+
+// Unreact is the resolver for the unreact field.
+func (r *mutationResolver) Unreact(ctx context.Context, postID string) (*model.ReactionPayload, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.PostClient.Unreact(ctx, &postv1.UnreactRequest{
+		PostId: postID,
+		UserId: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapReactionPayload(postID, "", resp.ReactionCounts), nil
+}
+
+// VotePoll is the resolver for the votePoll field.
+func (r *mutationResolver) VotePoll(ctx context.Context, postID string, choices []int) (*model.Poll, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	req := &postv1.VotePollRequest{
+		PostId:  postID,
+		UserId:  user.ID,
+		Choices: make([]int32, len(choices)),
+	}
+	for i, c := range choices {
+		req.Choices[i] = int32(c)
+	}
+
+	resp, err := r.PostClient.VotePoll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoPollToGraph(resp.Poll), nil
+}
+
+// Bookmark is the resolver for the bookmark field.
+func (r *mutationResolver) Bookmark(ctx context.Context, postID string, collectionID *string) (*model.Bookmark, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	req := &postv1.BookmarkRequest{UserId: user.ID, PostId: postID}
+	if collectionID != nil {
+		req.CollectionId = *collectionID
+	}
+
+	resp, err := r.PostClient.Bookmark(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := r.bookmarksFromProto(ctx, []*postv1.Bookmark{resp.Bookmark}, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+// Unbookmark is the resolver for the unbookmark field.
+func (r *mutationResolver) Unbookmark(ctx context.Context, postID string) (bool, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return false, ErrUnauthenticated
+	}
+
+	_, err := r.PostClient.Unbookmark(ctx, &postv1.UnbookmarkRequest{UserId: user.ID, PostId: postID})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateBookmarkCollection is the resolver for the createBookmarkCollection field.
+func (r *mutationResolver) CreateBookmarkCollection(ctx context.Context, name string) (*model.BookmarkCollection, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.PostClient.CreateBookmarkCollection(ctx, &postv1.CreateBookmarkCollectionRequest{UserId: user.ID, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoBookmarkCollectionToGraph(resp.Collection), nil
+}
+
+// RenameBookmarkCollection is the resolver for the renameBookmarkCollection field.
+func (r *mutationResolver) RenameBookmarkCollection(ctx context.Context, id string, name string) (*model.BookmarkCollection, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.PostClient.RenameBookmarkCollection(ctx, &postv1.RenameBookmarkCollectionRequest{
+		UserId:       user.ID,
+		CollectionId: id,
+		Name:         name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoBookmarkCollectionToGraph(resp.Collection), nil
+}
+
+// ReorderBookmarkCollections is the resolver for the reorderBookmarkCollections field.
+func (r *mutationResolver) ReorderBookmarkCollections(ctx context.Context, ids []string) ([]*model.BookmarkCollection, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.PostClient.ReorderBookmarkCollections(ctx, &postv1.ReorderBookmarkCollectionsRequest{UserId: user.ID, CollectionIds: ids})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoBookmarkCollectionsToGraph(resp.Collections), nil
+}
+
+// DeleteBookmarkCollection is the resolver for the deleteBookmarkCollection field.
+func (r *mutationResolver) DeleteBookmarkCollection(ctx context.Context, id string) (bool, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return false, ErrUnauthenticated
+	}
+
+	_, err := r.PostClient.DeleteBookmarkCollection(ctx, &postv1.DeleteBookmarkCollectionRequest{UserId: user.ID, CollectionId: id})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TrackPostEvents is the resolver for the trackPostEvents field.
+func (r *mutationResolver) TrackPostEvents(ctx context.Context, events []*model.PostEventInput) (bool, error) {
+	viewerID := ""
+	if user := auth.ForContext(ctx); user != nil {
+		viewerID = user.ID
+	}
+
+	req := &postv1.RecordPostEventsRequest{
+		ViewerId:  viewerID,
+		SessionId: auth.ClientIPForContext(ctx), // Dédoublonne les lecteurs anonymes
+		Events:    make([]*postv1.PostEvent, len(events)),
+	}
+	for i, e := range events {
+		req.Events[i] = &postv1.PostEvent{PostId: e.PostID, Kind: strings.ToLower(string(e.Kind))}
+	}
+
+	if _, err := r.PostClient.RecordPostEvents(ctx, req); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ReportContent is the resolver for the reportContent field.
+func (r *mutationResolver) ReportContent(ctx context.Context, input model.ReportContentInput) (bool, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return false, ErrUnauthenticated
+	}
+
+	req := &postv1.ReportContentRequest{
+		ReporterId: user.ID,
+		TargetType: strings.ToLower(string(input.TargetType)),
+		TargetId:   input.TargetID,
+		Reason:     strings.ToLower(string(input.Reason)),
+	}
+	if input.Details != nil {
+		req.Details = *input.Details
+	}
+
+	if _, err := r.PostClient.ReportContent(ctx, req); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ClaimModerationCase is the resolver for the claimModerationCase field.
+func (r *mutationResolver) ClaimModerationCase(ctx context.Context, id string) (*model.ModerationCase, error) {
+	user, err := requireModerator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.PostClient.ClaimModerationCase(ctx, &postv1.ClaimModerationCaseRequest{
+		CaseId:      id,
+		ModeratorId: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoModerationCaseToGraph(resp.Case), nil
+}
+
+// ResolveModerationCase is the resolver for the resolveModerationCase field.
+func (r *mutationResolver) ResolveModerationCase(ctx context.Context, input model.ResolveModerationCaseInput) (*model.ModerationCase, error) {
+	user, err := requireModerator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &postv1.ResolveModerationCaseRequest{
+		CaseId:      input.CaseID,
+		ModeratorId: user.ID,
+		Action:      strings.ToLower(string(input.Action)),
+	}
+	if input.Note != nil {
+		req.Note = *input.Note
+	}
+	if input.ContentWarning != nil {
+		req.ContentWarning = *input.ContentWarning
+	}
+	if input.SuspendUntil != nil {
+		req.SuspendUntil = timestamppb.New(*input.SuspendUntil)
+	}
+
+	resp, err := r.PostClient.ResolveModerationCase(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoModerationCaseToGraph(resp.Case), nil
+}
+
 // Author is the resolver for the author field.
 func (r *postResolver) Author(ctx context.Context, obj *model.Post) (*model.User, error) {
 	// 1. On récupère l'ID qu'on a stocké à l'étape précédente
@@ -143,11 +724,52 @@ func (r *postResolver) Author(ctx context.Context, obj *model.Post) (*model.User
 	return mapProtoUserToGraph(resp.User), nil
 }
 
+// Comments is the resolver for the comments field.
+func (r *postResolver) Comments(ctx context.Context, obj *model.Post, first *int, after *string) (*model.CommentConnection, error) {
+	return r.listComments(ctx, obj.ID, "", first, after)
+}
+
+// Revisions is the resolver for the revisions field.
+func (r *postResolver) Revisions(ctx context.Context, obj *model.Post, first *int, after *string) (*model.PostRevisionConnection, error) {
+	viewerID := ""
+	if user := auth.ForContext(ctx); user != nil {
+		viewerID = user.ID
+	}
+	return r.listRevisions(ctx, obj.ID, viewerID, first, after)
+}
+
+// Thread is the resolver for the thread field.
+func (r *postResolver) Thread(ctx context.Context, obj *model.Post) ([]*model.Post, error) {
+	if obj.ThreadID == nil {
+		return nil, nil
+	}
+	// Post lu depuis un fil déjà chargé : pas d'appel gRPC par post (ni en sélection imbriquée)
+	if obj.Thread != nil {
+		return obj.Thread, nil
+	}
+	viewerID := ""
+	if user := auth.ForContext(ctx); user != nil {
+		viewerID = user.ID
+	}
+
+	// Par le post lui-même (et non la tête) : le fil reste lisible si la tête a été supprimée
+	resp, err := r.PostClient.GetPost(ctx, &postv1.GetPostRequest{
+		PostId:             obj.ID,
+		ViewerId:           viewerID,
+		IncludeThread:      true,
+		ShowSensitiveMedia: auth.ForContext(ctx).WantsSensitiveMedia(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoThreadToGraph(resp.Thread), nil
+}
+
 // Me is the resolver for the me field.
 func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	userID := auth.ForContext(ctx)
 	if userID == nil {
-		return nil, errors.New("unauthorized: you must be logged in")
+		return nil, ErrUnauthenticated
 	}
 
 	// Appel gRPC GetUser
@@ -161,9 +783,25 @@ func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	return mapProtoUserToGraph(resp.User), nil
 }
 
+// RegistrationChallenge is the resolver for the registrationChallenge field.
+func (r *queryResolver) RegistrationChallenge(ctx context.Context) (*model.RegistrationChallenge, error) {
+	resp, err := r.IdentityClient.GetRegistrationChallenge(ctx, &identityv1.GetRegistrationChallengeRequest{
+		IpAddress: auth.ClientIPForContext(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RegistrationChallenge{
+		Challenge:  resp.Challenge,
+		Difficulty: int(resp.Difficulty),
+		ExpiresAt:  resp.ExpiresAt.AsTime(),
+	}, nil
+}
+
 // Feed is the resolver for the feed field.
 // Feed récupère la timeline (IDs) puis hydrate le contenu (Posts)
-func (r *queryResolver) Feed(ctx context.Context, limit *int, offset *int) ([]*model.Post, error) {
+func (r *queryResolver) Feed(ctx context.Context, first *int, after *string) (*model.PostConnection, error) {
 	// 1. Récupérer l'ID utilisateur depuis le contexte (JWT Middleware)
 	// (Assumons que vous avez une fonction helper pour ça, sinon hardcodez pour le test)
 	// userID := middleware.GetUserID(ctx)
@@ -174,28 +812,28 @@ func (r *queryResolver) Feed(ctx context.Context, limit *int, offset *int) ([]*m
 	}
 	userID := user.ID
 
-	// Valeurs par défaut
-	l := int32(20)
-	if limit != nil {
-		l = int32(*limit)
+	// Valeurs par défaut (le curseur est l'offset renvoyé par le Feed Service)
+	req := &feedv1.GetTimelineRequest{UserId: userID, Limit: 20}
+	if first != nil {
+		req.Limit = int32(*first)
 	}
-	o := int32(0)
-	if offset != nil {
-		o = int32(*offset)
+	if after != nil {
+		offset, err := parseFeedCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		req.Offset = offset
 	}
 
 	// 2. Appel Feed Service (Récupère les IDs)
-	timelineResp, err := r.FeedClient.GetTimeline(ctx, &feedv1.GetTimelineRequest{
-		UserId: userID,
-		Limit:  l,
-		Offset: o,
-	})
+	timelineResp, err := r.FeedClient.GetTimeline(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch timeline: %w", err)
 	}
 
+	pageInfo := feedPageInfo(timelineResp.NextOffset)
 	if len(timelineResp.Items) == 0 {
-		return []*model.Post{}, nil
+		return &model.PostConnection{Nodes: []*model.Post{}, PageInfo: pageInfo}, nil
 	}
 
 	// 3. Extraction des IDs de posts
@@ -205,8 +843,11 @@ func (r *queryResolver) Feed(ctx context.Context, limit *int, offset *int) ([]*m
 	}
 
 	// 4. Appel Post Service (Batch Hydration - Récupère le contenu)
+	// viewer_id : le Post Service renvoie la réaction du lecteur dans le même batch
 	postsResp, err := r.PostClient.GetPosts(ctx, &postv1.GetPostsRequest{
-		PostIds: postIDs,
+		PostIds:            postIDs,
+		ViewerId:           userID,
+		ShowSensitiveMedia: auth.ForContext(ctx).WantsSensitiveMedia(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch posts content: %w", err)
@@ -216,26 +857,258 @@ func (r *queryResolver) Feed(ctx context.Context, limit *int, offset *int) ([]*m
 	// Note: On ne mappe pas 'Author' ici, on laisse le resolver Post.Author le faire (voir plus bas)
 	gqlPosts := make([]*model.Post, len(postsResp.Posts))
 	for i, p := range postsResp.Posts {
-		gqlPosts[i] = &model.Post{
-			ID:       p.Id,
-			AuthorID: p.AuthorId, // ✅ On passe l'ID pour le resolver suivant
-			Content:  p.Content,
+		gqlPosts[i] = mapProtoPostToGraph(p)
+	}
 
-			// ✅ CORRECTION TIME : On passe l'objet time.Time direct.
-			// gqlgen fera le Marshal vers JSON string tout seul.
-			CreatedAt: p.CreatedAt.AsTime(),
-			UpdatedAt: p.UpdatedAt.AsTime(),
+	// 6. Reposts / citations : les originaux sont résolus en un seul batch
+	if err := r.attachRepostedPosts(ctx, gqlPosts, userID); err != nil {
+		return nil, fmt.Errorf("failed to fetch reposted posts: %w", err)
+	}
 
-			Media: mapProtoMediaToGraph(p.Media),
+	return &model.PostConnection{Nodes: gqlPosts, PageInfo: pageInfo}, nil
+}
 
-			// ⚠️ IMPORTANT : On NE remplit PAS Author ici !
-			// Le champ Author sera vide (nil) à cette étape, et c'est normal.
+// FeedLanguages is the resolver for the feedLanguages field.
+func (r *queryResolver) FeedLanguages(ctx context.Context) ([]string, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.FeedClient.GetAcceptedLanguages(ctx, &feedv1.GetAcceptedLanguagesRequest{UserId: user.ID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Languages, nil
+}
+
+// PostsByHashtag is the resolver for the postsByHashtag field.
+func (r *queryResolver) PostsByHashtag(ctx context.Context, tag string, first *int, after *string) (*model.PostConnection, error) {
+	viewerID := ""
+	if user := auth.ForContext(ctx); user != nil {
+		viewerID = user.ID
+	}
+
+	req := &postv1.ListPostsByHashtagRequest{
+		Hashtag:            tag,
+		Limit:              20,
+		ViewerId:           viewerID,
+		ShowSensitiveMedia: auth.ForContext(ctx).WantsSensitiveMedia(),
+	}
+	if first != nil {
+		req.Limit = int32(*first)
+	}
+	if after != nil {
+		req.PageToken = *after
+	}
+
+	resp, err := r.PostClient.ListPostsByHashtag(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*model.Post, len(resp.Posts))
+	for i, p := range resp.Posts {
+		nodes[i] = mapProtoPostToGraph(p)
+	}
+
+	if err := r.attachRepostedPosts(ctx, nodes, viewerID); err != nil {
+		return nil, err
+	}
+
+	pageInfo := &model.PageInfo{HasNextPage: resp.NextPageToken != ""}
+	if pageInfo.HasNextPage {
+		pageInfo.EndCursor = &resp.NextPageToken
+	}
+	return &model.PostConnection{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// SearchPosts is the resolver for the searchPosts field.
+func (r *queryResolver) SearchPosts(ctx context.Context, query string, filter *model.PostSearchFilter, first *int, after *string) (*model.PostSearchConnection, error) {
+	viewerID := ""
+	if user := auth.ForContext(ctx); user != nil {
+		viewerID = user.ID
+	}
+
+	req := &postv1.SearchPostsRequest{
+		Query:              query,
+		ViewerId:           viewerID,
+		Limit:              20,
+		ShowSensitiveMedia: auth.ForContext(ctx).WantsSensitiveMedia(),
+	}
+	applySearchFilter(req, filter)
+	if first != nil {
+		req.Limit = int32(*first)
+	}
+	if after != nil {
+		req.PageToken = *after
+	}
+
+	resp, err := r.PostClient.SearchPosts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*model.PostSearchResult, len(resp.Results))
+	posts := make([]*model.Post, len(resp.Results))
+	for i, res := range resp.Results {
+		posts[i] = mapProtoPostToGraph(res.Post)
+		nodes[i] = &model.PostSearchResult{
+			Post:    posts[i],
+			Rank:    float64(res.Rank),
+			Snippet: res.Snippet,
 		}
 	}
 
-	return gqlPosts, nil
+	if err := r.attachRepostedPosts(ctx, posts, viewerID); err != nil {
+		return nil, err
+	}
+
+	pageInfo := &model.PageInfo{HasNextPage: resp.NextPageToken != ""}
+	if pageInfo.HasNextPage {
+		pageInfo.EndCursor = &resp.NextPageToken
+	}
+	return &model.PostSearchConnection{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// PollResults is the resolver for the pollResults field.
+func (r *queryResolver) PollResults(ctx context.Context, postID string) (*model.Poll, error) {
+	viewerID := ""
+	if user := auth.ForContext(ctx); user != nil {
+		viewerID = user.ID
+	}
+
+	resp, err := r.PostClient.GetPollResults(ctx, &postv1.GetPollResultsRequest{
+		PostId:   postID,
+		ViewerId: viewerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoPollToGraph(resp.Poll), nil
 }
 
+// Bookmarks is the resolver for the bookmarks field.
+func (r *queryResolver) Bookmarks(ctx context.Context, collectionID *string, first *int, after *string) (*model.BookmarkConnection, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	req := &postv1.ListBookmarksRequest{UserId: user.ID, Limit: 20, ShowSensitiveMedia: user.WantsSensitiveMedia()}
+	if collectionID != nil {
+		req.CollectionId = *collectionID
+	}
+	if first != nil {
+		req.Limit = int32(*first)
+	}
+	if after != nil {
+		req.PageToken = *after
+	}
+
+	resp, err := r.PostClient.ListBookmarks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := r.bookmarksFromProto(ctx, resp.Bookmarks, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	pageInfo := &model.PageInfo{HasNextPage: resp.NextPageToken != ""}
+	if resp.NextPageToken != "" {
+		pageInfo.EndCursor = &resp.NextPageToken
+	}
+	return &model.BookmarkConnection{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// BookmarkCollections is the resolver for the bookmarkCollections field.
+func (r *queryResolver) BookmarkCollections(ctx context.Context) ([]*model.BookmarkCollection, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	resp, err := r.PostClient.ListBookmarkCollections(ctx, &postv1.ListBookmarkCollectionsRequest{UserId: user.ID})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoBookmarkCollectionsToGraph(resp.Collections), nil
+}
+
+// PostAnalytics is the resolver for the postAnalytics field.
+func (r *queryResolver) PostAnalytics(ctx context.Context, postID string, from *time.Time, to *time.Time) (*model.PostAnalytics, error) {
+	user := auth.ForContext(ctx)
+	if user == nil {
+		return nil, ErrUnauthenticated
+	}
+
+	req := &postv1.GetPostAnalyticsRequest{PostId: postID, UserId: user.ID}
+	if from != nil {
+		req.From = timestamppb.New(*from)
+	}
+	if to != nil {
+		req.To = timestamppb.New(*to)
+	}
+
+	resp, err := r.PostClient.GetPostAnalytics(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoPostAnalyticsToGraph(resp.Analytics), nil
+}
+
+// ModerationCases is the resolver for the moderationCases field.
+func (r *queryResolver) ModerationCases(ctx context.Context, status *model.ModerationCaseStatus, first *int, after *string) (*model.ModerationCaseConnection, error) {
+	if _, err := requireModerator(ctx); err != nil {
+		return nil, err
+	}
+
+	req := &postv1.ListModerationCasesRequest{Limit: 20}
+	if status != nil {
+		req.Status = strings.ToLower(string(*status))
+	}
+	if first != nil {
+		req.Limit = int32(*first)
+	}
+	if after != nil {
+		req.PageToken = *after
+	}
+
+	resp, err := r.PostClient.ListModerationCases(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*model.ModerationCase, len(resp.Cases))
+	for i, c := range resp.Cases {
+		nodes[i] = mapProtoModerationCaseToGraph(c)
+	}
+
+	pageInfo := &model.PageInfo{HasNextPage: resp.NextPageToken != ""}
+	if resp.NextPageToken != "" {
+		pageInfo.EndCursor = &resp.NextPageToken
+	}
+	return &model.ModerationCaseConnection{Nodes: nodes, PageInfo: pageInfo}, nil
+}
+
+// ModerationCase is the resolver for the moderationCase field.
+func (r *queryResolver) ModerationCase(ctx context.Context, id string) (*model.ModerationCase, error) {
+	if _, err := requireModerator(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := r.PostClient.GetModerationCase(ctx, &postv1.GetModerationCaseRequest{CaseId: id})
+	if err != nil {
+		return nil, err
+	}
+	return mapProtoModerationCaseToGraph(resp.Case), nil
+}
+
+// Comment returns CommentResolver implementation.
+func (r *Resolver) Comment() CommentResolver { return &commentResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
@@ -245,6 +1118,7 @@ func (r *Resolver) Post() PostResolver { return &postResolver{r} }
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+type commentResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type postResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -0,0 +1,27 @@
+package graph
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/api-gateway/graph/model"
+)
+
+// applySearchFilter recopie les filtres optionnels de searchPosts dans la requête gRPC
+func applySearchFilter(req *postv1.SearchPostsRequest, filter *model.PostSearchFilter) {
+	if filter == nil {
+		return
+	}
+	if filter.AuthorID != nil {
+		req.AuthorId = *filter.AuthorID
+	}
+	if filter.MediaType != nil {
+		req.MediaType = *filter.MediaType
+	}
+	if filter.Since != nil {
+		req.Since = timestamppb.New(*filter.Since)
+	}
+	if filter.Until != nil {
+		req.Until = timestamppb.New(*filter.Until)
+	}
+}
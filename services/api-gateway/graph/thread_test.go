@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+)
+
+func TestPostThreadReusesLoadedThread(t *testing.T) {
+	threadID := "head"
+	protoThread := []*postv1.Post{
+		{Id: "head", ThreadId: threadID, ThreadPosition: 1},
+		{Id: "second", ThreadId: threadID, ThreadPosition: 2},
+		{Id: "third", ThreadId: threadID, ThreadPosition: 3},
+	}
+	thread := mapProtoThreadToGraph(protoThread)
+
+	// Sans client gRPC : tout appel au Post Service ferait paniquer le test
+	r := &postResolver{&Resolver{}}
+	for _, post := range thread {
+		got, err := r.Thread(context.Background(), post)
+		if err != nil {
+			t.Fatalf("Thread(%s): %v", post.ID, err)
+		}
+		if len(got) != len(thread) {
+			t.Fatalf("Thread(%s) = %d posts, want %d", post.ID, len(got), len(thread))
+		}
+		for i := range got {
+			if got[i].ID != protoThread[i].Id {
+				t.Errorf("Thread(%s)[%d] = %s, want %s", post.ID, i, got[i].ID, protoThread[i].Id)
+			}
+		}
+		// Sélection imbriquée (thread { thread }) : toujours sans appel
+		if nested, err := r.Thread(context.Background(), got[len(got)-1]); err != nil || len(nested) != len(thread) {
+			t.Errorf("thread imbriqué = %d posts, %v", len(nested), err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"strings"
 
@@ -12,17 +13,39 @@ import (
 type contextKey struct{ name string }
 
 var userCtxKey = &contextKey{"user"}
+var clientIPCtxKey = &contextKey{"client_ip"}
+var idempotencyKeyCtxKey = &contextKey{"idempotency_key"}
 
 // ✅ AMÉLIORATION : On définit une struct User.
-// Cela résout votre erreur "user.ID undefined" et permet d'ajouter "Role" plus tard.
+// Cela résout votre erreur "user.ID undefined" et porte le rôle (outils de modération).
 type User struct {
-	ID string
+	ID   string
+	Role string
+
+	ShowSensitiveMedia bool // Préférence de lecture (Identity Service), transmise au Post Service
+}
+
+// WantsSensitiveMedia : un lecteur anonyme voit les médias sensibles floutés
+func (u *User) WantsSensitiveMedia() bool {
+	return u != nil && u.ShowSensitiveMedia
+}
+
+// CanModerate : accès à la file de modération
+func (u *User) CanModerate() bool {
+	return u != nil && (u.Role == "moderator" || u.Role == "admin")
 }
 
 // Middleware décode le header Authorization et valide le token via gRPC
 func Middleware(identityClient identityv1.IdentityServiceClient) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 0. IP du client (utile même sans token : anti-bot sur l'inscription)
+			r = r.WithContext(context.WithValue(r.Context(), clientIPCtxKey, clientIP(r)))
+			// Header "Idempotency-Key" : transmis tel quel aux commandes qui le supportent (createPost...)
+			if key := strings.TrimSpace(r.Header.Get("Idempotency-Key")); key != "" {
+				r = r.WithContext(context.WithValue(r.Context(), idempotencyKeyCtxKey, key))
+			}
+
 			header := r.Header.Get("Authorization")
 
 			// 1. Pas de header ? On laisse passer (c'est le Resolver qui décidera si c'est grave)
@@ -52,7 +75,10 @@ func Middleware(identityClient identityv1.IdentityServiceClient) func(http.Handl
 
 			// 4. Succès : On crée l'objet User
 			user := &User{
-				ID: validateResp.UserId,
+				ID:   validateResp.UserId,
+				Role: validateResp.Role,
+
+				ShowSensitiveMedia: validateResp.ShowSensitiveMedia,
 			}
 
 			// 5. Injection dans le contexte
@@ -69,3 +95,25 @@ func ForContext(ctx context.Context) *User {
 	raw, _ := ctx.Value(userCtxKey).(*User)
 	return raw
 }
+
+// ClientIPForContext renvoie l'IP du client HTTP (vide hors requête HTTP)
+func ClientIPForContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPCtxKey).(string)
+	return ip
+}
+
+// IdempotencyKeyForContext renvoie le header Idempotency-Key de la requête (vide si absent)
+func IdempotencyKeyForContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtxKey).(string)
+	return key
+}
+
+// clientIP extrait l'IP de la connexion.
+// Note : on ignore volontairement X-Forwarded-For (falsifiable) tant qu'aucun proxy de confiance n'est configuré.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
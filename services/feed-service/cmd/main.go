@@ -10,6 +10,7 @@ import (
 
 	// Drivers
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
@@ -89,14 +90,21 @@ func main() {
 	// 6. Initialisation du Core
 	feedService := services.NewFeedService(feedRepo, graphClient)
 
-	// 7. Initialisation du Consumer NATS (Driving Adapter - Async)
+	// 7. Initialisation du Consumer JetStream (Driving Adapter - Async)
+	// Durable : les posts publiés pendant un redémarrage sont distribués à la reprise
+	js, err := jetstream.New(nc)
+	if err != nil {
+		slog.Error("Failed to init JetStream", "error", err)
+		os.Exit(1)
+	}
 	handler := events.NewEventHandler(feedService)
-	_, err = nc.Subscribe("post.created", handler.HandlePostCreated)
+	consumeCtx, err := handler.Consume(ctx, js)
 	if err != nil {
-		slog.Error("Failed to subscribe to NATS", "error", err)
+		// Le stream POSTS est créé par le Post Service : redémarrer une fois celui-ci lancé
+		slog.Error("Failed to consume POSTS stream", "error", err)
 		os.Exit(1)
 	}
-	slog.Info("👂 Listening for events (NATS)")
+	slog.Info("👂 Listening for events (JetStream)", "stream", events.StreamName, "consumer", events.ConsumerName)
 
 	// 8. Initialisation du Serveur gRPC (Driving Adapter - Sync)
 	// C'est ici qu'on permet la LECTURE du feed
@@ -135,6 +143,7 @@ func main() {
 	<-quit
 	slog.Info("🛑 Shutting down server...")
 
+	consumeCtx.Stop() // Plus de nouvelles livraisons ; les événements non acquittés seront relivrés
 	grpcServer.GracefulStop()
 	// On pourrait fermer rdb et nc proprement ici aussi
 	slog.Info("👋 Server exited")
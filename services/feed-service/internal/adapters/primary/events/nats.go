@@ -2,67 +2,119 @@ package events
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"log/slog"
 	"time"
 
+	eventsv1 "github.com/jupiterclapton/cenackle/gen/events/v1"
 	"github.com/jupiterclapton/cenackle/services/feed-service/internal/core/domain"
 	"github.com/jupiterclapton/cenackle/services/feed-service/internal/core/ports"
-	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
 )
 
 type EventHandler struct {
 	service ports.FeedService
 }
 
+const (
+	StreamName   = "POSTS" // Créé par le Post Service
+	ConsumerName = "feed-service"
+	// retryDelay : nouvelle livraison d'un événement dont le traitement a échoué (Redis, Graph Service...)
+	retryDelay = 5 * time.Second
+)
+
 // ✅ C'est cette fonction qui manquait pour que main.go fonctionne !
 func NewEventHandler(service ports.FeedService) *EventHandler {
 	return &EventHandler{service: service}
 }
 
-func (h *EventHandler) HandlePostCreated(msg *nats.Msg) {
-	// 🟢 1. EXTRACTION DU CONTEXTE DE TRACE (Le lien avec le Post Service)
-	// On crée un contexte vide, et on le remplit avec les infos trouvées dans les headers NATS
-	ctx := context.Background()
-	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(msg.Header))
+// Consume : consommateur durable sur le stream POSTS. Un événement n'est acquitté qu'une fois traité :
+// ceux publiés pendant un arrêt du Feed Service (ou dont le traitement a échoué) sont relivrés à la reprise.
+func (h *EventHandler) Consume(ctx context.Context, js jetstream.JetStream) (jetstream.ConsumeContext, error) {
+	consumer, err := js.CreateOrUpdateConsumer(ctx, StreamName, jetstream.ConsumerConfig{
+		Durable:        ConsumerName,
+		FilterSubjects: []string{"post.created", "post.deleted"},
+		DeliverPolicy:  jetstream.DeliverNewPolicy, // À la création seulement : ensuite, reprise là où on s'était arrêté
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        time.Minute, // > timeout du fan-out
+		MaxDeliver:     10,
+		MaxAckPending:  256, // Fan-outs en parallèle
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create consumer: %w", err)
+	}
 
-	// 🟢 2. DÉMARRAGE DU SPAN (La mesure du temps de traitement)
-	tracer := otel.Tracer("feed-service")
-	// On crée un span nommé "process_post_created"
-	ctx, span := tracer.Start(ctx, "process_post_created", trace.WithSpanKind(trace.SpanKindConsumer))
-	defer span.End() // On s'assure que le span se ferme à la fin de la fonction
+	return consumer.Consume(h.dispatch)
+}
 
-	// --- VOTRE CODE EXISTANT ---
-	type PostCreatedEvent struct {
-		ID        string    `json:"id"`
-		AuthorID  string    `json:"author_id"`
-		Type      string    `json:"type"`
-		CreatedAt time.Time `json:"created_at"`
+// dispatch décode l'enveloppe events.v1 (contrat partagé avec le Post Service) et route selon le payload
+func (h *EventHandler) dispatch(msg jetstream.Msg) {
+	var env eventsv1.EventEnvelope
+	if err := proto.Unmarshal(msg.Data(), &env); err != nil {
+		slog.Error("❌ Invalid event format", "subject", msg.Subject(), "error", err)
+		_ = msg.Term() // Inutile de le relivrer
+		return
 	}
 
-	var event PostCreatedEvent
-	if err := json.Unmarshal(msg.Data, &event); err != nil {
-		// 🟢 Astuce : On peut attacher l'erreur au span pour la voir dans Jaeger
-		span.RecordError(err)
-		slog.Error("❌ Invalid event format", "error", err)
+	// 🟢 EXTRACTION DU CONTEXTE DE TRACE (Le lien avec le Post Service)
+	// On crée un contexte vide, et on le remplit avec le contexte W3C porté par l'enveloppe
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(env.TraceContext))
+
+	// Date de l'événement : ordonne une annonce et un retrait du même post traités en parallèle
+	occurredAt := env.OccurredAt.AsTime()
+
+	switch payload := env.Payload.(type) {
+	case *eventsv1.EventEnvelope_PostCreated:
+		h.HandlePostCreated(ctx, msg, payload.PostCreated, occurredAt)
+	case *eventsv1.EventEnvelope_PostDeleted:
+		h.HandlePostDeleted(ctx, msg, payload.PostDeleted, occurredAt)
+	default:
+		slog.Error("❌ Unexpected event", "subject", msg.Subject(), "event_type", env.EventType, "version", env.Version)
+		_ = msg.Term()
+	}
+}
+
+// settle acquitte l'événement traité, ou le fait relivrer plus tard
+func settle(msg jetstream.Msg, err error) {
+	if err == nil {
+		_ = msg.Ack()
 		return
 	}
+	_ = msg.NakWithDelay(retryDelay)
+}
+
+func (h *EventHandler) HandlePostCreated(ctx context.Context, msg jetstream.Msg, event *eventsv1.PostCreated, occurredAt time.Time) {
+	// 🟢 DÉMARRAGE DU SPAN (La mesure du temps de traitement)
+	tracer := otel.Tracer("feed-service")
+	// On crée un span nommé "process_post_created"
+	ctx, span := tracer.Start(ctx, "process_post_created", trace.WithSpanKind(trace.SpanKindConsumer))
+	defer span.End() // On s'assure que le span se ferme à la fin de la fonction
 
-	slog.Info("📨 Feed Service received event", "post_id", event.ID, "type", event.Type)
+	slog.Info("📨 Feed Service received event", "post_id", event.Id, "type", event.Type)
 
 	item := &domain.FeedItem{
-		PostID:    event.ID,
-		AuthorID:  event.AuthorID,
+		PostID:    event.Id,
+		AuthorID:  event.AuthorId,
 		Type:      domain.ContentType(event.Type),
-		CreatedAt: event.CreatedAt,
+		CreatedAt: event.CreatedAt.AsTime(),
+
+		OriginalPostID: event.RepostedPostId,
+
+		Visibility:       domain.Visibility(event.Visibility),
+		MentionedUserIDs: event.MentionedUserIds,
+
+		Language: event.Language,
+
+		AnnouncedAt: occurredAt,
 	}
 
 	// --- LANCEMENT EN BACKGROUND ---
 	go func() {
-		// 🟢 3. PROPAGATION DU CONTEXTE
+		// 🟢 PROPAGATION DU CONTEXTE
 		// AU LIEU DE : context.Background()
 		// ON UTILISE : ctx (celui qui contient la trace)
 		// Cela permet à DistributePost -> Redis d'hériter du TraceID
@@ -70,10 +122,37 @@ func (h *EventHandler) HandlePostCreated(msg *nats.Msg) {
 		childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 		defer cancel()
 
-		if err := h.service.DistributePost(childCtx, item); err != nil {
-			slog.Error("❌ Fan-out failed", "post_id", event.ID, "error", err)
+		err := h.service.DistributePost(childCtx, item)
+		if err != nil {
+			slog.Error("❌ Fan-out failed", "post_id", event.Id, "error", err)
 		} else {
-			slog.Debug("✅ Fan-out success", "post_id", event.ID)
+			slog.Debug("✅ Fan-out success", "post_id", event.Id)
+		}
+		settle(msg, err)
+	}()
+}
+
+func (h *EventHandler) HandlePostDeleted(ctx context.Context, msg jetstream.Msg, event *eventsv1.PostDeleted, occurredAt time.Time) {
+	tracer := otel.Tracer("feed-service")
+	ctx, span := tracer.Start(ctx, "process_post_deleted", trace.WithSpanKind(trace.SpanKindConsumer))
+	defer span.End()
+
+	if event.Id == "" {
+		slog.Error("❌ Invalid event format", "subject", msg.Subject())
+		_ = msg.Term()
+		return
+	}
+
+	slog.Info("📨 Feed Service received event", "post_id", event.Id, "subject", msg.Subject())
+
+	go func() {
+		childCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		err := h.service.RetractPost(childCtx, event.Id, occurredAt)
+		if err != nil {
+			slog.Error("❌ Retraction failed", "post_id", event.Id, "error", err)
 		}
+		settle(msg, err)
 	}()
 }
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"google.golang.org/grpc"
@@ -48,7 +49,7 @@ func (s *Server) GetTimeline(ctx context.Context, req *feedv1.GetTimelineRequest
 		Offset: int64(offset),
 	}
 
-	items, err := s.service.GetTimeline(ctx, domainReq)
+	items, nextOffset, err := s.service.GetTimeline(ctx, domainReq)
 	if err != nil {
 		slog.Error("Failed to get timeline", "error", err)
 		return nil, status.Error(codes.Internal, "failed to fetch timeline")
@@ -62,10 +63,41 @@ func (s *Server) GetTimeline(ctx context.Context, req *feedv1.GetTimelineRequest
 			AuthorId:  item.AuthorID, // Note: stocké dans Redis ou à déduire si manquant
 			Type:      string(item.Type),
 			CreatedAt: timestamppb.New(item.CreatedAt),
+			Language:  item.Language,
 		}
 	}
 
 	return &feedv1.GetTimelineResponse{
-		Items: protoItems,
+		Items:      protoItems,
+		NextOffset: int32(nextOffset),
 	}, nil
 }
+
+func (s *Server) GetAcceptedLanguages(ctx context.Context, req *feedv1.GetAcceptedLanguagesRequest) (*feedv1.AcceptedLanguagesResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	languages, err := s.service.GetAcceptedLanguages(ctx, req.UserId)
+	if err != nil {
+		slog.Error("Failed to get accepted languages", "error", err)
+		return nil, status.Error(codes.Internal, "failed to fetch accepted languages")
+	}
+	return &feedv1.AcceptedLanguagesResponse{Languages: languages}, nil
+}
+
+func (s *Server) SetAcceptedLanguages(ctx context.Context, req *feedv1.SetAcceptedLanguagesRequest) (*feedv1.AcceptedLanguagesResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	languages, err := s.service.SetAcceptedLanguages(ctx, req.UserId, req.Languages)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLanguage) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		slog.Error("Failed to set accepted languages", "error", err)
+		return nil, status.Error(codes.Internal, "failed to save accepted languages")
+	}
+	return &feedv1.AcceptedLanguagesResponse{Languages: languages}, nil
+}
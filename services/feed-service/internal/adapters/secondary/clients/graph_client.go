@@ -70,3 +70,28 @@ func (c *GraphClient) GetFollowers(ctx context.Context, userID string) ([]string
 	slog.Debug("Retrieved followers", "count", len(allFollowers))
 	return allFollowers, nil
 }
+
+// GetCloseFriends : même logique de STREAMING que GetFollowers
+func (c *GraphClient) GetCloseFriends(ctx context.Context, userID string) ([]string, error) {
+	stream, err := c.client.StreamCloseFriends(ctx, &graphv1.StreamCloseFriendsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start stream: %w", err)
+	}
+
+	var friends []string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error while streaming: %w", err)
+		}
+		friends = append(friends, resp.UserIds...)
+	}
+
+	slog.Debug("Retrieved close friends", "count", len(friends))
+	return friends, nil
+}
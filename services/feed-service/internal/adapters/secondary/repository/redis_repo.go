@@ -10,6 +10,53 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// addToTimelineScript : ZADD uniquement si le post n'a pas été retiré depuis son annonce et si le contenu
+// n'est pas déjà dans la timeline. Le destinataire est inscrit dans la trace du fan-out dans le même script.
+// KEYS[1] = timeline, KEYS[2] = set des contenus déjà présents, KEYS[3] = pierre tombale, KEYS[4] = trace du fan-out
+// ARGV[1] = score, ARGV[2] = membre, ARGV[3] = clé de dédoublonnage, ARGV[4] = TTL (secondes),
+// ARGV[5] = date de l'annonce (ms), ARGV[6] = destinataire
+// Atomique côté Redis : deux reposts du même original traités en parallèle n'entrent pas tous les deux,
+// et un destinataire écrit avant la pierre tombale est forcément vu par le retrait qui la pose.
+var addToTimelineScript = redis.NewScript(`
+local retracted = redis.call('GET', KEYS[3])
+if retracted and tonumber(retracted) >= tonumber(ARGV[5]) then
+	return 0
+end
+if redis.call('SADD', KEYS[2], ARGV[3]) == 0 then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[2])
+redis.call('SADD', KEYS[4], ARGV[6])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('EXPIRE', KEYS[2], ARGV[4])
+return 1
+`)
+
+// retractScript : pose la pierre tombale d'un post, le retrait le plus récent l'emporte
+// KEYS[1] = pierre tombale, ARGV[1] = date du retrait (ms), ARGV[2] = TTL (secondes)
+var retractScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current or tonumber(current) < tonumber(ARGV[1]) then
+	redis.call('SET', KEYS[1], ARGV[1], 'EX', ARGV[2])
+end
+return 1
+`)
+
+// removeFromTimelineScript : retire le membre et, s'il était bien présent, libère sa clé de dédoublonnage
+// (un repost rejeté car l'original était déjà là ne doit pas libérer la clé de l'original).
+// KEYS[1] = timeline, KEYS[2] = set des contenus déjà présents
+// ARGV[1] = membre, ARGV[2] = clé de dédoublonnage
+var removeFromTimelineScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('SREM', KEYS[2], ARGV[2])
+return 1
+`)
+
+// removeBatchSize : destinataires traités par pipeline lors d'un retrait
+const removeBatchSize = 1000
+
 type RedisFeedRepo struct {
 	client *redis.Client
 	ttl    time.Duration // Ex: 30 jours (on ne garde pas l'infini en RAM)
@@ -24,97 +71,219 @@ func NewRedisFeedRepo(client *redis.Client) *RedisFeedRepo {
 
 // AddToTimelines implémente le Fan-out massif
 func (r *RedisFeedRepo) AddToTimelines(ctx context.Context, userIDs []string, item *domain.FeedItem) error {
+	// Le script est chargé une fois (idempotent) pour pouvoir utiliser EVALSHA dans le pipeline
+	if err := addToTimelineScript.Load(ctx, r.client).Err(); err != nil {
+		return err
+	}
+
 	pipe := r.client.Pipeline()
 
-	// Format du membre : "VIDEO:user-uuid-123:post-uuid-456"
-	member := fmt.Sprintf("%s:%s:%s", item.Type, item.AuthorID, item.PostID)
+	// Format du membre : "VIDEO:user-uuid-123:post-uuid-456:fr" (langue vide si inconnue)
+	member := fmt.Sprintf("%s:%s:%s:%s", item.Type, item.AuthorID, item.PostID, item.Language)
 	score := float64(item.CreatedAt.Unix())
+	ttl := int64(r.ttl.Seconds())
+
+	// 1. Trace du fan-out (membre écrit) AVANT les timelines : un retrait concurrent sait quoi retirer
+	pipe.HSet(ctx, fanoutEntryKey(item.PostID), "member", member, "dedup", item.DedupKey())
+	pipe.Expire(ctx, fanoutEntryKey(item.PostID), r.ttl)
 
 	// Batch operation : On ajoute l'entrée pour chaque follower
 	for _, uid := range userIDs {
 		key := fmt.Sprintf("timeline:%s", uid)
 
-		// 1. Ajout au Sorted Set
-		pipe.ZAdd(ctx, key, redis.Z{
-			Score:  score,
-			Member: member,
-		})
+		// 2. Ajout au Sorted Set (sauf si le post a été retiré depuis, ou si ce contenu y est déjà,
+		// ex: original + repost) + Refresh TTL. Le destinataire rejoint la trace du fan-out.
+		addToTimelineScript.EvalSha(ctx, pipe,
+			[]string{key, seenKey(uid), tombstoneKey(item.PostID), fanoutKey(item.PostID)},
+			score, member, item.DedupKey(), ttl, item.AnnouncedAt.UnixMilli(), uid,
+		)
 
-		// 2. Capping (Optionnel mais recommandé) : On garde max 500 items pour économiser la RAM
+		// 3. Capping (Optionnel mais recommandé) : On garde max 500 items pour économiser la RAM
 		// pipe.ZRemRangeByRank(ctx, key, 0, -501)
-
-		// 3. Refresh TTL
-		pipe.Expire(ctx, key, r.ttl)
 	}
+	pipe.Expire(ctx, fanoutKey(item.PostID), r.ttl)
 
 	// Exécution atomique (ou presque) du pipeline
 	_, err := pipe.Exec(ctx)
 	return err
 }
 
-// GetTimeline lit et filtre
-func (r *RedisFeedRepo) GetTimeline(ctx context.Context, req domain.FeedRequest) ([]*domain.FeedItem, error) {
-	key := fmt.Sprintf("timeline:%s", req.UserID)
+// seenKey : contenus (posts originaux) déjà présents dans la timeline d'un utilisateur
+func seenKey(userID string) string {
+	return fmt.Sprintf("timeline:%s:seen", userID)
+}
 
-	// Pagination Redis (Inclusive)
-	start := req.Offset
-	stop := req.Offset + req.Limit - 1
+// RemoveFromTimelines retire un post de toutes les timelines qui l'ont reçu (cf. fanoutKey).
+// Renvoie le nombre de timelines modifiées. Un post inconnu (trop ancien, jamais distribué) est ignoré.
+// La pierre tombale est posée AVANT la lecture de la trace : un fan-out concurrent n'écrit plus rien
+// après elle, et tout ce qu'il a écrit avant est dans la trace parcourue ici.
+func (r *RedisFeedRepo) RemoveFromTimelines(ctx context.Context, postID string, retractedAt time.Time) (int, error) {
+	if err := retractScript.Run(ctx, r.client, []string{tombstoneKey(postID)}, retractedAt.UnixMilli(), int64(r.ttl.Seconds())).Err(); err != nil {
+		return 0, err
+	}
 
-	results, err := r.client.ZRevRangeWithScores(ctx, key, start, stop).Result()
+	entry, err := r.client.HGetAll(ctx, fanoutEntryKey(postID)).Result()
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	member, dedup := entry["member"], entry["dedup"]
+	if member == "" {
+		return 0, nil
 	}
 
-	items := make([]*domain.FeedItem, 0, len(results))
+	if err := removeFromTimelineScript.Load(ctx, r.client).Err(); err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	iter := r.client.SScan(ctx, fanoutKey(postID), 0, "", removeBatchSize).Iterator()
+	batch := make([]string, 0, removeBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		pipe := r.client.Pipeline()
+		cmds := make([]*redis.Cmd, len(batch))
+		for i, uid := range batch {
+			cmds[i] = removeFromTimelineScript.EvalSha(ctx, pipe,
+				[]string{fmt.Sprintf("timeline:%s", uid), seenKey(uid)},
+				member, dedup,
+			)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+		for _, cmd := range cmds {
+			if n, _ := cmd.Int(); n == 1 {
+				removed++
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
 
-	// Préparation du filtre (optimisation map)
-	filterMap := make(map[domain.ContentType]bool)
-	for _, t := range req.Types {
-		filterMap[t] = true
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == removeBatchSize {
+			if err := flush(); err != nil {
+				return removed, err
+			}
+		}
 	}
-	hasFilter := len(filterMap) > 0
+	if err := iter.Err(); err != nil {
+		return removed, err
+	}
+	if err := flush(); err != nil {
+		return removed, err
+	}
+
+	// Le retrait est fait : la trace du fan-out ne sert plus
+	return removed, r.client.Del(ctx, fanoutKey(postID), fanoutEntryKey(postID)).Err()
+}
+
+// fanoutKey : utilisateurs dont la timeline a reçu le post
+func fanoutKey(postID string) string {
+	return fmt.Sprintf("fanout:%s", postID)
+}
+
+// fanoutEntryKey : membre écrit dans les timelines et clé de dédoublonnage du post
+func fanoutEntryKey(postID string) string {
+	return fmt.Sprintf("fanout:%s:entry", postID)
+}
+
+// tombstoneKey : date (ms) du dernier retrait du post. Même durée de vie que les timelines :
+// au-delà, une annonce relivrée n'aurait de toute façon plus sa place dans un fil.
+func tombstoneKey(postID string) string {
+	return fmt.Sprintf("retracted:%s", postID)
+}
+
+// timelineScanWindow : items lus par ZREVRANGE quand le filtre écarte une partie de la page
+const timelineScanWindow = 100
+
+// GetTimeline lit et filtre, fenêtre par fenêtre, jusqu'à réunir req.Limit items acceptés ou épuiser la timeline.
+// nextOffset est l'index qui suit le dernier item parcouru (0 = timeline épuisée) : les items écartés
+// ne sont pas relus à la page suivante.
+func (r *RedisFeedRepo) GetTimeline(ctx context.Context, req domain.FeedRequest) ([]*domain.FeedItem, int64, error) {
+	key := fmt.Sprintf("timeline:%s", req.UserID)
+	window := max(req.Limit, timelineScanWindow)
 
-	for _, z := range results {
-		member, ok := z.Member.(string)
-		if !ok {
-			continue
+	items := make([]*domain.FeedItem, 0, req.Limit)
+	for start := req.Offset; ; start += window {
+		// Pagination Redis (Inclusive)
+		results, err := r.client.ZRevRangeWithScores(ctx, key, start, start+window-1).Result()
+		if err != nil {
+			return nil, 0, err
 		}
 
-		// 🟢 CORRECTION EXPERTE : Parsing robuste
-		// On s'attend à "TYPE:AUTHOR_ID:POST_ID"
-		parts := strings.Split(member, ":")
-
-		// Gestion de la compatibilité (si jamais on a encore des vieilles données)
-		var contentType domain.ContentType
-		var postID, authorID string
-
-		if len(parts) == 3 {
-			// Nouveau format
-			contentType = domain.ContentType(parts[0])
-			authorID = parts[1]
-			postID = parts[2]
-		} else if len(parts) == 2 {
-			// Ancien format (fallback pour ne pas crasher)
-			contentType = domain.ContentType(parts[0])
-			postID = parts[1]
-			authorID = "" // On ne l'a pas
-		} else {
-			// Format inconnu (donnée corrompue ?)
-			continue
+		for i, z := range results {
+			item, ok := parseTimelineMember(z)
+			if !ok || !req.Accepts(item) {
+				continue
+			}
+			items = append(items, item)
+			if int64(len(items)) == req.Limit {
+				return items, start + int64(i) + 1, nil
+			}
 		}
 
-		// Filtrage Applicatif
-		if hasFilter && !filterMap[contentType] {
-			continue
+		if int64(len(results)) < window {
+			return items, 0, nil
 		}
+	}
+}
 
-		items = append(items, &domain.FeedItem{
-			PostID:    postID,
-			AuthorID:  authorID, // ✅ Maintenant c'est rempli
-			Type:      contentType,
-			CreatedAt: time.Unix(int64(z.Score), 0),
-		})
+// parseTimelineMember : "TYPE:AUTHOR_ID:POST_ID:LANGUAGE" (cf. AddToTimelines), anciens formats compris
+func parseTimelineMember(z redis.Z) (*domain.FeedItem, bool) {
+	member, ok := z.Member.(string)
+	if !ok {
+		return nil, false
 	}
 
-	return items, nil
+	// 🟢 CORRECTION EXPERTE : Parsing robuste
+	parts := strings.Split(member, ":")
+
+	// Gestion de la compatibilité (si jamais on a encore des vieilles données)
+	item := &domain.FeedItem{CreatedAt: time.Unix(int64(z.Score), 0)}
+	switch len(parts) {
+	case 4:
+		// Nouveau format
+		item.Type, item.AuthorID, item.PostID, item.Language = domain.ContentType(parts[0]), parts[1], parts[2], parts[3]
+	case 3:
+		// Format sans langue (posts distribués avant la détection : toujours montrés)
+		item.Type, item.AuthorID, item.PostID = domain.ContentType(parts[0]), parts[1], parts[2]
+	case 2:
+		// Ancien format (fallback pour ne pas crasher) : pas d'auteur
+		item.Type, item.PostID = domain.ContentType(parts[0]), parts[1]
+	default:
+		// Format inconnu (donnée corrompue ?)
+		return nil, false
+	}
+	return item, true
+}
+
+// GetLanguages : ordre de saisie conservé (liste Redis), vide = toutes les langues
+func (r *RedisFeedRepo) GetLanguages(ctx context.Context, userID string) ([]string, error) {
+	return r.client.LRange(ctx, languagesKey(userID), 0, -1).Result()
+}
+
+// SetLanguages remplace la liste (transaction MULTI : jamais de liste à moitié écrite)
+func (r *RedisFeedRepo) SetLanguages(ctx context.Context, userID string, languages []string) error {
+	key := languagesKey(userID)
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(languages) > 0 {
+		values := make([]any, len(languages))
+		for i, l := range languages {
+			values[i] = l
+		}
+		pipe.RPush(ctx, key, values...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// languagesKey : préférence durable (pas de TTL, contrairement aux timelines)
+func languagesKey(userID string) string {
+	return fmt.Sprintf("feed:languages:%s", userID)
 }
@@ -1,6 +1,16 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxAcceptedLanguages : langues qu'un utilisateur peut garder dans son fil
+const MaxAcceptedLanguages = 20
+
+var ErrInvalidLanguage = errors.New("languages must be ISO 639-1 codes")
 
 type ContentType string
 
@@ -8,6 +18,7 @@ const (
 	TypePost    ContentType = "post"
 	TypeVideo   ContentType = "video"
 	TypeArticle ContentType = "article"
+	TypeRepost  ContentType = "repost" // Partage pur : AuthorID est le reposteur
 )
 
 type FeedItem struct {
@@ -15,12 +26,102 @@ type FeedItem struct {
 	AuthorID  string
 	Type      ContentType
 	CreatedAt time.Time
+
+	// OriginalPostID : post partagé (reposts et citations), vide pour un post original
+	OriginalPostID string
+
+	// Audience (cf. post-service) : détermine QUI reçoit le post dans sa timeline
+	Visibility       Visibility
+	MentionedUserIDs []string
+
+	// Language : langue du post (ISO 639-1), vide si inconnue
+	Language string
+
+	// AnnouncedAt : date de l'événement post.created. Un retrait plus récent l'emporte (cf. RetractPost),
+	// mais pas sur la re-publication d'un post restauré, annoncée après lui.
+	AnnouncedAt time.Time
+}
+
+type Visibility string
+
+const (
+	VisibilityPublic       Visibility = "public"
+	VisibilityFollowers    Visibility = "followers"
+	VisibilityMentioned    Visibility = "mentioned"
+	VisibilityCloseFriends Visibility = "close_friends"
+)
+
+// KeepMentioned : pour un post "mentioned", seuls les abonnés mentionnés le reçoivent
+func (i *FeedItem) KeepMentioned(followers []string) []string {
+	mentioned := make(map[string]bool, len(i.MentionedUserIDs))
+	for _, id := range i.MentionedUserIDs {
+		mentioned[id] = true
+	}
+
+	recipients := make([]string, 0, len(i.MentionedUserIDs))
+	for _, id := range followers {
+		if mentioned[id] {
+			recipients = append(recipients, id)
+		}
+	}
+	return recipients
+}
+
+// DedupKey identifie le contenu affiché : un repost pur montre l'original,
+// il ne doit donc pas apparaître si l'original (ou un autre repost) est déjà dans la timeline
+func (i *FeedItem) DedupKey() string {
+	if i.Type == TypeRepost && i.OriginalPostID != "" {
+		return i.OriginalPostID
+	}
+	return i.PostID
 }
 
 // FeedRequest encapsule les critères de recherche
 type FeedRequest struct {
-	UserID string
-	Limit  int64
-	Offset int64         // Pagination
-	Types  []ContentType // Filtrage optionnel
+	UserID    string
+	Limit     int64
+	Offset    int64         // Pagination
+	Types     []ContentType // Filtrage optionnel
+	Languages []string      // Langues acceptées par le lecteur (vide = toutes)
+}
+
+// Accepts : filtres de lecture (types demandés, langues acceptées)
+func (r FeedRequest) Accepts(item *FeedItem) bool {
+	if len(r.Types) > 0 && !slices.Contains(r.Types, item.Type) {
+		return false
+	}
+	return r.AcceptsLanguage(item.Language)
+}
+
+// AcceptsLanguage : un post de langue inconnue (trop court, non détectée) est toujours montré
+func (r FeedRequest) AcceptsLanguage(lang string) bool {
+	if len(r.Languages) == 0 || lang == "" {
+		return true
+	}
+	for _, l := range r.Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeLanguages : codes ISO 639-1 en minuscules, sans doublons (liste vide = toutes les langues)
+func NormalizeLanguages(languages []string) ([]string, error) {
+	seen := make(map[string]bool, len(languages))
+	normalized := make([]string, 0, len(languages))
+	for _, l := range languages {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if len(l) != 2 || l[0] < 'a' || l[0] > 'z' || l[1] < 'a' || l[1] > 'z' {
+			return nil, ErrInvalidLanguage
+		}
+		if !seen[l] {
+			seen[l] = true
+			normalized = append(normalized, l)
+		}
+	}
+	if len(normalized) > MaxAcceptedLanguages {
+		return nil, ErrInvalidLanguage
+	}
+	return normalized, nil
 }
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAcceptsLanguage(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages []string
+		lang      string
+		want      bool
+	}{
+		{"aucune préférence : tout passe", nil, "de", true},
+		{"langue acceptée", []string{"fr", "en"}, "en", true},
+		{"langue refusée", []string{"fr", "en"}, "de", false},
+		{"langue inconnue : toujours montrée", []string{"fr"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := FeedRequest{Languages: tt.languages}
+			if got := r.AcceptsLanguage(tt.lang); got != tt.want {
+				t.Errorf("AcceptsLanguage(%q) = %v, want %v", tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeedRequestAccepts(t *testing.T) {
+	tests := []struct {
+		name string
+		req  FeedRequest
+		item FeedItem
+		want bool
+	}{
+		{"aucun filtre", FeedRequest{}, FeedItem{Type: TypeVideo, Language: "de"}, true},
+		{"type demandé", FeedRequest{Types: []ContentType{TypePost, TypeVideo}}, FeedItem{Type: TypeVideo}, true},
+		{"type non demandé", FeedRequest{Types: []ContentType{TypePost}}, FeedItem{Type: TypeVideo}, false},
+		{"langue refusée", FeedRequest{Languages: []string{"fr"}}, FeedItem{Type: TypePost, Language: "de"}, false},
+		{"type demandé, langue refusée", FeedRequest{Types: []ContentType{TypePost}, Languages: []string{"fr"}}, FeedItem{Type: TypePost, Language: "de"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.Accepts(&tt.item); got != tt.want {
+				t.Errorf("Accepts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeLanguages(t *testing.T) {
+	tooMany := make([]string, MaxAcceptedLanguages+1)
+	for i := range tooMany {
+		tooMany[i] = string([]byte{'a' + byte(i/26), 'a' + byte(i%26)})
+	}
+
+	tests := []struct {
+		name      string
+		languages []string
+		want      []string
+		wantErr   error
+	}{
+		{"minuscules et espaces retirés", []string{" FR", "en "}, []string{"fr", "en"}, nil},
+		{"doublons retirés, ordre gardé", []string{"en", "fr", "EN"}, []string{"en", "fr"}, nil},
+		{"liste vide : toutes les langues", nil, []string{}, nil},
+		{"code sur trois lettres", []string{"fra"}, nil, ErrInvalidLanguage},
+		{"code régional", []string{"fr-FR"}, nil, ErrInvalidLanguage},
+		{"code vide", []string{""}, nil, ErrInvalidLanguage},
+		{"trop de langues", tooMany, nil, ErrInvalidLanguage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeLanguages(tt.languages)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("NormalizeLanguages(%s) = %v, want %v", strings.Join(tt.languages, ","), got, tt.want)
+			}
+		})
+	}
+}
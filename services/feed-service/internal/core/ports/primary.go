@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/jupiterclapton/cenackle/services/feed-service/internal/core/domain"
 )
@@ -12,6 +13,14 @@ type FeedService interface {
 	// DistributePost est appelé quand un event "PostCreated" arrive
 	DistributePost(ctx context.Context, item *domain.FeedItem) error
 
-	// GetTimeline est appelé par l'API Gateway pour l'affichage
-	GetTimeline(ctx context.Context, req domain.FeedRequest) ([]*domain.FeedItem, error)
+	// RetractPost est appelé quand un event "PostDeleted" arrive (retractedAt : date de l'événement)
+	RetractPost(ctx context.Context, postID string, retractedAt time.Time) error
+
+	// GetTimeline est appelé par l'API Gateway pour l'affichage (filtré selon les langues acceptées du lecteur).
+	// nextOffset : offset de la page suivante (0 = timeline épuisée)
+	GetTimeline(ctx context.Context, req domain.FeedRequest) (items []*domain.FeedItem, nextOffset int64, err error)
+
+	// Langues acceptées dans le fil (liste vide = toutes)
+	GetAcceptedLanguages(ctx context.Context, userID string) ([]string, error)
+	SetAcceptedLanguages(ctx context.Context, userID string, languages []string) ([]string, error)
 }
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/jupiterclapton/cenackle/services/feed-service/internal/core/domain"
 )
@@ -12,11 +13,24 @@ type FeedRepository interface {
 	// AddToTimelines ajoute un post dans les feeds de PLUSIEURS utilisateurs (Batch)
 	AddToTimelines(ctx context.Context, userIDs []string, item *domain.FeedItem) error
 
-	// GetTimeline récupère les items bruts depuis Redis
-	GetTimeline(ctx context.Context, req domain.FeedRequest) ([]*domain.FeedItem, error)
+	// RemoveFromTimelines retire un post de toutes les timelines qui l'ont reçu (nombre de timelines modifiées)
+	// et laisse une pierre tombale : AddToTimelines n'écrit plus les annonces antérieures à retractedAt
+	// (fan-out encore en cours ou relivré après le retrait)
+	RemoveFromTimelines(ctx context.Context, postID string, retractedAt time.Time) (int, error)
+
+	// GetTimeline récupère jusqu'à req.Limit items acceptés par req (cf. FeedRequest.Accepts) depuis Redis,
+	// et l'offset de la page suivante (0 = timeline épuisée)
+	GetTimeline(ctx context.Context, req domain.FeedRequest) ([]*domain.FeedItem, int64, error)
+
+	// Préférences de lecture : langues acceptées (vide = toutes)
+	GetLanguages(ctx context.Context, userID string) ([]string, error)
+	SetLanguages(ctx context.Context, userID string, languages []string) error
 }
 
 type GraphClient interface {
 	// GetFollowers récupère les ID des abonnés (Stream ou Pagination pour la perf)
 	GetFollowers(ctx context.Context, userID string) ([]string, error)
+
+	// GetCloseFriends récupère la liste d'amis proches (audience des posts "close_friends")
+	GetCloseFriends(ctx context.Context, userID string) ([]string, error)
 }
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/jupiterclapton/cenackle/services/feed-service/internal/core/domain"
 	"github.com/jupiterclapton/cenackle/services/feed-service/internal/core/ports"
@@ -25,25 +26,25 @@ func NewFeedService(repo ports.FeedRepository, graph ports.GraphClient) *FeedSer
 func (s *FeedService) DistributePost(ctx context.Context, item *domain.FeedItem) error {
 	slog.Info("📢 Fan-out starting", "post_id", item.PostID, "author_id", item.AuthorID)
 
-	// 1. Récupérer les followers via gRPC (Graph Service)
-	followers, err := s.graphClient.GetFollowers(ctx, item.AuthorID)
+	// 1. Récupérer les destinataires via gRPC (Graph Service), selon l'audience du post
+	recipients, err := s.recipients(ctx, item)
 	if err != nil {
 		return err
 	}
 
-	if len(followers) == 0 {
+	if len(recipients) == 0 {
 		return nil
 	}
 
 	// 2. Batch Processing (Chunking)
-	// On découpe la liste des followers pour ne pas saturer Redis ou la RAM
-	for i := 0; i < len(followers); i += BatchSize {
+	// On découpe la liste des destinataires pour ne pas saturer Redis ou la RAM
+	for i := 0; i < len(recipients); i += BatchSize {
 		end := i + BatchSize
-		if end > len(followers) {
-			end = len(followers)
+		if end > len(recipients) {
+			end = len(recipients)
 		}
 
-		batch := followers[i:end]
+		batch := recipients[i:end]
 
 		// 3. Écriture Redis (Pipeline)
 		err := s.repo.AddToTimelines(ctx, batch, item)
@@ -54,10 +55,64 @@ func (s *FeedService) DistributePost(ctx context.Context, item *domain.FeedItem)
 		}
 	}
 
-	slog.Info("✅ Fan-out complete", "count", len(followers))
+	slog.Info("✅ Fan-out complete", "count", len(recipients))
 	return nil
 }
 
-func (s *FeedService) GetTimeline(ctx context.Context, req domain.FeedRequest) ([]*domain.FeedItem, error) {
+// recipients : on n'écrit jamais un post dans la timeline de quelqu'un qui ne peut pas le lire
+func (s *FeedService) recipients(ctx context.Context, item *domain.FeedItem) ([]string, error) {
+	switch item.Visibility {
+	case domain.VisibilityCloseFriends:
+		return s.graphClient.GetCloseFriends(ctx, item.AuthorID)
+	case domain.VisibilityMentioned:
+		if len(item.MentionedUserIDs) == 0 {
+			return nil, nil
+		}
+		followers, err := s.graphClient.GetFollowers(ctx, item.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		return item.KeepMentioned(followers), nil
+	default:
+		// "public" et "followers" : tous les abonnés (ancien format d'event sans visibilité = public)
+		return s.graphClient.GetFollowers(ctx, item.AuthorID)
+	}
+}
+
+// RetractPost : un post supprimé disparaît de toutes les timelines où le fan-out l'avait écrit,
+// y compris celles qu'un fan-out concurrent (ou relivré) atteindrait après le retrait
+func (s *FeedService) RetractPost(ctx context.Context, postID string, retractedAt time.Time) error {
+	removed, err := s.repo.RemoveFromTimelines(ctx, postID, retractedAt)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("🗑️ Post retracted from timelines", "post_id", postID, "count", removed)
+	return nil
+}
+
+// GetTimeline : les langues acceptées sont appliquées à la lecture (le fan-out écrit tout,
+// changer ses préférences s'applique donc aussi aux posts déjà reçus)
+func (s *FeedService) GetTimeline(ctx context.Context, req domain.FeedRequest) ([]*domain.FeedItem, int64, error) {
+	languages, err := s.repo.GetLanguages(ctx, req.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Languages = languages
 	return s.repo.GetTimeline(ctx, req)
 }
+
+func (s *FeedService) GetAcceptedLanguages(ctx context.Context, userID string) ([]string, error) {
+	return s.repo.GetLanguages(ctx, userID)
+}
+
+func (s *FeedService) SetAcceptedLanguages(ctx context.Context, userID string, languages []string) ([]string, error) {
+	languages, err := domain.NormalizeLanguages(languages)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetLanguages(ctx, userID, languages); err != nil {
+		return nil, err
+	}
+	return languages, nil
+}
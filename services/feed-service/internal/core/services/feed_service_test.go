@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/jupiterclapton/cenackle/services/feed-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/feed-service/internal/core/ports"
+)
+
+// fakeFeedRepo : préférences de langue en mémoire, retient la requête de lecture
+type fakeFeedRepo struct {
+	ports.FeedRepository
+	languages map[string][]string
+	lastReq   domain.FeedRequest
+}
+
+func (r *fakeFeedRepo) GetLanguages(ctx context.Context, userID string) ([]string, error) {
+	return r.languages[userID], nil
+}
+
+func (r *fakeFeedRepo) SetLanguages(ctx context.Context, userID string, languages []string) error {
+	r.languages[userID] = languages
+	return nil
+}
+
+func (r *fakeFeedRepo) GetTimeline(ctx context.Context, req domain.FeedRequest) ([]*domain.FeedItem, int64, error) {
+	r.lastReq = req
+	return nil, 0, nil
+}
+
+func TestGetTimelineAppliesAcceptedLanguages(t *testing.T) {
+	repo := &fakeFeedRepo{languages: map[string][]string{}}
+	s := NewFeedService(repo, nil)
+
+	if _, err := s.SetAcceptedLanguages(t.Context(), "u1", []string{"FR", "en", "fr"}); err != nil {
+		t.Fatalf("SetAcceptedLanguages: %v", err)
+	}
+	// La requête du client ne choisit pas les langues : seules les préférences enregistrées comptent
+	if _, _, err := s.GetTimeline(t.Context(), domain.FeedRequest{UserID: "u1", Limit: 20, Languages: []string{"de"}}); err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+	if want := []string{"fr", "en"}; !slices.Equal(repo.lastReq.Languages, want) {
+		t.Errorf("langues = %v, want %v", repo.lastReq.Languages, want)
+	}
+	if repo.lastReq.AcceptsLanguage("de") {
+		t.Error("un post en allemand ne doit pas être montré")
+	}
+}
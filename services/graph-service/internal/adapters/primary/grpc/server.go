@@ -2,14 +2,17 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	graphv1 "github.com/jupiterclapton/cenackle/gen/graph/v1"
+	"github.com/jupiterclapton/cenackle/services/graph-service/internal/core/domain"
 	"github.com/jupiterclapton/cenackle/services/graph-service/internal/core/ports"
 )
 
@@ -48,10 +51,37 @@ func (s *Server) CheckRelation(ctx context.Context, req *graphv1.CheckRelationRe
 	if err != nil {
 		return nil, status.Error(codes.Internal, "internal error")
 	}
-	return &graphv1.CheckRelationResponse{
-		IsFollowing:  statusRel.IsFollowing,
-		IsFollowedBy: statusRel.IsFollowedBy,
-	}, nil
+	return mapRelationStatusToProto(statusRel), nil
+}
+
+// CheckRelations : variante batch de CheckRelation (une page de posts = un appel)
+func (s *Server) CheckRelations(ctx context.Context, req *graphv1.CheckRelationsRequest) (*graphv1.CheckRelationsResponse, error) {
+	statuses, err := s.service.CheckRelations(ctx, req.ActorId, req.TargetIds)
+	if err != nil {
+		slog.Error("Batch relation check failed", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	resp := &graphv1.CheckRelationsResponse{Relations: make(map[string]*graphv1.CheckRelationResponse, len(statuses))}
+	for id, st := range statuses {
+		resp.Relations[id] = mapRelationStatusToProto(st)
+	}
+	return resp, nil
+}
+
+func (s *Server) AddCloseFriend(ctx context.Context, req *graphv1.CloseFriendRequest) (*emptypb.Empty, error) {
+	if err := s.service.AddCloseFriend(ctx, req.OwnerId, req.FriendId); err != nil {
+		slog.Error("Add close friend failed", "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) RemoveCloseFriend(ctx context.Context, req *graphv1.CloseFriendRequest) (*emptypb.Empty, error) {
+	if err := s.service.RemoveCloseFriend(ctx, req.OwnerId, req.FriendId); err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) StreamFollowers(req *graphv1.StreamFollowersRequest, stream graphv1.GraphService_StreamFollowersServer) error {
@@ -70,12 +100,67 @@ func (s *Server) StreamFollowers(req *graphv1.StreamFollowersRequest, stream gra
 	return nil
 }
 
-// GetFollowers (Not implemented yet - placeholder)
+func (s *Server) StreamCloseFriends(req *graphv1.StreamCloseFriendsRequest, stream graphv1.GraphService_StreamCloseFriendsServer) error {
+	const BatchSize = 1000
+
+	err := s.service.StreamCloseFriends(stream.Context(), req.UserId, BatchSize, func(ids []string) error {
+		return stream.Send(&graphv1.StreamCloseFriendsResponse{
+			UserIds: ids,
+		})
+	})
+
+	if err != nil {
+		slog.Error("Streaming failed", "error", err)
+		return status.Error(codes.Internal, "streaming failed")
+	}
+	return nil
+}
+
+func mapRelationStatusToProto(st *domain.RelationStatus) *graphv1.CheckRelationResponse {
+	return &graphv1.CheckRelationResponse{
+		IsFollowing:   st.IsFollowing,
+		IsFollowedBy:  st.IsFollowedBy,
+		IsCloseFriend: st.IsCloseFriend,
+	}
+}
+
+// GetFollowers : liste paginée des abonnés (UI, export des données personnelles)
 func (s *Server) GetFollowers(ctx context.Context, req *graphv1.GetFollowersRequest) (*graphv1.GetFollowersResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "use StreamFollowers")
+	relations, next, err := s.service.GetFollowers(ctx, req.UserId, int(req.PageSize), req.PageToken)
+	if err != nil {
+		return nil, mapListError(err)
+	}
+
+	resp := &graphv1.GetFollowersResponse{Relations: make([]*graphv1.Relation, len(relations)), NextPageToken: next}
+	for i, rel := range relations {
+		resp.Relations[i] = mapRelationToProto(rel.ActorID, rel)
+	}
+	return resp, nil
 }
 
-// GetFollowing (Not implemented yet)
+// GetFollowing : liste paginée des abonnements
 func (s *Server) GetFollowing(ctx context.Context, req *graphv1.GetFollowingRequest) (*graphv1.GetFollowingResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented")
+	relations, next, err := s.service.GetFollowing(ctx, req.UserId, int(req.PageSize), req.PageToken)
+	if err != nil {
+		return nil, mapListError(err)
+	}
+
+	resp := &graphv1.GetFollowingResponse{Relations: make([]*graphv1.Relation, len(relations)), NextPageToken: next}
+	for i, rel := range relations {
+		resp.Relations[i] = mapRelationToProto(rel.TargetID, rel)
+	}
+	return resp, nil
+}
+
+// mapRelationToProto : userID est l'autre extrémité du lien, vue depuis l'utilisateur demandé
+func mapRelationToProto(userID string, rel *domain.Relation) *graphv1.Relation {
+	return &graphv1.Relation{UserId: userID, CreatedAt: timestamppb.New(rel.CreatedAt)}
+}
+
+func mapListError(err error) error {
+	if errors.Is(err, domain.ErrInvalidPageToken) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	slog.Error("Relation listing failed", "error", err)
+	return status.Error(codes.Internal, "internal error")
 }
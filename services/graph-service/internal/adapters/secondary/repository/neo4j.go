@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jupiterclapton/cenackle/services/graph-service/internal/core/domain"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -74,7 +75,8 @@ func (r *Neo4jRepo) GetRelationStatus(ctx context.Context, actorID, targetID str
 		query := `
 			MATCH (a:User {id: $actorId}), (b:User {id: $targetId})
 			RETURN EXISTS((a)-[:FOLLOWS]->(b)) as following, 
-			       EXISTS((b)-[:FOLLOWS]->(a)) as followedBy
+			       EXISTS((b)-[:FOLLOWS]->(a)) as followedBy,
+			       EXISTS((b)-[:CLOSE_FRIEND]->(a)) as closeFriend
 		`
 		res, err := tx.Run(ctx, query, map[string]any{"actorId": actorID, "targetId": targetID})
 		if err != nil {
@@ -85,9 +87,11 @@ func (r *Neo4jRepo) GetRelationStatus(ctx context.Context, actorID, targetID str
 			rec := res.Record()
 			following, _ := rec.Get("following")
 			followedBy, _ := rec.Get("followedBy")
+			closeFriend, _ := rec.Get("closeFriend")
 			return &domain.RelationStatus{
-				IsFollowing:  following.(bool),
-				IsFollowedBy: followedBy.(bool),
+				IsFollowing:   following.(bool),
+				IsFollowedBy:  followedBy.(bool),
+				IsCloseFriend: closeFriend.(bool),
 			}, nil
 		}
 		// Si aucun noeud trouvé, on considère false/false
@@ -100,15 +104,163 @@ func (r *Neo4jRepo) GetRelationStatus(ctx context.Context, actorID, targetID str
 	return result.(*domain.RelationStatus), nil
 }
 
+// GetRelationStatuses : UNWIND = une seule requête pour toute une page de posts
+func (r *Neo4jRepo) GetRelationStatuses(ctx context.Context, actorID string, targetIDs []string) (map[string]*domain.RelationStatus, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		query := `
+			MATCH (a:User {id: $actorId})
+			UNWIND $targetIds AS targetId
+			MATCH (b:User {id: targetId})
+			RETURN b.id as targetId,
+			       EXISTS((a)-[:FOLLOWS]->(b)) as following,
+			       EXISTS((b)-[:FOLLOWS]->(a)) as followedBy,
+			       EXISTS((b)-[:CLOSE_FRIEND]->(a)) as closeFriend
+		`
+		res, err := tx.Run(ctx, query, map[string]any{"actorId": actorID, "targetIds": targetIDs})
+		if err != nil {
+			return nil, err
+		}
+
+		statuses := make(map[string]*domain.RelationStatus, len(targetIDs))
+		for res.Next(ctx) {
+			rec := res.Record()
+			targetID, _ := rec.Get("targetId")
+			following, _ := rec.Get("following")
+			followedBy, _ := rec.Get("followedBy")
+			closeFriend, _ := rec.Get("closeFriend")
+			statuses[targetID.(string)] = &domain.RelationStatus{
+				IsFollowing:   following.(bool),
+				IsFollowedBy:  followedBy.(bool),
+				IsCloseFriend: closeFriend.(bool),
+			}
+		}
+		return statuses, res.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]*domain.RelationStatus), nil
+}
+
+// CreateCloseFriend : lien privé (owner)-[:CLOSE_FRIEND]->(friend), jamais exposé à friend
+func (r *Neo4jRepo) CreateCloseFriend(ctx context.Context, ownerID, friendID string) error {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		query := `
+			MERGE (a:User {id: $ownerId})
+			MERGE (b:User {id: $friendId})
+			MERGE (a)-[r:CLOSE_FRIEND]->(b)
+			ON CREATE SET r.created_at = datetime()
+		`
+		_, err := tx.Run(ctx, query, map[string]any{"ownerId": ownerID, "friendId": friendID})
+		return nil, err
+	})
+	return err
+}
+
+func (r *Neo4jRepo) DeleteCloseFriend(ctx context.Context, ownerID, friendID string) error {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		query := `
+			MATCH (a:User {id: $ownerId})-[r:CLOSE_FRIEND]->(b:User {id: $friendId})
+			DELETE r
+		`
+		_, err := tx.Run(ctx, query, map[string]any{"ownerId": ownerID, "friendId": friendID})
+		return nil, err
+	})
+	return err
+}
+
 // StreamFollowersIDs : La méthode pour le Fan-out
 func (r *Neo4jRepo) StreamFollowersIDs(ctx context.Context, userID string, batchSize int, yield func([]string) error) error {
+	// La requête cherche tous les noeuds 'f' qui ont une flèche FOLLOWS vers 'u'
+	query := `MATCH (u:User {id: $userId})<-[:FOLLOWS]-(f:User) RETURN f.id as id`
+	return r.streamIDs(ctx, query, userID, batchSize, yield)
+}
+
+// StreamCloseFriendsIDs : Fan-out des posts réservés aux amis proches
+func (r *Neo4jRepo) StreamCloseFriendsIDs(ctx context.Context, userID string, batchSize int, yield func([]string) error) error {
+	query := `MATCH (u:User {id: $userId})-[:CLOSE_FRIEND]->(f:User) RETURN f.id as id`
+	return r.streamIDs(ctx, query, userID, batchSize, yield)
+}
+
+// ListFollowers : (f)-[:FOLLOWS]->(u), plus récents d'abord
+func (r *Neo4jRepo) ListFollowers(ctx context.Context, userID string, limit int, cursor *domain.RelationCursor) ([]*domain.Relation, error) {
+	query := `
+		MATCH (u:User {id: $userId})<-[r:FOLLOWS]-(o:User)
+		WITH o, r.created_at AS since
+		WHERE $cursorTime IS NULL OR since < $cursorTime OR (since = $cursorTime AND o.id > $cursorId)
+		RETURN o.id AS id, since
+		ORDER BY since DESC, o.id ASC
+		LIMIT $limit
+	`
+	return r.listRelations(ctx, query, userID, limit, cursor, func(otherID string, since time.Time) *domain.Relation {
+		return &domain.Relation{ActorID: otherID, TargetID: userID, Type: "FOLLOWS", CreatedAt: since}
+	})
+}
+
+// ListFollowing : (u)-[:FOLLOWS]->(o), plus récents d'abord
+func (r *Neo4jRepo) ListFollowing(ctx context.Context, userID string, limit int, cursor *domain.RelationCursor) ([]*domain.Relation, error) {
+	query := `
+		MATCH (u:User {id: $userId})-[r:FOLLOWS]->(o:User)
+		WITH o, r.created_at AS since
+		WHERE $cursorTime IS NULL OR since < $cursorTime OR (since = $cursorTime AND o.id > $cursorId)
+		RETURN o.id AS id, since
+		ORDER BY since DESC, o.id ASC
+		LIMIT $limit
+	`
+	return r.listRelations(ctx, query, userID, limit, cursor, func(otherID string, since time.Time) *domain.Relation {
+		return &domain.Relation{ActorID: userID, TargetID: otherID, Type: "FOLLOWS", CreatedAt: since}
+	})
+}
+
+// listRelations exécute une page keyset ; 'build' oriente le lien selon la liste demandée
+func (r *Neo4jRepo) listRelations(ctx context.Context, query, userID string, limit int, cursor *domain.RelationCursor, build func(otherID string, since time.Time) *domain.Relation) ([]*domain.Relation, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
-	// Note: On n'utilise pas ExecuteRead ici car on veut streamer le résultat manuellement
-	// La requête cherche tous les noeuds 'f' qui ont une flèche FOLLOWS vers 'u'
-	query := `MATCH (u:User {id: $userId})<-[:FOLLOWS]-(f:User) RETURN f.id as followerId`
+	params := map[string]any{"userId": userID, "limit": limit, "cursorTime": nil, "cursorId": ""}
+	if cursor != nil {
+		params["cursorTime"] = cursor.CreatedAt
+		params["cursorId"] = cursor.UserID
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		res, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
 
+		relations := []*domain.Relation{}
+		for res.Next(ctx) {
+			rec := res.Record()
+			id, _ := rec.Get("id")
+			since, _ := rec.Get("since")
+			createdAt, _ := since.(time.Time)
+			relations = append(relations, build(id.(string), createdAt.UTC()))
+		}
+		return relations, res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*domain.Relation), nil
+}
+
+// streamIDs lit le résultat au fil de l'eau et le renvoie par paquets de batchSize
+func (r *Neo4jRepo) streamIDs(ctx context.Context, query, userID string, batchSize int, yield func([]string) error) error {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	// Note: On n'utilise pas ExecuteRead ici car on veut streamer le résultat manuellement
 	res, err := session.Run(ctx, query, map[string]any{"userId": userID})
 	if err != nil {
 		return err
@@ -117,7 +269,7 @@ func (r *Neo4jRepo) StreamFollowersIDs(ctx context.Context, userID string, batch
 	batch := make([]string, 0, batchSize)
 
 	for res.Next(ctx) {
-		id, _ := res.Record().Get("followerId")
+		id, _ := res.Record().Get("id")
 		batch = append(batch, id.(string))
 
 		if len(batch) >= batchSize {
@@ -1,6 +1,12 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidPageToken : curseur de pagination illisible (GetFollowers / GetFollowing)
+var ErrInvalidPageToken = errors.New("invalid page token")
 
 // Relation représente un lien dirigé dans le graphe (User -> Follows -> User)
 type Relation struct {
@@ -10,8 +16,15 @@ type Relation struct {
 	CreatedAt time.Time
 }
 
+// RelationCursor : position dans une liste triée par date de follow décroissante (égalités départagées par ID)
+type RelationCursor struct {
+	CreatedAt time.Time
+	UserID    string
+}
+
 // RelationStatus est utilisé pour l'UI (CheckRelation)
 type RelationStatus struct {
-	IsFollowing  bool // Actor suit Target
-	IsFollowedBy bool // Target suit Actor
+	IsFollowing   bool // Actor suit Target
+	IsFollowedBy  bool // Target suit Actor
+	IsCloseFriend bool // Target a mis Actor dans sa liste (privée) d'amis proches
 }
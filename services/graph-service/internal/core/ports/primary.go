@@ -11,8 +11,20 @@ type GraphService interface {
 	FollowUser(ctx context.Context, actorID, targetID string) error
 	UnfollowUser(ctx context.Context, actorID, targetID string) error
 	CheckRelation(ctx context.Context, actorID, targetID string) (*domain.RelationStatus, error)
+	// CheckRelations : variante batch (un lecteur, plusieurs auteurs) pour le filtrage de visibilité
+	CheckRelations(ctx context.Context, actorID string, targetIDs []string) (map[string]*domain.RelationStatus, error)
+
+	// Liste privée d'amis proches (audience "close_friends")
+	AddCloseFriend(ctx context.Context, ownerID, friendID string) error
+	RemoveCloseFriend(ctx context.Context, ownerID, friendID string) error
 
 	// StreamFollowers est crucial pour le Fan-out.
 	// Il renvoie les followers par paquets via le callback 'yield'.
 	StreamFollowers(ctx context.Context, userID string, batchSize int, yield func([]string) error) error
+	// StreamCloseFriends : même principe, pour le Fan-out des posts "close_friends"
+	StreamCloseFriends(ctx context.Context, userID string, batchSize int, yield func([]string) error) error
+
+	// Listes paginées (UI, export des données) : pageToken vide = première page, jeton suivant vide = fin
+	GetFollowers(ctx context.Context, userID string, pageSize int, pageToken string) ([]*domain.Relation, string, error)
+	GetFollowing(ctx context.Context, userID string, pageSize int, pageToken string) ([]*domain.Relation, string, error)
 }
@@ -14,7 +14,18 @@ type GraphRepository interface {
 	CreateRelation(ctx context.Context, actorID, targetID string) error
 	DeleteRelation(ctx context.Context, actorID, targetID string) error
 	GetRelationStatus(ctx context.Context, actorID, targetID string) (*domain.RelationStatus, error)
+	// GetRelationStatuses : une seule requête pour N cibles (les cibles inconnues sont absentes)
+	GetRelationStatuses(ctx context.Context, actorID string, targetIDs []string) (map[string]*domain.RelationStatus, error)
+
+	CreateCloseFriend(ctx context.Context, ownerID, friendID string) error
+	DeleteCloseFriend(ctx context.Context, ownerID, friendID string) error
 
 	// StreamFollowersIDs doit utiliser le curseur natif de Neo4j pour la performance
 	StreamFollowersIDs(ctx context.Context, userID string, batchSize int, yield func([]string) error) error
+	StreamCloseFriendsIDs(ctx context.Context, userID string, batchSize int, yield func([]string) error) error
+
+	// ListFollowers / ListFollowing : pagination keyset, plus récents d'abord (cursor nil = première page).
+	// Relation.ActorID / TargetID sont renseignés dans le sens du lien FOLLOWS.
+	ListFollowers(ctx context.Context, userID string, limit int, cursor *domain.RelationCursor) ([]*domain.Relation, error)
+	ListFollowing(ctx context.Context, userID string, limit int, cursor *domain.RelationCursor) ([]*domain.Relation, error)
 }
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/jupiterclapton/cenackle/services/graph-service/internal/core/domain"
 	"github.com/jupiterclapton/cenackle/services/graph-service/internal/core/ports"
@@ -34,6 +37,125 @@ func (s *graphService) CheckRelation(ctx context.Context, actorID, targetID stri
 	return s.repo.GetRelationStatus(ctx, actorID, targetID)
 }
 
+// MaxCheckRelations borne la taille d'un batch (une page de Feed, pas tout le graphe)
+const MaxCheckRelations = 500
+
+func (s *graphService) CheckRelations(ctx context.Context, actorID string, targetIDs []string) (map[string]*domain.RelationStatus, error) {
+	if len(targetIDs) > MaxCheckRelations {
+		return nil, errors.New("too many targets")
+	}
+
+	statuses := make(map[string]*domain.RelationStatus, len(targetIDs))
+	if actorID == "" || len(targetIDs) == 0 {
+		return statuses, nil
+	}
+
+	found, err := s.repo.GetRelationStatuses(ctx, actorID, targetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chaque cible demandée a une réponse (false/false si aucun lien)
+	for _, id := range targetIDs {
+		if st, ok := found[id]; ok {
+			statuses[id] = st
+		} else {
+			statuses[id] = &domain.RelationStatus{}
+		}
+	}
+	return statuses, nil
+}
+
+func (s *graphService) AddCloseFriend(ctx context.Context, ownerID, friendID string) error {
+	if ownerID == "" || friendID == "" {
+		return errors.New("ids cannot be empty")
+	}
+	if ownerID == friendID {
+		return errors.New("cannot add yourself as close friend")
+	}
+	return s.repo.CreateCloseFriend(ctx, ownerID, friendID)
+}
+
+func (s *graphService) RemoveCloseFriend(ctx context.Context, ownerID, friendID string) error {
+	return s.repo.DeleteCloseFriend(ctx, ownerID, friendID)
+}
+
+func (s *graphService) StreamCloseFriends(ctx context.Context, userID string, batchSize int, yield func([]string) error) error {
+	return s.repo.StreamCloseFriendsIDs(ctx, userID, batchSize, yield)
+}
+
 func (s *graphService) StreamFollowers(ctx context.Context, userID string, batchSize int, yield func([]string) error) error {
 	return s.repo.StreamFollowersIDs(ctx, userID, batchSize, yield)
 }
+
+// Bornes des listes paginées
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+func (s *graphService) GetFollowers(ctx context.Context, userID string, pageSize int, pageToken string) ([]*domain.Relation, string, error) {
+	return s.listRelations(ctx, userID, pageSize, pageToken, s.repo.ListFollowers, func(r *domain.Relation) string { return r.ActorID })
+}
+
+func (s *graphService) GetFollowing(ctx context.Context, userID string, pageSize int, pageToken string) ([]*domain.Relation, string, error) {
+	return s.listRelations(ctx, userID, pageSize, pageToken, s.repo.ListFollowing, func(r *domain.Relation) string { return r.TargetID })
+}
+
+// listRelations : 'other' désigne l'autre extrémité du lien, qui départage les follows de même date
+func (s *graphService) listRelations(
+	ctx context.Context, userID string, pageSize int, pageToken string,
+	list func(context.Context, string, int, *domain.RelationCursor) ([]*domain.Relation, error),
+	other func(*domain.Relation) string,
+) ([]*domain.Relation, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user id cannot be empty")
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
+
+	cursor, err := decodeRelationCursor(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Un élément de plus que demandé : savoir s'il reste une page sans requête supplémentaire
+	relations, err := list(ctx, userID, pageSize+1, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(relations) <= pageSize {
+		return relations, "", nil
+	}
+
+	relations = relations[:pageSize]
+	last := relations[pageSize-1]
+	return relations, encodeRelationCursor(&domain.RelationCursor{CreatedAt: last.CreatedAt, UserID: other(last)}), nil
+}
+
+// Jeton opaque : "<date RFC 3339 nano>|<user id>" en base64 URL
+func encodeRelationCursor(c *domain.RelationCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.UserID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeRelationCursor(token string) (*domain.RelationCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, domain.ErrInvalidPageToken
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, domain.ErrInvalidPageToken
+	}
+	return &domain.RelationCursor{CreatedAt: createdAt, UserID: id}, nil
+}
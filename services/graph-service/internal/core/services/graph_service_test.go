@@ -0,0 +1,55 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/graph-service/internal/core/domain"
+)
+
+func TestRelationCursorRoundTrip(t *testing.T) {
+	// Nanosecondes et fuseau non UTC : la pagination (created_at, user_id) doit reprendre au même point
+	at := time.Date(2026, 10, 18, 14, 3, 7, 123456789, time.FixedZone("CEST", 2*3600))
+	cursor := &domain.RelationCursor{CreatedAt: at, UserID: "7c9e6679-7425-40de-944b-e07fc1f90ae7"}
+
+	got, err := decodeRelationCursor(encodeRelationCursor(cursor))
+	if err != nil {
+		t.Fatalf("decodeRelationCursor: %v", err)
+	}
+	if !got.CreatedAt.Equal(at) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, at)
+	}
+	if got.UserID != cursor.UserID {
+		t.Errorf("UserID = %q, want %q", got.UserID, cursor.UserID)
+	}
+}
+
+func TestDecodeRelationCursorEmpty(t *testing.T) {
+	got, err := decodeRelationCursor("")
+	if err != nil || got != nil {
+		t.Errorf("decodeRelationCursor(\"\") = %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestDecodeRelationCursorInvalid(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"pas du base64", "%%%"},
+		{"sans séparateur", encode("2026-10-18T12:00:00Z")},
+		{"sans utilisateur", encode("2026-10-18T12:00:00Z|")},
+		{"date invalide", encode("hier|user-1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeRelationCursor(tt.token); !errors.Is(err, domain.ErrInvalidPageToken) {
+				t.Errorf("erreur = %v, want %v", err, domain.ErrInvalidPageToken)
+			}
+		})
+	}
+}
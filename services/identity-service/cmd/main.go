@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -31,9 +32,12 @@ import (
 	// Interne
 	"github.com/jupiterclapton/cenackle/services/identity-service/config"
 	grpc_adapter "github.com/jupiterclapton/cenackle/services/identity-service/internal/adapters/primary/grpc"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/adapters/secondary/archive"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/adapters/secondary/clients"
 	"github.com/jupiterclapton/cenackle/services/identity-service/internal/adapters/secondary/eventbroker"
 	"github.com/jupiterclapton/cenackle/services/identity-service/internal/adapters/secondary/repository"
 	"github.com/jupiterclapton/cenackle/services/identity-service/internal/adapters/secondary/security"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/adapters/secondary/storage"
 	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/services"
 )
 
@@ -113,14 +117,66 @@ func main() {
 
 	hasher := security.NewArgon2Hasher(nil) // Params par défaut
 
+	challengeSigner, err := security.NewHashcashSigner([]byte(cfg.PowSecret))
+	if err != nil {
+		slog.Error("Failed to init challenge signer", "error", err)
+		os.Exit(1)
+	}
+
+	// 6b. Infrastructure : Export des données personnelles (Post & Graph Services, stockage des archives)
+	postClient, err := clients.NewPostClient(cfg.PostServiceURL)
+	if err != nil {
+		slog.Error("Unable to connect to Post Service", "error", err)
+		os.Exit(1)
+	}
+	defer postClient.Close()
+
+	graphClient, err := clients.NewGraphClient(cfg.GraphServiceURL)
+	if err != nil {
+		slog.Error("Unable to connect to Graph Service", "error", err)
+		os.Exit(1)
+	}
+	defer graphClient.Close()
+
+	exportStorage, err := storage.NewLocalStorage(cfg.ExportDir, cfg.ExportBaseURL, []byte(cfg.ExportSigningSecret))
+	if err != nil {
+		slog.Error("Failed to init export storage", "error", err)
+		os.Exit(1)
+	}
+
 	// 7. Wiring (Injection de dépendances) - Adapters -> Service
 	repo := repository.NewPostgresRepo(dbPool)
 
 	// Orchestration du cœur
-	identityService := services.NewIdentityService(repo, hasher, jwtProvider, broker)
+	identityService := services.NewIdentityService(repo, hasher, jwtProvider, broker, repo, challengeSigner, repo, services.ChallengePolicy{
+		BaseDifficulty: cfg.PowBaseDifficulty,
+		MaxDifficulty:  cfg.PowMaxDifficulty,
+		TTL:            cfg.PowChallengeTTL,
+		RateWindow:     cfg.PowRateWindow,
+	})
+	exportService := services.NewExportService(repo, repo, repo)
+
+	// 7b. Préparation des exports (tourne sur chaque réplica, cf. FOR UPDATE SKIP LOCKED)
+	exportWorker := services.NewExportWorker(repo, repo, repo, postClient, graphClient, archive.NewZipWriter(), exportStorage, broker,
+		cfg.ExportLinkTTL, cfg.ExportPollInterval, cfg.ExportBatchSize)
+	go exportWorker.Run(ctx)
+
+	// 7c. Téléchargement des archives (liens signés, sans authentification)
+	downloadServer := &http.Server{
+		Addr:              cfg.ExportHTTPAddr,
+		Handler:           exportStorage.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		slog.Info("📦 Export download server listening", "address", cfg.ExportHTTPAddr)
+		if err := downloadServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("Export download server error", "error", err)
+			os.Exit(1)
+		}
+	}()
 
 	// Adapter Primaire (gRPC Handler)
-	grpcHandler := grpc_adapter.NewAuthGrpcServer(identityService)
+	grpcHandler := grpc_adapter.NewAuthGrpcServer(identityService, exportService)
 
 	// 8. Configuration du Serveur gRPC
 	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
@@ -181,10 +237,17 @@ func main() {
 	sig := <-quit // Bloquant
 	slog.Info("⚠️  Signal received, shutting down...", "signal", sig)
 
+	// Arrêt du worker d'export (un export interrompu sera repris à l'expiration de son bail)
+	cancel()
+
 	// Création d'un timeout pour l'arrêt
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
 
+	if err := downloadServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Export download server forced to shutdown", "error", err)
+	}
+
 	// Arrêt de gRPC (finit les requêtes en cours)
 	// Note: GracefulStop ne prend pas de contexte, mais on peut forcer Stop() après timeout
 	done := make(chan struct{})
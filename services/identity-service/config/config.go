@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -19,6 +20,26 @@ type Config struct {
 	RSAPrivateKeyPath string
 	RSAPublicKeyPath  string
 
+	// Anti-bot (Proof-of-Work sur l'inscription)
+	PowSecret         string // Clé HMAC de signature des défis (>= 32 octets)
+	PowBaseDifficulty int    // Bits à zéro exigés par défaut
+	PowMaxDifficulty  int
+	PowChallengeTTL   time.Duration
+	PowRateWindow     time.Duration // Fenêtre de comptage des inscriptions par IP
+
+	// Autres services (export des données personnelles)
+	PostServiceURL  string
+	GraphServiceURL string
+
+	// Export des données personnelles (archives sur le système de fichiers, liens signés)
+	ExportDir           string        // Racine du stockage local des archives
+	ExportHTTPAddr      string        // Écoute du serveur de téléchargement
+	ExportBaseURL       string        // URL publique de ce serveur (préfixe des liens signés)
+	ExportSigningSecret string        // Clé HMAC des liens (>= 32 octets)
+	ExportLinkTTL       time.Duration // Validité d'un lien de téléchargement
+	ExportPollInterval  time.Duration
+	ExportBatchSize     int // Exports préparés par tour (une archive peut être lourde : petits lots)
+
 	// Telemetry
 	OtelEndpoint string // URL du collecteur (Jaeger/Tempo)
 }
@@ -33,13 +54,34 @@ func Load() (*Config, error) {
 		NatsUrl:           getEnv("NATS_URL", "nats://localhost:4222"),
 		RSAPrivateKeyPath: getEnv("RSA_PRIVATE_KEY_PATH", "./keys/private.pem"),
 		RSAPublicKeyPath:  getEnv("RSA_PUBLIC_KEY_PATH", "./keys/public.pem"),
+		PowSecret:         getEnv("POW_SECRET", "local-dev-pow-secret-change-me-in-prod!"),
+		PowBaseDifficulty: getEnvInt("POW_BASE_DIFFICULTY", 18), // ~260k hashs en moyenne (< 1s sur mobile)
+		PowMaxDifficulty:  getEnvInt("POW_MAX_DIFFICULTY", 26),
+		PowChallengeTTL:   time.Duration(getEnvInt("POW_CHALLENGE_TTL_SECONDS", 300)) * time.Second,
+		PowRateWindow:     time.Duration(getEnvInt("POW_RATE_WINDOW_MINUTES", 60)) * time.Minute,
 		OtelEndpoint:      getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+
+		PostServiceURL:      getEnv("POST_SERVICE_URL", "localhost:50053"),
+		GraphServiceURL:     getEnv("GRAPH_SERVICE_URL", "localhost:50052"),
+		ExportDir:           getEnv("EXPORT_DIR", "./data/exports"),
+		ExportHTTPAddr:      getEnv("EXPORT_HTTP_ADDR", ":8081"),
+		ExportBaseURL:       getEnv("EXPORT_BASE_URL", "http://localhost:8081"),
+		ExportSigningSecret: getEnv("EXPORT_SIGNING_SECRET", "local-dev-export-secret-change-me-in-prod!"),
+		ExportLinkTTL:       time.Duration(getEnvInt("EXPORT_LINK_TTL_HOURS", 72)) * time.Hour,
+		ExportPollInterval:  time.Duration(getEnvInt("EXPORT_POLL_INTERVAL_SECONDS", 30)) * time.Second,
+		ExportBatchSize:     getEnvInt("EXPORT_BATCH_SIZE", 5),
 	}
 
 	// Validation basique pour éviter de démarrer avec une config cassée
 	if cfg.Env == "prod" && cfg.DBUrl == "" {
 		return nil, fmt.Errorf("DB_URL is required in production")
 	}
+	if cfg.Env == "prod" && os.Getenv("POW_SECRET") == "" {
+		return nil, fmt.Errorf("POW_SECRET is required in production")
+	}
+	if cfg.Env == "prod" && os.Getenv("EXPORT_SIGNING_SECRET") == "" {
+		return nil, fmt.Errorf("EXPORT_SIGNING_SECRET is required in production")
+	}
 
 	return cfg, nil
 }
@@ -0,0 +1,19 @@
+-- --- ANTI-BOT : Proof-of-Work sur l'inscription ---
+
+-- Anti-rejeu : un défi résolu ne peut servir qu'une seule fois.
+-- Les lignes expirées sont purgées par le service lors des insertions.
+CREATE TABLE IF NOT EXISTS consumed_challenges (
+    id TEXT PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_consumed_challenges_expires_at ON consumed_challenges(expires_at);
+
+-- Débit d'inscriptions par IP (sert à adapter la difficulté des défis)
+CREATE TABLE IF NOT EXISTS signup_attempts (
+    ip TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Permet le COUNT(*) sur la fenêtre glissante sans scanner toute la table
+CREATE INDEX IF NOT EXISTS idx_signup_attempts_ip_created_at ON signup_attempts(ip, created_at DESC);
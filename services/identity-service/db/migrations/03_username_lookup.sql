@@ -0,0 +1,2 @@
+-- Résolution des @mentions (post-service) : recherche insensible à la casse par username
+CREATE INDEX IF NOT EXISTS idx_users_username_lower ON users (LOWER(username));
@@ -0,0 +1,9 @@
+-- --- MODÉRATION : rôles et suspension de compte ---
+
+-- "user", "moderator", "admin" (attribués à la main, pas d'API d'escalade de privilèges)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+-- Suspension décidée par la modération (Post Service) : NULL = compte en règle.
+-- Un compte suspendu ne peut plus se connecter et ses jetons sont refusés par ValidateToken.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspension_reason TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- --- PRÉFÉRENCES DE LECTURE ---
+
+-- Médias marqués sensibles (auteur ou modération) : floutés par défaut, affichés si l'utilisateur l'a choisi.
+-- Transmis au Gateway par ValidateToken, qui le répercute sur les lectures du Post Service.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS show_sensitive_media BOOLEAN NOT NULL DEFAULT FALSE;
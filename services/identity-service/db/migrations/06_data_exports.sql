@@ -0,0 +1,34 @@
+-- --- DONNÉES PERSONNELLES : journal de sécurité et export (portabilité RGPD) ---
+
+-- Journal de sécurité : connexions, changements d'identifiants, sanctions. Restitué dans l'export.
+CREATE TABLE IF NOT EXISTS security_events (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(40) NOT NULL,
+    ip_address TEXT NOT NULL DEFAULT '',
+    device TEXT NOT NULL DEFAULT '',
+    detail TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_user ON security_events (user_id, created_at DESC);
+
+-- Demandes d'export : préparées en asynchrone par l'ExportWorker (archive ZIP déposée dans le stockage objet)
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- "pending", "ready", "failed"
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    object_key TEXT NOT NULL DEFAULT '',
+    link_expires_at TIMESTAMPTZ,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), -- Prise en charge par un worker = bail repoussé
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+-- Un seul export en préparation par utilisateur (une nouvelle demande renvoie celui-ci)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_exports_pending_user ON data_exports (user_id) WHERE status = 'pending';
+
+-- File du worker
+CREATE INDEX IF NOT EXISTS idx_data_exports_next_attempt ON data_exports (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,13 @@
+-- --- DONNÉES PERSONNELLES : livraison fiable de l'export ---
+
+-- Annonce data_export_ready accusée par le broker : tant qu'elle est NULL, le worker la republie
+ALTER TABLE data_exports ADD COLUMN IF NOT EXISTS notified_at TIMESTAMPTZ;
+
+-- Les exports déjà prêts ont été annoncés (au mieux) par l'ancienne version : on ne les rejoue pas
+UPDATE data_exports SET notified_at = completed_at WHERE status = 'ready' AND notified_at IS NULL;
+
+-- Statuts : "pending", "ready", "failed", "expired" (archive supprimée après expiration du lien)
+
+-- Annonces à republier et archives expirées à supprimer
+CREATE INDEX IF NOT EXISTS idx_data_exports_unnotified ON data_exports (next_attempt_at) WHERE status = 'ready' AND notified_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_data_exports_link_expiry ON data_exports (link_expires_at) WHERE status = 'ready';
@@ -0,0 +1,5 @@
+-- --- ANTI-BOT : purge du débit d'inscriptions ---
+
+-- Les inscriptions sorties de la fenêtre d'observation ne comptent plus : RecordSignup les supprime
+-- à chaque insertion (purge par date, toutes IP confondues)
+CREATE INDEX IF NOT EXISTS idx_signup_attempts_created_at ON signup_attempts(created_at);
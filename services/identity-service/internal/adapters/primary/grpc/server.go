@@ -21,6 +21,7 @@ import (
 type Server struct {
 	identityv1.UnimplementedIdentityServiceServer // Obligatoire pour la compatibilité forward
 	service                                       ports.IdentityService
+	exports                                       ports.DataExportService
 }
 
 // RegisterTo permet d'enregistrer ce handler sur un serveur gRPC existant
@@ -29,18 +30,35 @@ func (s *Server) RegisterTo(grpcServer *grpc.Server) {
 }
 
 // NewAuthGrpcServer initialise le serveur gRPC
-func NewAuthGrpcServer(service ports.IdentityService) *Server {
-	return &Server{service: service}
+func NewAuthGrpcServer(service ports.IdentityService, exports ports.DataExportService) *Server {
+	return &Server{service: service, exports: exports}
+}
+
+// GetRegistrationChallenge
+func (s *Server) GetRegistrationChallenge(ctx context.Context, req *identityv1.GetRegistrationChallengeRequest) (*identityv1.GetRegistrationChallengeResponse, error) {
+	challenge, err := s.service.GetRegistrationChallenge(ctx, req.IpAddress)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &identityv1.GetRegistrationChallengeResponse{
+		Challenge:  challenge.Token,
+		Difficulty: int32(challenge.Difficulty),
+		ExpiresAt:  timestamppb.New(challenge.ExpiresAt),
+	}, nil
 }
 
 // Register (Implemente IdentityServiceServer)
 func (s *Server) Register(ctx context.Context, req *identityv1.RegisterRequest) (*identityv1.RegisterResponse, error) {
 	// 1. Mapping Proto -> Domain Cmd
 	cmd := ports.RegisterCmd{
-		Email:    req.Email,
-		Password: req.Password,
-		Username: req.Username,
-		FullName: req.FullName,
+		Email:          req.Email,
+		Password:       req.Password,
+		Username:       req.Username,
+		FullName:       req.FullName,
+		IP:             req.IpAddress,
+		Challenge:      req.Challenge,
+		ChallengeNonce: req.ChallengeNonce,
 	}
 
 	// 2. Appel Service
@@ -96,6 +114,16 @@ func (s *Server) GetUser(ctx context.Context, req *identityv1.GetUserRequest) (*
 	}, nil
 }
 
+// ResolveUsernames : batch interne (ex: résolution des @mentions par le Post Service)
+func (s *Server) ResolveUsernames(ctx context.Context, req *identityv1.ResolveUsernamesRequest) (*identityv1.ResolveUsernamesResponse, error) {
+	ids, err := s.service.ResolveUsernames(ctx, req.Usernames)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &identityv1.ResolveUsernamesResponse{UserIds: ids}, nil
+}
+
 // UpdateProfile
 func (s *Server) UpdateProfile(ctx context.Context, req *identityv1.UpdateProfileRequest) (*identityv1.UpdateProfileResponse, error) {
 	// L'utilisation de 'optional' dans le proto génère des pointeurs (*string) en Go.
@@ -104,6 +132,8 @@ func (s *Server) UpdateProfile(ctx context.Context, req *identityv1.UpdateProfil
 		UserID:   req.UserId,
 		FullName: req.FullName, // Type: *string
 		Email:    req.Email,    // Type: *string
+
+		ShowSensitiveMedia: req.ShowSensitiveMedia, // Type: *bool
 	}
 
 	updatedUser, err := s.service.UpdateProfile(ctx, cmd)
@@ -127,7 +157,7 @@ func (s *Server) ChangePassword(ctx context.Context, req *identityv1.ChangePassw
 
 // ValidateToken
 func (s *Server) ValidateToken(ctx context.Context, req *identityv1.ValidateTokenRequest) (*identityv1.ValidateTokenResponse, error) {
-	userID, err := s.service.ValidateToken(ctx, req.Token)
+	user, err := s.service.ValidateToken(ctx, req.Token)
 	if err != nil {
 		// Ici, on ne renvoie pas forcément une erreur gRPC, mais une réponse valide disant "faux"
 		// Ou alors on renvoie Unauthenticated. C'est un choix d'API.
@@ -139,7 +169,51 @@ func (s *Server) ValidateToken(ctx context.Context, req *identityv1.ValidateToke
 
 	return &identityv1.ValidateTokenResponse{
 		IsValid: true,
-		UserId:  userID,
+		UserId:  user.ID,
+		Role:    string(user.Role),
+
+		ShowSensitiveMedia: user.ShowSensitiveMedia,
+	}, nil
+}
+
+// SuspendUser : appelé par la modération du Post Service
+func (s *Server) SuspendUser(ctx context.Context, req *identityv1.SuspendUserRequest) (*identityv1.SuspendUserResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	cmd := ports.SuspendUserCmd{
+		UserID:      req.UserId,
+		ModeratorID: req.ModeratorId,
+		Reason:      req.Reason,
+	}
+	if req.Until != nil {
+		cmd.Until = req.Until.AsTime()
+	}
+
+	user, err := s.service.SuspendUser(ctx, cmd)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &identityv1.SuspendUserResponse{User: mapUserToProto(user)}, nil
+}
+
+// RequestDataExport : l'archive est préparée en asynchrone, le lien arrive par événement
+func (s *Server) RequestDataExport(ctx context.Context, req *identityv1.RequestDataExportRequest) (*identityv1.RequestDataExportResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	export, err := s.exports.RequestDataExport(ctx, req.UserId)
+	if err != nil {
+		return nil, mapDomainError(err)
+	}
+
+	return &identityv1.RequestDataExportResponse{
+		ExportId:    export.ID,
+		Status:      string(export.Status),
+		RequestedAt: timestamppb.New(export.RequestedAt),
 	}, nil
 }
 
@@ -170,7 +244,7 @@ func mapUserToProto(u *domain.User) *identityv1.User {
 	if u == nil {
 		return nil
 	}
-	return &identityv1.User{
+	user := &identityv1.User{
 		Id:        u.ID,
 		Email:     u.Email,
 		Username:  u.Username,
@@ -178,7 +252,14 @@ func mapUserToProto(u *domain.User) *identityv1.User {
 		IsActive:  u.IsActive,
 		CreatedAt: timestamppb.New(u.CreatedAt),
 		UpdatedAt: timestamppb.New(u.UpdatedAt),
+		Role:      string(u.Role),
+
+		ShowSensitiveMedia: u.ShowSensitiveMedia,
 	}
+	if !u.SuspendedUntil.IsZero() {
+		user.SuspendedUntil = timestamppb.New(u.SuspendedUntil)
+	}
+	return user
 }
 
 // mapDomainError traduit les erreurs métier en codes d'erreur gRPC standard
@@ -192,8 +273,18 @@ func mapDomainError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Is(err, domain.ErrInvalidToken):
 		return status.Error(codes.Unauthenticated, "invalid token")
-	case errors.Is(err, domain.ErrInvalidEmail) || errors.Is(err, domain.ErrInvalidUsername):
+	case errors.Is(err, domain.ErrAccountSuspended):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrInvalidEmail) || errors.Is(err, domain.ErrInvalidUsername),
+		errors.Is(err, domain.ErrInvalidSuspension):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrChallengeRequired),
+		errors.Is(err, domain.ErrChallengeInvalid),
+		errors.Is(err, domain.ErrChallengeUnsolved):
 		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrChallengeExpired), errors.Is(err, domain.ErrChallengeReplayed):
+		// Le client doit redemander un défi
+		return status.Error(codes.FailedPrecondition, err.Error())
 	default:
 		// Erreur interne (DB down, etc.) -> ne pas fuiter les détails techniques
 		return status.Error(codes.Internal, "internal server error")
@@ -0,0 +1,78 @@
+package archive
+
+import (
+	"html/template"
+	"time"
+)
+
+// indexView : données de index.html (mêmes DTOs que les fichiers JSON)
+type indexView struct {
+	GeneratedAt    time.Time
+	Profile        profileDTO
+	SecurityEvents []securityEventDTO
+	Posts          []postDTO
+	Followers      []connectionDTO
+	Following      []connectionDTO
+}
+
+// indexTemplate : html/template échappe le contenu des posts (aucun HTML utilisateur n'est interprété)
+var indexTemplate = template.Must(template.New("index").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.UTC().Format("02/01/2006 15:04 UTC") },
+}).Parse(`<!DOCTYPE html>
+<html lang="fr">
+<head>
+<meta charset="utf-8">
+<title>Vos données — @{{.Profile.Username}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 960px; margin: 2rem auto; padding: 0 1rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2rem; }
+th, td { border-bottom: 1px solid #ddd; padding: .4rem; text-align: left; vertical-align: top; }
+.post { border: 1px solid #ddd; border-radius: 6px; padding: .8rem; margin-bottom: 1rem; white-space: pre-wrap; }
+.meta { color: #666; font-size: .85rem; }
+</style>
+</head>
+<body>
+<h1>Vos données</h1>
+<p class="meta">Export généré le {{date .GeneratedAt}}. Les mêmes données, au format JSON, sont dans les autres fichiers de cette archive.</p>
+
+<h2>Profil <small>(profile.json)</small></h2>
+<table>
+<tr><th>Identifiant</th><td>{{.Profile.ID}}</td></tr>
+<tr><th>Nom d'utilisateur</th><td>@{{.Profile.Username}}</td></tr>
+<tr><th>Nom complet</th><td>{{.Profile.FullName}}</td></tr>
+<tr><th>Email</th><td>{{.Profile.Email}}</td></tr>
+<tr><th>Rôle</th><td>{{.Profile.Role}}</td></tr>
+<tr><th>Médias sensibles affichés</th><td>{{if .Profile.ShowSensitiveMedia}}oui{{else}}non{{end}}</td></tr>
+{{with .Profile.SuspendedUntil}}<tr><th>Suspendu jusqu'au</th><td>{{date .}}</td></tr>{{end}}
+<tr><th>Inscription</th><td>{{date .Profile.CreatedAt}}</td></tr>
+</table>
+
+<h2>Journal de sécurité <small>(security_events.json, {{len .SecurityEvents}})</small></h2>
+<table>
+<tr><th>Date</th><th>Événement</th><th>IP</th><th>Appareil</th><th>Détail</th></tr>
+{{range .SecurityEvents}}<tr><td>{{date .CreatedAt}}</td><td>{{.Kind}}</td><td>{{.IP}}</td><td>{{.Device}}</td><td>{{.Detail}}</td></tr>
+{{end}}</table>
+
+<h2>Posts <small>(posts.json, {{len .Posts}})</small></h2>
+{{range .Posts}}<div class="post">
+<div class="meta">{{date .CreatedAt}} · {{.Status}} · {{.Visibility}}{{with .EditedAt}} · modifié le {{date .}}{{end}}{{with .DeletedAt}} · supprimé le {{date .}}{{end}}</div>
+{{with .ContentWarning}}<p><strong>Avertissement :</strong> {{.}}</p>{{end}}
+{{.Content}}
+{{range .Media}}<div class="meta">Média ({{.Type}}{{if .Sensitive}}, sensible{{end}}) : <a href="{{.URL}}">{{.URL}}</a></div>{{end}}
+</div>
+{{end}}
+
+<h2>Abonnés <small>(followers.json, {{len .Followers}})</small></h2>
+<table>
+<tr><th>Utilisateur</th><th>Depuis le</th></tr>
+{{range .Followers}}<tr><td>{{.UserID}}</td><td>{{date .Since}}</td></tr>
+{{end}}</table>
+
+<h2>Abonnements <small>(following.json, {{len .Following}})</small></h2>
+<table>
+<tr><th>Utilisateur</th><th>Depuis le</th></tr>
+{{range .Following}}<tr><td>{{.UserID}}</td><td>{{date .Since}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,202 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+)
+
+// ZipWriter met l'export en forme : un fichier JSON par source (lisible par une machine)
+// et un index.html (lisible par un humain, sans dépendance externe).
+type ZipWriter struct{}
+
+func NewZipWriter() *ZipWriter {
+	return &ZipWriter{}
+}
+
+func (w *ZipWriter) ContentType() string {
+	return "application/zip"
+}
+
+func (w *ZipWriter) Build(bundle *domain.ExportBundle) ([]byte, error) {
+	profile := toProfileDTO(bundle.User)
+	events := toSecurityEventDTOs(bundle.SecurityEvents)
+	posts := toPostDTOs(bundle.Posts)
+	followers := toConnectionDTOs(bundle.Followers)
+	following := toConnectionDTOs(bundle.Following)
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", profile},
+		{"security_events.json", events},
+		{"posts.json", posts},
+		{"followers.json", followers},
+		{"following.json", following},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for _, f := range files {
+		data, err := json.MarshalIndent(f.data, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal %s: %w", f.name, err)
+		}
+		if err := writeFile(zw, f.name, data, bundle.GeneratedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	var index bytes.Buffer
+	err := indexTemplate.Execute(&index, indexView{
+		GeneratedAt:    bundle.GeneratedAt,
+		Profile:        profile,
+		SecurityEvents: events,
+		Posts:          posts,
+		Followers:      followers,
+		Following:      following,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("render index: %w", err)
+	}
+	if err := writeFile(zw, "index.html", index.Bytes(), bundle.GeneratedAt); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close zip: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeFile(zw *zip.Writer, name string, data []byte, modified time.Time) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// --- DTOs (format public de l'archive : ne pas renommer les champs) ---
+
+type profileDTO struct {
+	ID                 string     `json:"id"`
+	Email              string     `json:"email"`
+	Username           string     `json:"username"`
+	FullName           string     `json:"full_name"`
+	Role               string     `json:"role"`
+	IsActive           bool       `json:"is_active"`
+	ShowSensitiveMedia bool       `json:"show_sensitive_media"`
+	SuspendedUntil     *time.Time `json:"suspended_until,omitempty"`
+	SuspensionReason   string     `json:"suspension_reason,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+type securityEventDTO struct {
+	Kind      string    `json:"kind"`
+	IP        string    `json:"ip_address,omitempty"`
+	Device    string    `json:"device,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type postDTO struct {
+	ID             string     `json:"id"`
+	Content        string     `json:"content"`
+	ContentWarning string     `json:"content_warning,omitempty"`
+	Media          []mediaDTO `json:"media,omitempty"`
+	Status         string     `json:"status"`
+	Visibility     string     `json:"visibility"`
+	Language       string     `json:"language,omitempty"`
+	RepostedPostID string     `json:"reposted_post_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	EditedAt       *time.Time `json:"edited_at,omitempty"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+type mediaDTO struct {
+	ID        string `json:"id"`
+	URL       string `json:"url"`
+	Type      string `json:"type"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+}
+
+type connectionDTO struct {
+	UserID string    `json:"user_id"`
+	Since  time.Time `json:"since"`
+}
+
+func toProfileDTO(u *domain.User) profileDTO {
+	return profileDTO{
+		ID:                 u.ID,
+		Email:              u.Email,
+		Username:           u.Username,
+		FullName:           u.FullName,
+		Role:               string(u.Role),
+		IsActive:           u.IsActive,
+		ShowSensitiveMedia: u.ShowSensitiveMedia,
+		SuspendedUntil:     optionalTime(u.SuspendedUntil),
+		SuspensionReason:   u.SuspensionReason,
+		CreatedAt:          u.CreatedAt,
+		UpdatedAt:          u.UpdatedAt,
+	}
+}
+
+func toSecurityEventDTOs(events []*domain.SecurityEvent) []securityEventDTO {
+	dtos := make([]securityEventDTO, len(events))
+	for i, e := range events {
+		dtos[i] = securityEventDTO{Kind: string(e.Kind), IP: e.IP, Device: e.Device, Detail: e.Detail, CreatedAt: e.CreatedAt}
+	}
+	return dtos
+}
+
+func toPostDTOs(posts []*domain.ExportedPost) []postDTO {
+	dtos := make([]postDTO, len(posts))
+	for i, p := range posts {
+		dto := postDTO{
+			ID:             p.ID,
+			Content:        p.Content,
+			ContentWarning: p.ContentWarning,
+			Status:         p.Status,
+			Visibility:     p.Visibility,
+			Language:       p.Language,
+			RepostedPostID: p.RepostedPostID,
+			CreatedAt:      p.CreatedAt,
+			UpdatedAt:      p.UpdatedAt,
+			EditedAt:       optionalTime(p.EditedAt),
+			DeletedAt:      optionalTime(p.DeletedAt),
+		}
+		for _, m := range p.Media {
+			dto.Media = append(dto.Media, mediaDTO{ID: m.ID, URL: m.URL, Type: m.Type, Sensitive: m.Sensitive})
+		}
+		dtos[i] = dto
+	}
+	return dtos
+}
+
+func toConnectionDTOs(connections []*domain.Connection) []connectionDTO {
+	dtos := make([]connectionDTO, len(connections))
+	for i, c := range connections {
+		dtos[i] = connectionDTO{UserID: c.UserID, Since: c.Since}
+	}
+	return dtos
+}
+
+// optionalTime : le temps zéro du domaine est omis du JSON
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,91 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+)
+
+func TestZipWriterBuild(t *testing.T) {
+	generated := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	bundle := &domain.ExportBundle{
+		Export: &domain.DataExport{ID: "export-1", UserID: "user-1"},
+		User:   &domain.User{ID: "user-1", Email: "ada@example.com", Username: "ada", FullName: "Ada <Lovelace>"},
+		SecurityEvents: []*domain.SecurityEvent{
+			{Kind: domain.SecurityDataExportRequested, CreatedAt: generated},
+		},
+		Posts: []*domain.ExportedPost{
+			{ID: "post-1", Content: "bonjour", Status: "published", Visibility: "public", CreatedAt: generated,
+				Media: []domain.ExportedMedia{{ID: "m1", URL: "https://cdn.test/m1.jpg", Type: "image", Sensitive: true}}},
+		},
+		Followers:   []*domain.Connection{{UserID: "user-2", Since: generated}},
+		GeneratedAt: generated,
+	}
+
+	data, err := NewZipWriter().Build(bundle)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("archive illisible : %v", err)
+	}
+
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name, err)
+		}
+		files[f.Name] = content
+	}
+
+	for _, name := range []string{"profile.json", "security_events.json", "posts.json", "followers.json", "following.json", "index.html"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("%s absent de l'archive", name)
+		}
+	}
+
+	var profile profileDTO
+	if err := json.Unmarshal(files["profile.json"], &profile); err != nil {
+		t.Fatalf("profile.json: %v", err)
+	}
+	if profile.Username != "ada" || profile.Email != "ada@example.com" {
+		t.Errorf("profil = %+v", profile)
+	}
+
+	var posts []postDTO
+	if err := json.Unmarshal(files["posts.json"], &posts); err != nil {
+		t.Fatalf("posts.json: %v", err)
+	}
+	if len(posts) != 1 || posts[0].ID != "post-1" || len(posts[0].Media) != 1 || !posts[0].Media[0].Sensitive {
+		t.Errorf("posts = %+v", posts)
+	}
+	if posts[0].EditedAt != nil || posts[0].DeletedAt != nil {
+		t.Error("les dates zéro doivent être omises")
+	}
+
+	// Sans abonnement, la liste est vide et non null (format stable pour les outils de lecture)
+	if got := strings.TrimSpace(string(files["following.json"])); got != "[]" {
+		t.Errorf("following.json = %s, want []", got)
+	}
+
+	index := string(files["index.html"])
+	if strings.Contains(index, "Ada <Lovelace>") {
+		t.Error("index.html : contenu utilisateur non échappé")
+	}
+	if !strings.Contains(index, "bonjour") {
+		t.Error("index.html : post absent")
+	}
+}
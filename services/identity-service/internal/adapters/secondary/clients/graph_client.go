@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	graphv1 "github.com/jupiterclapton/cenackle/gen/graph/v1"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+)
+
+// relationsPageSize : taille maximale d'une page GetFollowers / GetFollowing
+const relationsPageSize = 500
+
+type GraphClient struct {
+	client graphv1.GraphServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewGraphClient initialise la connexion gRPC (utilisée par l'export des données personnelles)
+func NewGraphClient(targetURL string) (*GraphClient, error) {
+	conn, err := grpc.NewClient(targetURL,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphClient{
+		client: graphv1.NewGraphServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+func (c *GraphClient) Close() error {
+	return c.conn.Close()
+}
+
+// ListFollowers parcourt toutes les pages de GetFollowers
+func (c *GraphClient) ListFollowers(ctx context.Context, userID string) ([]*domain.Connection, error) {
+	var connections []*domain.Connection
+	pageToken := ""
+	for {
+		resp, err := c.client.GetFollowers(ctx, &graphv1.GetFollowersRequest{
+			UserId:    userID,
+			PageSize:  relationsPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("graph-service: get followers: %w", err)
+		}
+		connections = appendConnections(connections, resp.Relations)
+		if resp.NextPageToken == "" {
+			return connections, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// ListFollowing parcourt toutes les pages de GetFollowing
+func (c *GraphClient) ListFollowing(ctx context.Context, userID string) ([]*domain.Connection, error) {
+	var connections []*domain.Connection
+	pageToken := ""
+	for {
+		resp, err := c.client.GetFollowing(ctx, &graphv1.GetFollowingRequest{
+			UserId:    userID,
+			PageSize:  relationsPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("graph-service: get following: %w", err)
+		}
+		connections = appendConnections(connections, resp.Relations)
+		if resp.NextPageToken == "" {
+			return connections, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func appendConnections(connections []*domain.Connection, relations []*graphv1.Relation) []*domain.Connection {
+	for _, r := range relations {
+		connections = append(connections, &domain.Connection{UserID: r.UserId, Since: r.CreatedAt.AsTime()})
+	}
+	return connections
+}
@@ -0,0 +1,88 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+)
+
+// exportPageSize : pages de l'appel interne ExportUserPosts (500 max côté Post Service)
+const exportPageSize = 500
+
+type PostClient struct {
+	client postv1.PostServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewPostClient initialise la connexion gRPC (utilisée par l'export des données personnelles)
+func NewPostClient(targetURL string) (*PostClient, error) {
+	conn, err := grpc.NewClient(targetURL,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PostClient{
+		client: postv1.NewPostServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+func (c *PostClient) Close() error {
+	return c.conn.Close()
+}
+
+// ListUserPosts parcourt toutes les pages d'ExportUserPosts
+func (c *PostClient) ListUserPosts(ctx context.Context, userID string) ([]*domain.ExportedPost, error) {
+	var posts []*domain.ExportedPost
+	pageToken := ""
+	for {
+		resp, err := c.client.ExportUserPosts(ctx, &postv1.ExportUserPostsRequest{
+			UserId:    userID,
+			Limit:     exportPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("post-service: export user posts: %w", err)
+		}
+		for _, p := range resp.Posts {
+			posts = append(posts, mapExportedPost(p))
+		}
+		if resp.NextPageToken == "" {
+			return posts, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func mapExportedPost(p *postv1.Post) *domain.ExportedPost {
+	post := &domain.ExportedPost{
+		ID:             p.Id,
+		Content:        p.Content,
+		ContentWarning: p.ContentWarning,
+		Status:         p.Status,
+		Visibility:     p.Visibility,
+		Language:       p.Language,
+		RepostedPostID: p.RepostedPostId,
+		CreatedAt:      p.CreatedAt.AsTime(),
+		UpdatedAt:      p.UpdatedAt.AsTime(),
+	}
+	if p.EditedAt != nil {
+		post.EditedAt = p.EditedAt.AsTime()
+	}
+	if p.DeletedAt != nil {
+		post.DeletedAt = p.DeletedAt.AsTime()
+	}
+	for _, m := range p.Media {
+		post.Media = append(post.Media, domain.ExportedMedia{ID: m.Id, URL: m.Url, Type: m.Type, Sensitive: m.Sensitive})
+	}
+	return post
+}
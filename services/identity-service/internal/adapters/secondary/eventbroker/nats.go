@@ -2,17 +2,26 @@ package eventbroker
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	eventsv1 "github.com/jupiterclapton/cenackle/gen/events/v1"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream" // Le nouveau SDK JetStream
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
 	StreamName     = "IDENTITY"
 	SubjectPattern = "identity.>" // Tous les events identity.*
+
+	producer     = "identity-service"
+	eventVersion = 1 // Version des contrats events.v1 produits ici
 )
 
 type NatsBroker struct {
@@ -48,38 +57,69 @@ func NewNatsBroker(url string) (*NatsBroker, error) {
 	return &NatsBroker{js: js}, nil
 }
 
-// Payload de l'événement (pourrait être généré par Protobuf)
-type UserRegisteredEvent struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-}
-
 func (n *NatsBroker) PublishUserRegistered(ctx context.Context, userID, email string) error {
-	// 1. Préparation du payload
-	eventData := UserRegisteredEvent{
-		UserID: userID,
+	// identity.user.registered -> permet aux subscribers de filtrer facilement
+	env := newEnvelope(ctx, "identity.user.registered")
+	env.Payload = &eventsv1.EventEnvelope_UserRegistered{UserRegistered: &eventsv1.UserRegistered{
+		UserId: userID,
 		Email:  email,
+	}}
+	return n.publish(ctx, env)
+}
+
+func (n *NatsBroker) PublishUserSuspended(ctx context.Context, user *domain.User, moderatorID string) error {
+	env := newEnvelope(ctx, "identity.user.suspended")
+	env.Payload = &eventsv1.EventEnvelope_UserSuspended{UserSuspended: &eventsv1.UserSuspended{
+		UserId:         user.ID,
+		ModeratorId:    moderatorID,
+		Reason:         user.SuspensionReason,
+		SuspendedUntil: timestamppb.New(user.SuspendedUntil),
+		Permanent:      user.SuspendedUntil.Equal(domain.PermanentSuspension),
+	}}
+	return n.publish(ctx, env)
+}
+
+// PublishDataExportReady : le Notification Service relaie le lien à l'utilisateur.
+// L'ID d'événement est celui de l'export : une annonce republiée par le worker est dédupliquée par JetStream.
+func (n *NatsBroker) PublishDataExportReady(ctx context.Context, export *domain.DataExport, downloadURL string) error {
+	env := newEnvelope(ctx, "identity.user.data_export_ready")
+	env.EventId = export.ID
+	env.Payload = &eventsv1.EventEnvelope_DataExportReady{DataExportReady: &eventsv1.DataExportReady{
+		ExportId:    export.ID,
+		UserId:      export.UserID,
+		DownloadUrl: downloadURL,
+		ExpiresAt:   timestamppb.New(export.LinkExpiresAt),
+	}}
+	return n.publish(ctx, env)
+}
+
+// --- Helpers ---
+
+// newEnvelope : ID, date et contexte de trace de la requête en cours. L'appelant fixe le payload.
+func newEnvelope(ctx context.Context, eventType string) *eventsv1.EventEnvelope {
+	trace := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, trace)
+
+	return &eventsv1.EventEnvelope{
+		EventId:      uuid.New().String(),
+		EventType:    eventType,
+		Version:      eventVersion,
+		OccurredAt:   timestamppb.Now(),
+		Producer:     producer,
+		TraceContext: trace,
 	}
+}
 
-	data, err := json.Marshal(eventData)
+// publish : JetStream garantit que le serveur a bien reçu et persisté le message.
+// event_id sert de Nats-Msg-Id : un essai rejoué n'est pas stocké deux fois.
+func (n *NatsBroker) publish(ctx context.Context, env *eventsv1.EventEnvelope) error {
+	data, err := proto.Marshal(env)
 	if err != nil {
 		return fmt.Errorf("marshal event: %w", err)
 	}
 
-	// 2. Définition du Sujet (Topic)
-	// identity.user.registered -> permet aux subscribers de filtrer facilement
-	subject := "identity.user.registered"
-
-	// 3. Publication Async avec confirmation
-	// JetStream garantit que le serveur a bien reçu et persisté le message
-	ack, err := n.js.Publish(ctx, subject, data)
-	if err != nil {
+	if _, err := n.js.Publish(ctx, env.EventType, data, jetstream.WithMsgID(env.EventId)); err != nil {
 		return fmt.Errorf("nats publish: %w", err)
 	}
-
-	// Optionnel : logger l'ACK sequence pour debug
-	// fmt.Printf("Published event on %s, seq: %d\n", subject, ack.Sequence)
-	_ = ack
-
 	return nil
 }
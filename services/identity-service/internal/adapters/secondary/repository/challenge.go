@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+)
+
+// --- ANTI-BOT : implémentation de ports.ChallengeStore ---
+
+// ConsumeChallenge insère l'ID du défi : la clé primaire garantit l'usage unique (même entre replicas).
+// On profite de l'écriture pour purger les défis expirés (ils ne peuvent plus être rejoués).
+func (r *PostgresRepo) ConsumeChallenge(ctx context.Context, challengeID string, expiresAt time.Time) error {
+	q := `
+		WITH purge AS (
+			DELETE FROM consumed_challenges WHERE expires_at < NOW()
+		)
+		INSERT INTO consumed_challenges (id, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (id) DO NOTHING
+	`
+	tag, err := r.db.Exec(ctx, q, challengeID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("db: consume challenge: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrChallengeReplayed
+	}
+	return nil
+}
+
+// RecordSignup trace une inscription réussie pour le calcul de difficulté.
+// Comme pour ConsumeChallenge, on profite de l'écriture pour purger les inscriptions sorties de la fenêtre.
+func (r *PostgresRepo) RecordSignup(ctx context.Context, ip string, at, purgeBefore time.Time) error {
+	q := `
+		WITH purge AS (
+			DELETE FROM signup_attempts WHERE created_at < $3
+		)
+		INSERT INTO signup_attempts (ip, created_at) VALUES ($1, $2)
+	`
+	_, err := r.db.Exec(ctx, q, ip, at, purgeBefore)
+	if err != nil {
+		return fmt.Errorf("db: record signup: %w", err)
+	}
+	return nil
+}
+
+// CountSignupsSince compte les inscriptions d'une IP sur la fenêtre glissante
+func (r *PostgresRepo) CountSignupsSince(ctx context.Context, ip string, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM signup_attempts WHERE ip = $1 AND created_at >= $2`,
+		ip, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("db: count signups: %w", err)
+	}
+	return count, nil
+}
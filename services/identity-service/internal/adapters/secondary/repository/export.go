@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+)
+
+// --- DONNÉES PERSONNELLES : implémentation de ports.SecurityEventRepository et ports.DataExportRepository ---
+
+func (r *PostgresRepo) RecordSecurityEvent(ctx context.Context, event *domain.SecurityEvent) error {
+	q := `
+		INSERT INTO security_events (id, user_id, kind, ip_address, device, detail, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.Exec(ctx, q, event.ID, event.UserID, string(event.Kind), event.IP, event.Device, event.Detail, event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("db: record security event: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepo) ListSecurityEvents(ctx context.Context, userID string) ([]*domain.SecurityEvent, error) {
+	q := `
+		SELECT id, user_id, kind, ip_address, device, detail, created_at
+		FROM security_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("db: list security events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.SecurityEvent
+	for rows.Next() {
+		var e domain.SecurityEvent
+		var kind string
+		if err := rows.Scan(&e.ID, &e.UserID, &kind, &e.IP, &e.Device, &e.Detail, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("db: scan security event: %w", err)
+		}
+		e.Kind = domain.SecurityEventKind(kind)
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+// Colonnes lues pour hydrater un export (l'ordre doit suivre scanExport)
+const exportColumns = `id, user_id, status, requested_at, completed_at, object_key, link_expires_at, attempts, next_attempt_at, last_error, notified_at`
+
+// CreateExport : l'index unique partiel garantit un seul export "pending" par utilisateur
+func (r *PostgresRepo) CreateExport(ctx context.Context, export *domain.DataExport) error {
+	q := `
+		INSERT INTO data_exports (id, user_id, status, requested_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) WHERE status = 'pending' DO NOTHING
+	`
+	tag, err := r.db.Exec(ctx, q, export.ID, export.UserID, string(export.Status), export.RequestedAt, export.NextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("db: create export: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrExportAlreadyPending
+	}
+	return nil
+}
+
+func (r *PostgresRepo) FindPendingExport(ctx context.Context, userID string) (*domain.DataExport, error) {
+	q := `SELECT ` + exportColumns + ` FROM data_exports WHERE user_id = $1 AND status = 'pending'`
+
+	export, err := scanExport(r.db.QueryRow(ctx, q, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db: find pending export: %w", err)
+	}
+	return export, nil
+}
+
+// ClaimPendingExports : la réservation est un simple bail sur next_attempt_at (pas de transaction longue
+// pendant la collecte). Le bail ne compte pas comme une tentative : seul Fail incrémente attempts.
+func (r *PostgresRepo) ClaimPendingExports(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.DataExport, error) {
+	q := `
+		UPDATE data_exports
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM data_exports
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + exportColumns
+
+	rows, err := r.db.Query(ctx, q, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("db: claim exports: %w", err)
+	}
+	return collectExports(rows)
+}
+
+// RenewExportLease : sans effet si l'export n'est plus en préparation
+func (r *PostgresRepo) RenewExportLease(ctx context.Context, exportID string, leaseUntil time.Time) error {
+	q := `UPDATE data_exports SET next_attempt_at = $2 WHERE id = $1 AND status = 'pending'`
+	if _, err := r.db.Exec(ctx, q, exportID, leaseUntil); err != nil {
+		return fmt.Errorf("db: renew export lease: %w", err)
+	}
+	return nil
+}
+
+// ClaimUnnotifiedExports : même bail que ClaimPendingExports, sur les annonces non accusées.
+// Un lien déjà expiré n'est plus annoncé (l'archive part au nettoyage).
+func (r *PostgresRepo) ClaimUnnotifiedExports(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.DataExport, error) {
+	q := `
+		UPDATE data_exports
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM data_exports
+			WHERE status = 'ready' AND notified_at IS NULL AND next_attempt_at <= $1 AND link_expires_at > $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + exportColumns
+
+	rows, err := r.db.Query(ctx, q, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("db: claim unnotified exports: %w", err)
+	}
+	return collectExports(rows)
+}
+
+// ListExpiredExports : la suppression étant idempotente, deux workers peuvent traiter la même ligne sans dommage
+func (r *PostgresRepo) ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]*domain.DataExport, error) {
+	q := `
+		SELECT ` + exportColumns + `
+		FROM data_exports
+		WHERE status = 'ready' AND link_expires_at <= $1
+		ORDER BY link_expires_at
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, q, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("db: list expired exports: %w", err)
+	}
+	return collectExports(rows)
+}
+
+func (r *PostgresRepo) SaveExport(ctx context.Context, export *domain.DataExport) error {
+	q := `
+		UPDATE data_exports
+		SET status = $2, completed_at = $3, object_key = $4, link_expires_at = $5,
+			attempts = $6, next_attempt_at = $7, last_error = $8, notified_at = $9
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, q,
+		export.ID, string(export.Status), nullableTime(export.CompletedAt), export.ObjectKey, nullableTime(export.LinkExpiresAt),
+		export.Attempts, export.NextAttemptAt, export.LastError, nullableTime(export.NotifiedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("db: save export: %w", err)
+	}
+	return nil
+}
+
+// scanExport accepte une pgx.Row comme une ligne de pgx.Rows
+func scanExport(row pgx.Row) (*domain.DataExport, error) {
+	var e domain.DataExport
+	var status string
+	var completedAt, linkExpiresAt, notifiedAt *time.Time
+	err := row.Scan(&e.ID, &e.UserID, &status, &e.RequestedAt, &completedAt, &e.ObjectKey, &linkExpiresAt,
+		&e.Attempts, &e.NextAttemptAt, &e.LastError, &notifiedAt)
+	if err != nil {
+		return nil, err
+	}
+	e.Status = domain.ExportStatus(status)
+	if completedAt != nil {
+		e.CompletedAt = *completedAt
+	}
+	if linkExpiresAt != nil {
+		e.LinkExpiresAt = *linkExpiresAt
+	}
+	if notifiedAt != nil {
+		e.NotifiedAt = *notifiedAt
+	}
+	return &e, nil
+}
+
+func collectExports(rows pgx.Rows) ([]*domain.DataExport, error) {
+	defer rows.Close()
+
+	var exports []*domain.DataExport
+	for rows.Next() {
+		export, err := scanExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("db: scan export: %w", err)
+		}
+		exports = append(exports, export)
+	}
+	return exports, rows.Err()
+}
+
+// nullableTime : le temps zéro du domaine devient NULL en base
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
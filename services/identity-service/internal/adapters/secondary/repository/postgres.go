@@ -21,10 +21,19 @@ type sqlUser struct {
 	PasswordHash string    `db:"password_hash"`
 	FullName     string    `db:"full_name"`
 	IsActive     bool      `db:"is_active"`
+	Role         string    `db:"role"`
 	CreatedAt    time.Time `db:"created_at"`
 	UpdatedAt    time.Time `db:"updated_at"`
+
+	SuspendedUntil   *time.Time `db:"suspended_until"`
+	SuspensionReason string     `db:"suspension_reason"`
+
+	ShowSensitiveMedia bool `db:"show_sensitive_media"`
 }
 
+// Colonnes lues pour hydrater un sqlUser (l'ordre doit suivre les Scan)
+const userColumns = `id, email, username, password_hash, full_name, is_active, role, suspended_until, suspension_reason, show_sensitive_media, created_at, updated_at`
+
 type PostgresRepo struct {
 	db *pgxpool.Pool
 }
@@ -38,8 +47,8 @@ func NewPostgresRepo(pool *pgxpool.Pool) *PostgresRepo {
 // Save insère un utilisateur.
 func (r *PostgresRepo) Save(ctx context.Context, user *domain.User) error {
 	q := `
-		INSERT INTO users (id, email, username, password_hash, full_name, is_active, created_at, updated_at)
-		VALUES (@id, @email, @username, @password_hash, @full_name, @is_active, @created_at, @updated_at)
+		INSERT INTO users (id, email, username, password_hash, full_name, is_active, role, created_at, updated_at)
+		VALUES (@id, @email, @username, @password_hash, @full_name, @is_active, @role, @created_at, @updated_at)
 	`
 
 	// Utilisation de pgx.NamedArgs pour la clarté
@@ -50,6 +59,7 @@ func (r *PostgresRepo) Save(ctx context.Context, user *domain.User) error {
 		"password_hash": user.PasswordHash,
 		"full_name":     user.FullName,
 		"is_active":     user.IsActive,
+		"role":          string(user.Role),
 		"created_at":    user.CreatedAt,
 		"updated_at":    user.UpdatedAt,
 	}
@@ -64,7 +74,7 @@ func (r *PostgresRepo) Save(ctx context.Context, user *domain.User) error {
 
 // GetByEmail récupère un utilisateur.
 func (r *PostgresRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	q := `SELECT id, email, username, password_hash, full_name, is_active, created_at, updated_at FROM users WHERE email = $1`
+	q := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
 
 	var u sqlUser
 	// CollectOneRow est un helper de pgx v5 pour mapper struct automatiquement
@@ -73,7 +83,7 @@ func (r *PostgresRepo) GetByEmail(ctx context.Context, email string) (*domain.Us
 	// Alternative manuelle "Pure pgx" (sans scany) pour plus de contrôle :
 
 	row := r.db.QueryRow(ctx, q, email)
-	err := row.Scan(&u.ID, &u.Email, &u.Username, &u.PasswordHash, &u.FullName, &u.IsActive, &u.CreatedAt, &u.UpdatedAt)
+	err := row.Scan(&u.ID, &u.Email, &u.Username, &u.PasswordHash, &u.FullName, &u.IsActive, &u.Role, &u.SuspendedUntil, &u.SuspensionReason, &u.ShowSensitiveMedia, &u.CreatedAt, &u.UpdatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -86,12 +96,12 @@ func (r *PostgresRepo) GetByEmail(ctx context.Context, email string) (*domain.Us
 }
 
 func (r *PostgresRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
-	q := `SELECT id, email, username, password_hash, full_name, is_active, created_at, updated_at FROM users WHERE id = $1`
+	q := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
 
 	var u sqlUser
 	// Scan manuel pour l'exemple
 	err := r.db.QueryRow(ctx, q, id).Scan(
-		&u.ID, &u.Email, &u.Username, &u.PasswordHash, &u.FullName, &u.IsActive, &u.CreatedAt, &u.UpdatedAt,
+		&u.ID, &u.Email, &u.Username, &u.PasswordHash, &u.FullName, &u.IsActive, &u.Role, &u.SuspendedUntil, &u.SuspensionReason, &u.ShowSensitiveMedia, &u.CreatedAt, &u.UpdatedAt,
 	)
 
 	if err != nil {
@@ -104,10 +114,38 @@ func (r *PostgresRepo) GetByID(ctx context.Context, id string) (*domain.User, er
 	return r.toDomain(&u), nil
 }
 
+// GetByUsernames : les usernames doivent déjà être en minuscules (index sur LOWER(username)).
+// Le username n'étant pas unique en base, on garde le compte le plus ancien en cas de doublon.
+func (r *PostgresRepo) GetByUsernames(ctx context.Context, usernames []string) ([]*domain.User, error) {
+	q := `
+		SELECT DISTINCT ON (LOWER(username)) ` + userColumns + `
+		FROM users
+		WHERE LOWER(username) = ANY($1) AND is_active
+		ORDER BY LOWER(username), created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, q, usernames)
+	if err != nil {
+		return nil, fmt.Errorf("db: get by usernames: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var u sqlUser
+		if err := rows.Scan(&u.ID, &u.Email, &u.Username, &u.PasswordHash, &u.FullName, &u.IsActive, &u.Role, &u.SuspendedUntil, &u.SuspensionReason, &u.ShowSensitiveMedia, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("db: scan user: %w", err)
+		}
+		users = append(users, r.toDomain(&u))
+	}
+	return users, rows.Err()
+}
+
 func (r *PostgresRepo) Update(ctx context.Context, user *domain.User) error {
 	q := `
 		UPDATE users 
-		SET email = @email, full_name = @full_name, password_hash = @password_hash, updated_at = @updated_at 
+		SET email = @email, full_name = @full_name, password_hash = @password_hash, updated_at = @updated_at,
+			show_sensitive_media = @show_sensitive_media
 		WHERE id = @id
 	`
 	args := pgx.NamedArgs{
@@ -116,6 +154,8 @@ func (r *PostgresRepo) Update(ctx context.Context, user *domain.User) error {
 		"full_name":     user.FullName,
 		"password_hash": user.PasswordHash,
 		"updated_at":    user.UpdatedAt,
+
+		"show_sensitive_media": user.ShowSensitiveMedia,
 	}
 
 	tag, err := r.db.Exec(ctx, q, args)
@@ -130,20 +170,50 @@ func (r *PostgresRepo) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// SaveSuspension : UPDATE ciblé, le reste de la ligne n'est pas relu ni réécrit
+func (r *PostgresRepo) SaveSuspension(ctx context.Context, user *domain.User) error {
+	q := `
+		UPDATE users
+		SET suspended_until = $2, suspension_reason = $3, updated_at = $4
+		WHERE id = $1
+	`
+	var suspendedUntil *time.Time
+	if !user.SuspendedUntil.IsZero() {
+		suspendedUntil = &user.SuspendedUntil
+	}
+
+	tag, err := r.db.Exec(ctx, q, user.ID, suspendedUntil, user.SuspensionReason, user.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("db: save suspension: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
 // --- HELPERS ---
 
 // toDomain convertit le DTO SQL en entité Domaine
 func (r *PostgresRepo) toDomain(u *sqlUser) *domain.User {
-	return &domain.User{
-		ID:           u.ID,
-		Email:        u.Email,
-		Username:     u.Username,
-		PasswordHash: u.PasswordHash,
-		FullName:     u.FullName,
-		IsActive:     u.IsActive,
-		CreatedAt:    u.CreatedAt,
-		UpdatedAt:    u.UpdatedAt,
+	user := &domain.User{
+		ID:               u.ID,
+		Email:            u.Email,
+		Username:         u.Username,
+		PasswordHash:     u.PasswordHash,
+		FullName:         u.FullName,
+		IsActive:         u.IsActive,
+		Role:             domain.Role(u.Role),
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
+		SuspensionReason: u.SuspensionReason,
+
+		ShowSensitiveMedia: u.ShowSensitiveMedia,
+	}
+	if u.SuspendedUntil != nil {
+		user.SuspendedUntil = *u.SuspendedUntil
 	}
+	return user
 }
 
 // handleError traduit les codes d'erreur PostgreSQL en erreurs du Domaine
@@ -0,0 +1,89 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+)
+
+// challengePayload est la partie signée du jeton (format compact, proche d'un JWT sans header)
+type challengePayload struct {
+	ID         string `json:"jti"`
+	IP         string `json:"ip"`
+	Difficulty int    `json:"d"`
+	ExpiresAt  int64  `json:"exp"`
+}
+
+// HashcashSigner signe les défis d'inscription avec HMAC-SHA256.
+// Format du jeton : base64url(payload) + "." + base64url(hmac)
+type HashcashSigner struct {
+	secret []byte
+}
+
+func NewHashcashSigner(secret []byte) (*HashcashSigner, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("hashcash secret must be at least 32 bytes")
+	}
+	return &HashcashSigner{secret: secret}, nil
+}
+
+// Sign encode et signe le défi, puis renseigne challenge.Token
+func (h *HashcashSigner) Sign(challenge *domain.RegistrationChallenge) (string, error) {
+	payload, err := json.Marshal(challengePayload{
+		ID:         challenge.ID,
+		IP:         challenge.IP,
+		Difficulty: challenge.Difficulty,
+		ExpiresAt:  challenge.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	token := encoded + "." + base64.RawURLEncoding.EncodeToString(h.mac(encoded))
+
+	challenge.Token = token
+	return token, nil
+}
+
+// Verify contrôle la signature (temps constant) et décode le défi
+func (h *HashcashSigner) Verify(token string) (*domain.RegistrationChallenge, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, domain.ErrChallengeInvalid
+	}
+
+	gotMac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(gotMac, h.mac(encoded)) {
+		return nil, domain.ErrChallengeInvalid
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, domain.ErrChallengeInvalid
+	}
+	var p challengePayload
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, domain.ErrChallengeInvalid
+	}
+
+	return &domain.RegistrationChallenge{
+		ID:         p.ID,
+		IP:         p.IP,
+		Difficulty: p.Difficulty,
+		ExpiresAt:  time.Unix(p.ExpiresAt, 0).UTC(),
+		Token:      token,
+	}, nil
+}
+
+func (h *HashcashSigner) mac(data string) []byte {
+	m := hmac.New(sha256.New, h.secret)
+	m.Write([]byte(data))
+	return m.Sum(nil)
+}
@@ -0,0 +1,87 @@
+package security
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestSigner(t *testing.T, secret []byte) *HashcashSigner {
+	t.Helper()
+	h, err := NewHashcashSigner(secret)
+	if err != nil {
+		t.Fatalf("NewHashcashSigner: %v", err)
+	}
+	return h
+}
+
+func TestHashcashSignerRoundTrip(t *testing.T) {
+	h := newTestSigner(t, testSecret)
+	expiresAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	c := &domain.RegistrationChallenge{ID: "c1", IP: "203.0.113.7", Difficulty: 18, ExpiresAt: expiresAt}
+
+	token, err := h.Sign(c)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if c.Token != token {
+		t.Errorf("Token = %q, want %q", c.Token, token)
+	}
+
+	// Un défi expiré se décode : l'expiration est contrôlée par le service, comme l'IP
+	got, err := h.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got.ID != c.ID || got.IP != c.IP || got.Difficulty != c.Difficulty || !got.ExpiresAt.Equal(expiresAt) || got.Token != token {
+		t.Errorf("Verify = %+v, want %+v", got, c)
+	}
+}
+
+func TestHashcashSignerRejects(t *testing.T) {
+	h := newTestSigner(t, testSecret)
+	token, err := h.Sign(&domain.RegistrationChallenge{ID: "c1", IP: "203.0.113.7", Difficulty: 18, ExpiresAt: time.Now().Add(time.Minute)})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+
+	// Même signature, payload réécrit : difficulté abaissée et IP changée
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"jti":"c1","ip":"198.51.100.1","d":1,"exp":4102444800}`))
+	otherSigner := newTestSigner(t, []byte("another-secret-another-secret-!!"))
+	otherToken, err := otherSigner.Sign(&domain.RegistrationChallenge{ID: "c1", IP: "203.0.113.7", Difficulty: 18, ExpiresAt: time.Now().Add(time.Minute)})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"payload falsifié", forged + "." + sig},
+		{"signature falsifiée", payload + "." + base64.RawURLEncoding.EncodeToString([]byte("forged"))},
+		{"signature d'un autre secret", otherToken},
+		{"signature absente", payload},
+		{"signature mal encodée", payload + ".!!"},
+		{"jeton vide", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := h.Verify(tt.token); !errors.Is(err, domain.ErrChallengeInvalid) {
+				t.Errorf("err = %v, want %v", err, domain.ErrChallengeInvalid)
+			}
+		})
+	}
+}
+
+func TestNewHashcashSignerShortSecret(t *testing.T) {
+	if _, err := NewHashcashSigner([]byte("too-short")); err == nil {
+		t.Error("secret de moins de 32 octets accepté")
+	}
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// downloadPrefix : chemin HTTP sous lequel les objets sont servis
+const downloadPrefix = "/downloads/"
+
+// LocalStorage : stockage objet sur le système de fichiers (dev, mono-nœud ou volume partagé).
+// Les liens signés (HMAC-SHA256 de la clé et de l'expiration) sont vérifiés par le Handler
+// qui sert les fichiers : aucune authentification n'est nécessaire pour télécharger.
+type LocalStorage struct {
+	root    string
+	baseURL string // URL publique du Handler (ex: https://cenackle.example/exports)
+	secret  []byte
+}
+
+func NewLocalStorage(root, baseURL string, secret []byte) (*LocalStorage, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("storage: signing secret must be at least 32 bytes")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("storage: create root: %w", err)
+	}
+	return &LocalStorage{root: root, baseURL: strings.TrimRight(baseURL, "/"), secret: secret}, nil
+}
+
+// Put écrit dans un fichier temporaire puis le renomme : un téléchargement ne voit jamais d'archive partielle
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("storage: mkdir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("storage: create temp: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // Sans effet après le Rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("storage: write: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("storage: close: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("storage: rename: %w", err)
+	}
+	return nil
+}
+
+// Delete : un fichier déjà absent n'est pas une erreur (nettoyage rejoué)
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("storage: delete: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) SignedURL(key string, expiresAt time.Time) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", s.sign(key, expires))
+	return s.baseURL + downloadPrefix + key + "?" + q.Encode(), nil
+}
+
+// Handler sert les objets dont le lien est valide (signature correcte et non expiré)
+func (s *LocalStorage) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		key, ok := strings.CutPrefix(r.URL.Path, downloadPrefix)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		expires := r.URL.Query().Get("expires")
+		signature := r.URL.Query().Get("signature")
+		if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+			http.Error(w, "invalid signature", http.StatusForbidden)
+			return
+		}
+		unix, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || time.Now().After(time.Unix(unix, 0)) {
+			http.Error(w, "link expired", http.StatusGone)
+			return
+		}
+
+		target, err := s.path(key)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(key)))
+		w.Header().Set("Cache-Control", "private, no-store")
+		http.ServeFile(w, r, target)
+	})
+}
+
+func (s *LocalStorage) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path résout la clé sous la racine (une clé ne peut pas en sortir via "..")
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
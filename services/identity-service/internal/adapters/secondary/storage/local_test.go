@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestStorage(t *testing.T) *LocalStorage {
+	t.Helper()
+	s, err := NewLocalStorage(t.TempDir(), "https://cenackle.test", testSecret)
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	return s
+}
+
+// download rejoue un lien signé contre le Handler
+func download(t *testing.T, s *LocalStorage, signed string) *httptest.ResponseRecorder {
+	t.Helper()
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("url.Parse(%q): %v", signed, err)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, u.RequestURI(), nil))
+	return rec
+}
+
+func TestNewLocalStorageRejectsShortSecret(t *testing.T) {
+	if _, err := NewLocalStorage(t.TempDir(), "", []byte("court")); err == nil {
+		t.Fatal("secret de moins de 32 octets accepté")
+	}
+}
+
+func TestLocalStorageSignedDownload(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t)
+	key := "exports/user-1/export-1.zip"
+	if err := s.Put(ctx, key, []byte("archive"), "application/zip"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	signed, err := s.SignedURL(key, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	rec := download(t, s, signed)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec.Body.String() != "archive" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "archive")
+	}
+}
+
+func TestLocalStorageHandlerRejects(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t)
+	key := "exports/user-1/export-1.zip"
+	if err := s.Put(ctx, key, []byte("archive"), "application/zip"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	valid, err := s.SignedURL(key, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	expired, err := s.SignedURL(key, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	other, err := NewLocalStorage(t.TempDir(), "https://cenackle.test", []byte("fedcba9876543210fedcba9876543210"))
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	foreign, err := other.SignedURL(key, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		signed string
+		want   int
+	}{
+		{"lien expiré", expired, http.StatusGone},
+		{"signature d'un autre secret", foreign, http.StatusForbidden},
+		{"expiration prolongée", strings.Replace(valid, "expires=", "expires=9", 1), http.StatusForbidden},
+		{"autre clé", strings.Replace(valid, "export-1.zip", "export-2.zip", 1), http.StatusForbidden},
+		{"sans signature", strings.Split(valid, "?")[0], http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := download(t, s, tt.signed); rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalStorageRejectsPathTraversal(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t)
+
+	for _, key := range []string{"", "../secret", "exports/../../secret", "/etc/passwd", "exports//a.zip", "exports/./a.zip"} {
+		if err := s.Put(ctx, key, []byte("x"), "text/plain"); err == nil {
+			t.Errorf("Put(%q) accepté", key)
+		}
+		if _, err := s.SignedURL(key, time.Now().Add(time.Hour)); err == nil {
+			t.Errorf("SignedURL(%q) accepté", key)
+		}
+		if err := s.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) accepté", key)
+		}
+	}
+}
+
+func TestLocalStorageDelete(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t)
+	key := "exports/user-1/export-1.zip"
+	if err := s.Put(ctx, key, []byte("archive"), "application/zip"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(s.root, "exports", "user-1", "export-1.zip")); !os.IsNotExist(err) {
+		t.Errorf("fichier encore présent : %v", err)
+	}
+	// Nettoyage rejoué : l'objet absent n'est pas une erreur
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("second Delete = %v, want nil", err)
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/bits"
+	"time"
+)
+
+// --- ERREURS DU DOMAINE (Anti-bot) ---
+var (
+	ErrChallengeRequired = errors.New("registration challenge is required")
+	ErrChallengeInvalid  = errors.New("invalid registration challenge")
+	ErrChallengeExpired  = errors.New("registration challenge expired")
+	ErrChallengeUnsolved = errors.New("registration challenge not solved")
+	ErrChallengeReplayed = errors.New("registration challenge already used")
+)
+
+// RegistrationChallenge est un défi "proof-of-work" façon hashcash.
+// Le client doit trouver un nonce tel que SHA-256(Token + ":" + nonce)
+// commence par au moins Difficulty bits à zéro.
+type RegistrationChallenge struct {
+	ID         string
+	IP         string
+	Difficulty int
+	ExpiresAt  time.Time
+
+	// Token est la représentation signée du défi (renseignée par le ChallengeSigner).
+	// C'est elle qui est hachée avec le nonce : le client ne peut donc pas changer la difficulté.
+	Token string
+}
+
+// IsExpired indique si le défi n'est plus utilisable
+func (c *RegistrationChallenge) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// IsSolvedBy vérifie la preuve de travail
+func (c *RegistrationChallenge) IsSolvedBy(nonce string) bool {
+	if nonce == "" || c.Token == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(c.Token + ":" + nonce))
+	return leadingZeroBits(sum[:]) >= c.Difficulty
+}
+
+// ChallengeDifficulty calcule la difficulté en fonction des inscriptions récentes d'une IP.
+// Chaque doublement du nombre d'inscriptions ajoute un bit (donc double le coût moyen côté client).
+func ChallengeDifficulty(recentSignups, base, max int) int {
+	if recentSignups < 0 {
+		recentSignups = 0
+	}
+	difficulty := base + bits.Len(uint(recentSignups))
+	if difficulty > max {
+		return max
+	}
+	return difficulty
+}
+
+// --- HELPERS INTERNES ---
+
+func leadingZeroBits(sum []byte) int {
+	n := 0
+	for _, b := range sum {
+		if b == 0 {
+			n += 8
+			continue
+		}
+		return n + bits.LeadingZeros8(b)
+	}
+	return n
+}
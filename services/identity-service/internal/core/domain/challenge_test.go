@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestChallengeDifficulty(t *testing.T) {
+	tests := []struct {
+		name   string
+		recent int
+		want   int
+	}{
+		{"aucune inscription récente", 0, 16},
+		{"compteur négatif ramené à zéro", -3, 16},
+		{"une inscription : un bit", 1, 17},
+		{"deux ou trois : deux bits", 3, 18},
+		{"chaque doublement ajoute un bit", 4, 19},
+		{"plafond atteint", 1 << 10, 22},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChallengeDifficulty(tt.recent, 16, 22); got != tt.want {
+				t.Errorf("ChallengeDifficulty(%d) = %d, want %d", tt.recent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+	tests := []struct {
+		name string
+		sum  []byte
+		want int
+	}{
+		{"premier bit à un", []byte{0x80, 0x00}, 0},
+		{"quatre bits à zéro", []byte{0x0f, 0xff}, 4},
+		{"octet nul puis un bit à zéro", []byte{0x00, 0x40}, 9},
+		{"tout à zéro", []byte{0x00, 0x00}, 16},
+		{"vide", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := leadingZeroBits(tt.sum); got != tt.want {
+				t.Errorf("leadingZeroBits(%x) = %d, want %d", tt.sum, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSolvedBy(t *testing.T) {
+	c := &RegistrationChallenge{Token: "payload.signature", Difficulty: 8}
+
+	// Résolution comme le ferait le client (8 bits : ~256 essais)
+	nonce, zeros := "", 0
+	for i := 0; ; i++ {
+		nonce = strconv.Itoa(i)
+		sum := sha256.Sum256([]byte(c.Token + ":" + nonce))
+		if zeros = leadingZeroBits(sum[:]); zeros >= c.Difficulty {
+			break
+		}
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		difficulty int
+		nonce      string
+		want       bool
+	}{
+		{"nonce valide", c.Token, c.Difficulty, nonce, true},
+		{"difficulté supérieure au travail fourni", c.Token, zeros + 1, nonce, false},
+		{"nonce d'un autre jeton", "other.token", 24, nonce, false},
+		{"nonce vide", c.Token, 0, "", false},
+		{"jeton vide", "", 0, nonce, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &RegistrationChallenge{Token: tt.token, Difficulty: tt.difficulty}
+			if got := c.IsSolvedBy(tt.nonce); got != tt.want {
+				t.Errorf("IsSolvedBy(%q) = %v, want %v", tt.nonce, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChallengeIsExpired(t *testing.T) {
+	expiresAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	c := &RegistrationChallenge{ExpiresAt: expiresAt}
+
+	if c.IsExpired(expiresAt.Add(-time.Second)) {
+		t.Error("expiré avant ExpiresAt")
+	}
+	if !c.IsExpired(expiresAt) {
+		t.Error("encore valide à ExpiresAt")
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrExportAlreadyPending : une demande concurrente a déjà créé l'export en préparation
+var ErrExportAlreadyPending = errors.New("a data export is already pending")
+
+// Règles de l'export des données personnelles
+const (
+	// ExportClaimLease : un export pris par un worker qui meurt redevient disponible après ce délai
+	// (le worker renouvelle le bail tant que la préparation dure)
+	ExportClaimLease = 10 * time.Minute
+	// ExportNotifyRetryDelay : attente avant de republier data_export_ready si l'événement n'a pas été accusé
+	ExportNotifyRetryDelay = time.Minute
+	// ExportMaxAttempts : au-delà, l'export passe en échec (l'utilisateur peut en redemander un)
+	ExportMaxAttempts   = 5
+	exportMaxRetryDelay = time.Hour
+)
+
+// ExportStatus : pending -> ready | failed, puis ready -> expired (archive supprimée après LinkExpiresAt)
+type ExportStatus string
+
+const (
+	ExportPending ExportStatus = "pending"
+	ExportReady   ExportStatus = "ready"
+	ExportFailed  ExportStatus = "failed"
+	ExportExpired ExportStatus = "expired"
+)
+
+// DataExport : une demande d'export (portabilité RGPD), préparée en asynchrone
+type DataExport struct {
+	ID          string
+	UserID      string
+	Status      ExportStatus
+	RequestedAt time.Time
+	CompletedAt time.Time // Zéro tant que l'export n'est pas prêt (ou en échec)
+
+	// Archive déposée dans le stockage objet, téléchargeable par lien signé jusqu'à LinkExpiresAt
+	ObjectKey     string
+	LinkExpiresAt time.Time
+	// NotifiedAt : data_export_ready accusé par le broker (zéro = à republier, cf. ExportWorker)
+	NotifiedAt time.Time
+
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+func NewDataExport(userID string, now time.Time) *DataExport {
+	return &DataExport{
+		ID:            uuid.NewString(),
+		UserID:        userID,
+		Status:        ExportPending,
+		RequestedAt:   now,
+		NextAttemptAt: now,
+	}
+}
+
+// ArchiveKey : emplacement de l'archive dans le stockage objet (l'ID, aléatoire, n'est pas devinable)
+func (e *DataExport) ArchiveKey() string {
+	return "exports/" + e.UserID + "/" + e.ID + ".zip"
+}
+
+// Complete : archive déposée, lien valable 'linkTTL'. L'annonce est due tout de suite ; si elle
+// n'est pas accusée, elle sera republiée après ExportNotifyRetryDelay.
+func (e *DataExport) Complete(key string, now time.Time, linkTTL time.Duration) {
+	e.Status = ExportReady
+	e.ObjectKey = key
+	e.CompletedAt = now
+	e.LinkExpiresAt = now.Add(linkTTL)
+	e.NextAttemptAt = now.Add(ExportNotifyRetryDelay)
+	e.LastError = ""
+}
+
+// MarkNotified : l'événement data_export_ready a été accusé
+func (e *DataExport) MarkNotified(now time.Time) {
+	e.NotifiedAt = now
+}
+
+// Expire : le lien a expiré, l'archive a été supprimée du stockage
+func (e *DataExport) Expire() {
+	e.Status = ExportExpired
+	e.ObjectKey = ""
+}
+
+// Fail : nouvel essai plus tard (backoff exponentiel), ou échec définitif après ExportMaxAttempts
+func (e *DataExport) Fail(cause error, now time.Time) {
+	e.Attempts++
+	e.LastError = cause.Error()
+	if e.Attempts >= ExportMaxAttempts {
+		e.Status = ExportFailed
+		e.CompletedAt = now
+		return
+	}
+	delay := min(time.Minute<<(e.Attempts-1), exportMaxRetryDelay)
+	e.NextAttemptAt = now.Add(delay)
+}
+
+// ExportBundle : tout ce qui est archivé pour un utilisateur, rassemblé par l'ExportWorker
+type ExportBundle struct {
+	Export         *DataExport
+	User           *User
+	SecurityEvents []*SecurityEvent
+	Posts          []*ExportedPost // Post Service
+	Followers      []*Connection   // Graph Service
+	Following      []*Connection
+	GeneratedAt    time.Time
+}
+
+// ExportedPost : un post tel que restitué par le Post Service (tous statuts, supprimés non purgés compris)
+type ExportedPost struct {
+	ID             string
+	Content        string
+	ContentWarning string
+	Media          []ExportedMedia
+	Status         string
+	Visibility     string
+	Language       string
+	RepostedPostID string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	EditedAt       time.Time // Zéro si jamais édité
+	DeletedAt      time.Time // Zéro si vivant
+}
+
+// ExportedMedia : référence vers le fichier (le fichier lui-même reste dans le stockage média)
+type ExportedMedia struct {
+	ID        string
+	URL       string
+	Type      string
+	Sensitive bool
+}
+
+// Connection : l'autre extrémité d'un abonnement, et sa date
+type Connection struct {
+	UserID string
+	Since  time.Time
+}
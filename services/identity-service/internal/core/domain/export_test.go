@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDataExportFail(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	cause := errors.New("graph unavailable")
+
+	e := NewDataExport("user-1", now)
+	wantDelays := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute}
+	for i, want := range wantDelays {
+		e.Fail(cause, now)
+		if e.Status != ExportPending {
+			t.Fatalf("tentative %d : Status = %v, want %v", i+1, e.Status, ExportPending)
+		}
+		if got := e.NextAttemptAt.Sub(now); got != want {
+			t.Errorf("tentative %d : délai = %v, want %v", i+1, got, want)
+		}
+	}
+
+	e.Fail(cause, now)
+	if e.Status != ExportFailed {
+		t.Fatalf("après %d tentatives : Status = %v, want %v", ExportMaxAttempts, e.Status, ExportFailed)
+	}
+	if e.Attempts != ExportMaxAttempts {
+		t.Errorf("Attempts = %d, want %d", e.Attempts, ExportMaxAttempts)
+	}
+	if !e.CompletedAt.Equal(now) {
+		t.Errorf("CompletedAt = %v, want %v", e.CompletedAt, now)
+	}
+	if e.LastError != cause.Error() {
+		t.Errorf("LastError = %q, want %q", e.LastError, cause.Error())
+	}
+}
+
+func TestDataExportLifecycle(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	e := NewDataExport("user-1", now)
+	e.Fail(errors.New("boom"), now)
+	e.Complete(e.ArchiveKey(), now, 24*time.Hour)
+
+	if e.Status != ExportReady || e.LastError != "" {
+		t.Fatalf("Complete : Status = %v, LastError = %q", e.Status, e.LastError)
+	}
+	if !e.LinkExpiresAt.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("LinkExpiresAt = %v, want %v", e.LinkExpiresAt, now.Add(24*time.Hour))
+	}
+	if !e.NextAttemptAt.Equal(now.Add(ExportNotifyRetryDelay)) {
+		t.Errorf("NextAttemptAt = %v, want %v (annonce rejouable)", e.NextAttemptAt, now.Add(ExportNotifyRetryDelay))
+	}
+	if !e.NotifiedAt.IsZero() {
+		t.Error("NotifiedAt renseigné avant la publication")
+	}
+
+	e.MarkNotified(now)
+	if !e.NotifiedAt.Equal(now) {
+		t.Errorf("NotifiedAt = %v, want %v", e.NotifiedAt, now)
+	}
+
+	e.Expire()
+	if e.Status != ExportExpired || e.ObjectKey != "" {
+		t.Errorf("Expire : Status = %v, ObjectKey = %q", e.Status, e.ObjectKey)
+	}
+}
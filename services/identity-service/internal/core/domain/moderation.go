@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAccountSuspended  = errors.New("account is suspended")
+	ErrInvalidSuspension = errors.New("suspension must end in the future")
+)
+
+// Role : droits de l'utilisateur (les modérateurs traitent la file de signalements)
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// PermanentSuspension : "suspendu jusqu'à nouvel ordre" (une date plutôt qu'un booléen de plus)
+var PermanentSuspension = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// CanModerate : modérateurs et administrateurs
+func (u *User) CanModerate() bool {
+	return u.Role == RoleModerator || u.Role == RoleAdmin
+}
+
+// IsSuspendedAt : la suspension se lève d'elle-même à son échéance
+func (u *User) IsSuspendedAt(now time.Time) bool {
+	return now.Before(u.SuspendedUntil)
+}
+
+// Suspend : until zéro = suspension permanente
+func (u *User) Suspend(until time.Time, reason string, now time.Time) error {
+	if until.IsZero() {
+		until = PermanentSuspension
+	}
+	if !until.After(now) {
+		return ErrInvalidSuspension
+	}
+	u.SuspendedUntil = until.UTC()
+	u.SuspensionReason = strings.TrimSpace(reason)
+	u.touch()
+	return nil
+}
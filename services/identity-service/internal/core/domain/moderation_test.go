@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUserSuspend(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		until   time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{"temporaire", now.Add(24 * time.Hour), now.Add(24 * time.Hour), nil},
+		{"zéro = définitive", time.Time{}, PermanentSuspension, nil},
+		{"échéance passée", now.Add(-time.Minute), time.Time{}, ErrInvalidSuspension},
+		{"échéance immédiate", now, time.Time{}, ErrInvalidSuspension},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &User{}
+			err := u.Suspend(tt.until, "  spam  ", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Suspend error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !u.SuspendedUntil.IsZero() {
+					t.Errorf("SuspendedUntil modifié malgré l'erreur : %v", u.SuspendedUntil)
+				}
+				return
+			}
+			if !u.SuspendedUntil.Equal(tt.want) {
+				t.Errorf("SuspendedUntil = %v, want %v", u.SuspendedUntil, tt.want)
+			}
+			if u.SuspensionReason != "spam" {
+				t.Errorf("SuspensionReason = %q, want %q", u.SuspensionReason, "spam")
+			}
+			if !u.IsSuspendedAt(now) {
+				t.Error("IsSuspendedAt(now) = false après Suspend")
+			}
+		})
+	}
+}
+
+func TestUserIsSuspendedAt(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	if (&User{}).IsSuspendedAt(now) {
+		t.Error("un compte sans sanction ne doit pas être suspendu")
+	}
+	u := &User{SuspendedUntil: now.Add(time.Hour)}
+	if !u.IsSuspendedAt(now) {
+		t.Error("suspension en cours non détectée")
+	}
+	if u.IsSuspendedAt(now.Add(time.Hour)) {
+		t.Error("la suspension doit se lever à son échéance")
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventKind : événements du journal de sécurité d'un compte
+type SecurityEventKind string
+
+const (
+	SecurityLoginSucceeded      SecurityEventKind = "login_succeeded"
+	SecurityLoginFailed         SecurityEventKind = "login_failed" // Mauvais mot de passe (email connu)
+	SecurityPasswordChanged     SecurityEventKind = "password_changed"
+	SecurityEmailChanged        SecurityEventKind = "email_changed"
+	SecurityAccountSuspended    SecurityEventKind = "account_suspended"
+	SecurityDataExportRequested SecurityEventKind = "data_export_requested"
+)
+
+// SecurityEvent : entrée du journal (jamais modifiée, restituée dans l'export des données)
+type SecurityEvent struct {
+	ID        string
+	UserID    string
+	Kind      SecurityEventKind
+	IP        string // Vide si l'action ne vient pas d'une requête client (ex: modération)
+	Device    string
+	Detail    string // Complément lisible (ex: motif d'une suspension)
+	CreatedAt time.Time
+}
+
+func NewSecurityEvent(userID string, kind SecurityEventKind, ip, device, detail string) *SecurityEvent {
+	return &SecurityEvent{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Kind:      kind,
+		IP:        ip,
+		Device:    device,
+		Detail:    detail,
+		CreatedAt: time.Now().UTC(),
+	}
+}
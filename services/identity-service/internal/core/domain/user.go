@@ -28,8 +28,16 @@ type User struct {
 	PasswordHash string
 	FullName     string
 	IsActive     bool // Utile pour le "soft delete" ou ban
+	Role         Role
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+
+	// Suspension décidée par la modération (zéro = compte en règle, cf. IsSuspendedAt)
+	SuspendedUntil   time.Time
+	SuspensionReason string
+
+	// ShowSensitiveMedia : afficher les médias marqués sensibles sans les flouter (false par défaut)
+	ShowSensitiveMedia bool
 }
 
 // --- FACTORY (CONSTRUCTEUR) ---
@@ -53,6 +61,7 @@ func NewUser(email, username, passwordHash, fullName string) (*User, error) {
 		PasswordHash: passwordHash,
 		FullName:     strings.TrimSpace(fullName),
 		IsActive:     true,
+		Role:         RoleUser,
 		CreatedAt:    time.Now().UTC(), // Toujours utiliser UTC
 		UpdatedAt:    time.Now().UTC(),
 	}, nil
@@ -66,6 +75,12 @@ func (u *User) UpdatePassword(newHash string) {
 	u.touch()
 }
 
+// SetShowSensitiveMedia change la préférence d'affichage des médias sensibles
+func (u *User) SetShowSensitiveMedia(show bool) {
+	u.ShowSensitiveMedia = show
+	u.touch()
+}
+
 // UpdateProfile permet de changer les infos non-critiques
 func (u *User) UpdateProfile(fullName string) {
 	u.FullName = strings.TrimSpace(fullName)
@@ -15,6 +15,11 @@ type RegisterCmd struct {
 	Password string
 	Username string
 	FullName string
+
+	// Anti-bot : solution du défi obtenu via GetRegistrationChallenge
+	IP             string
+	Challenge      string
+	ChallengeNonce string
 	// Plus tard, on pourra ajouter : IsTermsAccepted bool, ReferalCode string, etc.
 }
 
@@ -29,6 +34,15 @@ type UpdateProfileCmd struct {
 	UserID   string
 	Email    *string // Pointeur pour savoir si on veut update ou pas (nil = pas de changement)
 	FullName *string
+
+	ShowSensitiveMedia *bool // Préférence de lecture (nil = pas de changement)
+}
+
+type SuspendUserCmd struct {
+	UserID      string
+	ModeratorID string
+	Reason      string
+	Until       time.Time // Zéro = suspension définitive
 }
 
 // --- OUTPUTS ---
@@ -46,15 +60,28 @@ type AuthResponse struct {
 
 type IdentityService interface {
 	// Authentification
+	GetRegistrationChallenge(ctx context.Context, ip string) (*domain.RegistrationChallenge, error)
 	Register(ctx context.Context, cmd RegisterCmd) (*AuthResponse, error)
 	Login(ctx context.Context, cmd LoginCmd) (*AuthResponse, error)
 
 	// Token Management
 	RefreshToken(ctx context.Context, refreshToken string) (*AuthResponse, error)
-	ValidateToken(ctx context.Context, token string) (string, error) // Retourne UserID ou Claims
+	// ValidateToken renvoie le titulaire du token (rejeté si le compte est désactivé ou suspendu)
+	ValidateToken(ctx context.Context, token string) (*domain.User, error)
 
 	// User Management
 	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	// ResolveUsernames renvoie username (en minuscules) -> userID ; les inconnus sont absents
+	ResolveUsernames(ctx context.Context, usernames []string) (map[string]string, error)
 	UpdateProfile(ctx context.Context, cmd UpdateProfileCmd) (*domain.User, error)
 	ChangePassword(ctx context.Context, userID, oldPass, newPass string) error
+
+	// Modération
+	SuspendUser(ctx context.Context, cmd SuspendUserCmd) (*domain.User, error)
+}
+
+// DataExportService : export des données personnelles (portabilité RGPD)
+type DataExportService interface {
+	// RequestDataExport enregistre la demande (préparée en asynchrone) ; renvoie celle en cours s'il y en a une
+	RequestDataExport(ctx context.Context, userID string) (*domain.DataExport, error)
 }
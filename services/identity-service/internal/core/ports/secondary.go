@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
 )
@@ -14,7 +15,36 @@ type UserRepository interface {
 	Save(ctx context.Context, user *domain.User) error
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	GetByID(ctx context.Context, id string) (*domain.User, error)
+	// GetByUsernames : recherche en batch, insensible à la casse (utilisateurs actifs uniquement)
+	GetByUsernames(ctx context.Context, usernames []string) ([]*domain.User, error)
+	// Update enregistre le profil et le mot de passe (jamais la suspension, cf. SaveSuspension)
 	Update(ctx context.Context, user *domain.User) error
+	// SaveSuspension n'écrit que la sanction : une mise à jour de profil concurrente ne peut pas l'écraser
+	SaveSuspension(ctx context.Context, user *domain.User) error
+}
+
+// SecurityEventRepository : journal de sécurité des comptes (en ajout seul)
+type SecurityEventRepository interface {
+	RecordSecurityEvent(ctx context.Context, event *domain.SecurityEvent) error
+	// ListSecurityEvents : plus récents d'abord
+	ListSecurityEvents(ctx context.Context, userID string) ([]*domain.SecurityEvent, error)
+}
+
+// DataExportRepository : file des exports de données personnelles
+type DataExportRepository interface {
+	CreateExport(ctx context.Context, export *domain.DataExport) error
+	// FindPendingExport renvoie l'export en préparation de l'utilisateur (nil, nil si aucun)
+	FindPendingExport(ctx context.Context, userID string) (*domain.DataExport, error)
+	// ClaimPendingExports réserve jusqu'à 'limit' exports dus (FOR UPDATE SKIP LOCKED) en repoussant
+	// leur prochaine tentative à 'leaseUntil' : un worker qui meurt ne bloque pas la file
+	ClaimPendingExports(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.DataExport, error)
+	// RenewExportLease prolonge le bail d'un export en préparation (collecte ou archive longue)
+	RenewExportLease(ctx context.Context, exportID string, leaseUntil time.Time) error
+	// ClaimUnnotifiedExports réserve les exports prêts dont l'annonce n'a pas été accusée (lien encore valide)
+	ClaimUnnotifiedExports(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.DataExport, error)
+	// ListExpiredExports : exports prêts dont le lien a expiré (archive à supprimer)
+	ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]*domain.DataExport, error)
+	SaveExport(ctx context.Context, export *domain.DataExport) error
 }
 
 // --- MESSAGERIE (BROKER) ---
@@ -23,6 +53,38 @@ type UserRepository interface {
 // Il permet de notifier les autres microservices (Feed, Notif) qu'un événement a eu lieu.
 type EventPublisher interface {
 	PublishUserRegistered(ctx context.Context, userID, email string) error
+	PublishUserSuspended(ctx context.Context, user *domain.User, moderatorID string) error
+	PublishDataExportReady(ctx context.Context, export *domain.DataExport, downloadURL string) error
+}
+
+// --- AUTRES SERVICES (gRPC, export des données personnelles) ---
+
+// PostArchive : le Post Service restitue tous les posts d'un utilisateur
+type PostArchive interface {
+	ListUserPosts(ctx context.Context, userID string) ([]*domain.ExportedPost, error)
+}
+
+// SocialGraph : le Graph Service restitue abonnés et abonnements
+type SocialGraph interface {
+	ListFollowers(ctx context.Context, userID string) ([]*domain.Connection, error)
+	ListFollowing(ctx context.Context, userID string) ([]*domain.Connection, error)
+}
+
+// --- EXPORT (ARCHIVE & STOCKAGE OBJET) ---
+
+// ArchiveWriter met en forme l'export (ex: ZIP de fichiers JSON + index HTML lisible)
+type ArchiveWriter interface {
+	Build(bundle *domain.ExportBundle) ([]byte, error)
+	ContentType() string
+}
+
+// ObjectStorage : dépôt des archives (S3, système de fichiers local...)
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// SignedURL : lien de téléchargement sans authentification, refusé après expiresAt
+	SignedURL(key string, expiresAt time.Time) (string, error)
+	// Delete est idempotent (un objet absent n'est pas une erreur)
+	Delete(ctx context.Context, key string) error
 }
 
 // --- SÉCURITÉ (CRYPTO) ---
@@ -38,3 +100,22 @@ type TokenProvider interface {
 	GenerateTokens(user *domain.User) (access string, refresh string, err error)
 	Validate(token string) (userID string, err error)
 }
+
+// --- ANTI-BOT (PROOF-OF-WORK) ---
+
+// ChallengeSigner signe les défis d'inscription pour que leur vérification reste stateless.
+type ChallengeSigner interface {
+	// Sign renvoie le jeton signé (il sera aussi stocké dans challenge.Token)
+	Sign(challenge *domain.RegistrationChallenge) (string, error)
+	// Verify contrôle la signature et renvoie le défi décodé (domain.ErrChallengeInvalid sinon)
+	Verify(token string) (*domain.RegistrationChallenge, error)
+}
+
+// ChallengeStore porte le seul état nécessaire : l'anti-rejeu et le débit d'inscriptions par IP.
+type ChallengeStore interface {
+	// ConsumeChallenge marque un défi comme utilisé (domain.ErrChallengeReplayed s'il l'était déjà)
+	ConsumeChallenge(ctx context.Context, challengeID string, expiresAt time.Time) error
+	// RecordSignup trace une inscription et purge celles antérieures à purgeBefore (hors fenêtre de comptage)
+	RecordSignup(ctx context.Context, ip string, at, purgeBefore time.Time) error
+	CountSignupsSince(ctx context.Context, ip string, since time.Time) (int, error)
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/ports"
+)
+
+// fakeSigner : jetons connus (jeton -> défi), tout autre jeton est falsifié
+type fakeSigner struct {
+	ports.ChallengeSigner
+	challenges map[string]domain.RegistrationChallenge
+}
+
+func (s *fakeSigner) Verify(token string) (*domain.RegistrationChallenge, error) {
+	c, ok := s.challenges[token]
+	if !ok {
+		return nil, domain.ErrChallengeInvalid
+	}
+	c.Token = token
+	return &c, nil
+}
+
+func TestVerifyChallenge(t *testing.T) {
+	future := time.Now().Add(time.Minute)
+	signer := &fakeSigner{challenges: map[string]domain.RegistrationChallenge{
+		"valid":    {ID: "c1", IP: "203.0.113.7", Difficulty: 0, ExpiresAt: future},
+		"expired":  {ID: "c2", IP: "203.0.113.7", Difficulty: 0, ExpiresAt: time.Now().Add(-time.Second)},
+		"unsolved": {ID: "c3", IP: "203.0.113.7", Difficulty: 257, ExpiresAt: future}, // Plus de bits qu'un SHA-256
+	}}
+	s := &IdentityService{challengeSigner: signer}
+
+	tests := []struct {
+		name    string
+		cmd     ports.RegisterCmd
+		wantErr error
+	}{
+		{"défi résolu", ports.RegisterCmd{IP: "203.0.113.7", Challenge: "valid", ChallengeNonce: "1"}, nil},
+		{"défi absent", ports.RegisterCmd{IP: "203.0.113.7"}, domain.ErrChallengeRequired},
+		{"jeton falsifié", ports.RegisterCmd{IP: "203.0.113.7", Challenge: "forged", ChallengeNonce: "1"}, domain.ErrChallengeInvalid},
+		{"défi expiré", ports.RegisterCmd{IP: "203.0.113.7", Challenge: "expired", ChallengeNonce: "1"}, domain.ErrChallengeExpired},
+		{"défi émis pour une autre IP", ports.RegisterCmd{IP: "198.51.100.1", Challenge: "valid", ChallengeNonce: "1"}, domain.ErrChallengeInvalid},
+		{"preuve de travail insuffisante", ports.RegisterCmd{IP: "203.0.113.7", Challenge: "unsolved", ChallengeNonce: "1"}, domain.ErrChallengeUnsolved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.verifyChallenge(tt.cmd)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/ports"
+)
+
+// ExportService implémente ports.DataExportService : il n'enregistre que la demande,
+// l'archive est préparée par l'ExportWorker.
+type ExportService struct {
+	users       ports.UserRepository
+	exports     ports.DataExportRepository
+	securityLog ports.SecurityEventRepository
+}
+
+func NewExportService(users ports.UserRepository, exports ports.DataExportRepository, securityLog ports.SecurityEventRepository) ports.DataExportService {
+	return &ExportService{users: users, exports: exports, securityLog: securityLog}
+}
+
+func (s *ExportService) RequestDataExport(ctx context.Context, userID string) (*domain.DataExport, error) {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// Une seule préparation à la fois : redemander ne relance pas le travail
+	pending, err := s.exports.FindPendingExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return pending, nil
+	}
+
+	export := domain.NewDataExport(userID, time.Now().UTC())
+	if err := s.exports.CreateExport(ctx, export); err != nil {
+		if errors.Is(err, domain.ErrExportAlreadyPending) {
+			// Double clic : l'autre demande a gagné, on renvoie la sienne
+			return s.exports.FindPendingExport(ctx, userID)
+		}
+		return nil, fmt.Errorf("create export: %w", err)
+	}
+
+	// Best effort, comme pour les autres entrées du journal
+	_ = s.securityLog.RecordSecurityEvent(ctx, domain.NewSecurityEvent(userID, domain.SecurityDataExportRequested, "", "", ""))
+
+	return export, nil
+}
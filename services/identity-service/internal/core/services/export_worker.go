@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/ports"
+)
+
+// ExportWorker prépare les exports en attente : collecte (Identity, Post et Graph Services),
+// mise en archive, dépôt dans le stockage objet puis publication du lien signé.
+// Il republie les annonces non accusées et supprime les archives dont le lien a expiré.
+// Il tourne sur chaque réplica (FOR UPDATE SKIP LOCKED côté repository).
+type ExportWorker struct {
+	users       ports.UserRepository
+	exports     ports.DataExportRepository
+	securityLog ports.SecurityEventRepository
+	posts       ports.PostArchive
+	graph       ports.SocialGraph
+	archive     ports.ArchiveWriter
+	storage     ports.ObjectStorage
+	broker      ports.EventPublisher
+
+	linkTTL   time.Duration // Validité du lien de téléchargement
+	interval  time.Duration
+	batchSize int
+}
+
+func NewExportWorker(
+	users ports.UserRepository,
+	exports ports.DataExportRepository,
+	securityLog ports.SecurityEventRepository,
+	posts ports.PostArchive,
+	graph ports.SocialGraph,
+	archive ports.ArchiveWriter,
+	storage ports.ObjectStorage,
+	broker ports.EventPublisher,
+	linkTTL, interval time.Duration,
+	batchSize int,
+) *ExportWorker {
+	return &ExportWorker{
+		users:       users,
+		exports:     exports,
+		securityLog: securityLog,
+		posts:       posts,
+		graph:       graph,
+		archive:     archive,
+		storage:     storage,
+		broker:      broker,
+		linkTTL:     linkTTL,
+		interval:    interval,
+		batchSize:   batchSize,
+	}
+}
+
+// Run bloque jusqu'à l'annulation du contexte
+func (w *ExportWorker) Run(ctx context.Context) {
+	slog.Info("📦 Data export worker started", "interval", w.interval, "link_ttl", w.linkTTL)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.drain(ctx)
+			w.redeliver(ctx)
+			w.sweep(ctx)
+		}
+	}
+}
+
+// drain traite la file lot par lot
+func (w *ExportWorker) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		batch, err := w.exports.ClaimPendingExports(ctx, now, now.Add(domain.ExportClaimLease), w.batchSize)
+		if err != nil {
+			slog.Error("Failed to claim data exports", "error", err)
+			return
+		}
+
+		for _, export := range batch {
+			w.process(ctx, export)
+		}
+		if len(batch) < w.batchSize {
+			return
+		}
+	}
+}
+
+// process : un échec est retenté plus tard (backoff), jusqu'à ExportMaxAttempts
+func (w *ExportWorker) process(ctx context.Context, export *domain.DataExport) {
+	release := w.holdLease(ctx, export)
+	url, err := w.build(ctx, export)
+	release()
+	if err != nil {
+		export.Fail(err, time.Now().UTC())
+		slog.Error("Data export failed", "export_id", export.ID, "attempts", export.Attempts, "status", export.Status, "error", err)
+		if err := w.exports.SaveExport(ctx, export); err != nil {
+			slog.Error("Failed to save data export", "export_id", export.ID, "error", err)
+		}
+		return
+	}
+
+	if err := w.exports.SaveExport(ctx, export); err != nil {
+		// L'archive est déposée mais l'export reste "pending" : il sera refait à l'expiration du bail
+		slog.Error("Failed to save data export", "export_id", export.ID, "error", err)
+		return
+	}
+
+	slog.Info("Data export ready", "export_id", export.ID, "user_id", export.UserID)
+	w.notify(ctx, export, url)
+}
+
+// holdLease renouvelle le bail tant que la préparation dure : une collecte plus longue que
+// ExportClaimLease ne doit pas laisser un autre réplica reprendre l'export en parallèle.
+// La fonction renvoyée arrête le renouvellement (et attend sa fin avant l'enregistrement du résultat).
+func (w *ExportWorker) holdLease(ctx context.Context, export *domain.DataExport) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(domain.ExportClaimLease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				leaseUntil := time.Now().UTC().Add(domain.ExportClaimLease)
+				if err := w.exports.RenewExportLease(ctx, export.ID, leaseUntil); err != nil && ctx.Err() == nil {
+					slog.Error("Failed to renew data export lease", "export_id", export.ID, "error", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// notify publie data_export_ready. Sans accusé, l'annonce reste due et redeliver la rejoue.
+func (w *ExportWorker) notify(ctx context.Context, export *domain.DataExport, url string) {
+	if err := w.broker.PublishDataExportReady(ctx, export, url); err != nil {
+		slog.Error("Failed to publish data export ready", "export_id", export.ID, "error", err)
+		return
+	}
+	export.MarkNotified(time.Now().UTC())
+	if err := w.exports.SaveExport(ctx, export); err != nil {
+		// L'annonce sera republiée (même ID d'événement, dédupliquée par le broker)
+		slog.Error("Failed to save data export", "export_id", export.ID, "error", err)
+	}
+}
+
+// redeliver republie les annonces non accusées (broker indisponible au moment de la préparation)
+func (w *ExportWorker) redeliver(ctx context.Context) {
+	now := time.Now().UTC()
+	batch, err := w.exports.ClaimUnnotifiedExports(ctx, now, now.Add(domain.ExportNotifyRetryDelay), w.batchSize)
+	if err != nil {
+		slog.Error("Failed to claim unnotified data exports", "error", err)
+		return
+	}
+
+	for _, export := range batch {
+		url, err := w.storage.SignedURL(export.ObjectKey, export.LinkExpiresAt)
+		if err != nil {
+			slog.Error("Failed to sign data export url", "export_id", export.ID, "error", err)
+			continue
+		}
+		w.notify(ctx, export, url)
+	}
+}
+
+// sweep supprime les archives dont le lien a expiré : elles ne sont plus téléchargeables
+// et contiennent des données personnelles qu'il n'y a pas de raison de conserver.
+func (w *ExportWorker) sweep(ctx context.Context) {
+	batch, err := w.exports.ListExpiredExports(ctx, time.Now().UTC(), w.batchSize)
+	if err != nil {
+		slog.Error("Failed to list expired data exports", "error", err)
+		return
+	}
+
+	for _, export := range batch {
+		if export.ObjectKey != "" {
+			if err := w.storage.Delete(ctx, export.ObjectKey); err != nil {
+				slog.Error("Failed to delete data export archive", "export_id", export.ID, "error", err)
+				continue
+			}
+		}
+		export.Expire()
+		if err := w.exports.SaveExport(ctx, export); err != nil {
+			slog.Error("Failed to save data export", "export_id", export.ID, "error", err)
+		}
+	}
+}
+
+// build collecte les données, dépose l'archive et renvoie le lien signé
+func (w *ExportWorker) build(ctx context.Context, export *domain.DataExport) (string, error) {
+	bundle, err := w.collect(ctx, export)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := w.archive.Build(bundle)
+	if err != nil {
+		return "", fmt.Errorf("build archive: %w", err)
+	}
+
+	key := export.ArchiveKey()
+	if err := w.storage.Put(ctx, key, data, w.archive.ContentType()); err != nil {
+		return "", fmt.Errorf("store archive: %w", err)
+	}
+
+	now := time.Now().UTC()
+	url, err := w.storage.SignedURL(key, now.Add(w.linkTTL))
+	if err != nil {
+		return "", fmt.Errorf("sign download url: %w", err)
+	}
+	export.Complete(key, now, w.linkTTL)
+	return url, nil
+}
+
+func (w *ExportWorker) collect(ctx context.Context, export *domain.DataExport) (*domain.ExportBundle, error) {
+	user, err := w.users.GetByID(ctx, export.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("load user: %w", err)
+	}
+	events, err := w.securityLog.ListSecurityEvents(ctx, export.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("load security events: %w", err)
+	}
+	posts, err := w.posts.ListUserPosts(ctx, export.UserID)
+	if err != nil {
+		return nil, err
+	}
+	followers, err := w.graph.ListFollowers(ctx, export.UserID)
+	if err != nil {
+		return nil, err
+	}
+	following, err := w.graph.ListFollowing(ctx, export.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ExportBundle{
+		Export:         export,
+		User:           user,
+		SecurityEvents: events,
+		Posts:          posts,
+		Followers:      followers,
+		Following:      following,
+		GeneratedAt:    time.Now().UTC(),
+	}, nil
+}
@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
 	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/ports"
 )
@@ -16,27 +19,55 @@ type IdentityService struct {
 	hasher        ports.PasswordHasher
 	tokenProvider ports.TokenProvider
 	broker        ports.EventPublisher
+	securityLog   ports.SecurityEventRepository // Journal de sécurité (restitué dans l'export des données)
+
+	// Anti-bot (Proof-of-Work sur l'inscription)
+	challengeSigner ports.ChallengeSigner
+	challengeStore  ports.ChallengeStore
+	challengePolicy ChallengePolicy
 	// On pourrait ajouter ici un LoggerPort pour le logging structuré
 }
 
+// ChallengePolicy regroupe les réglages du défi d'inscription
+type ChallengePolicy struct {
+	BaseDifficulty int           // Bits exigés pour une IP "calme"
+	MaxDifficulty  int           // Plafond (au-delà, le coût devient absurde pour un humain)
+	TTL            time.Duration // Durée de validité d'un défi
+	RateWindow     time.Duration // Fenêtre d'observation des inscriptions par IP
+}
+
 // NewIdentityService est le constructeur avec injection de dépendances.
 func NewIdentityService(
 	repo ports.UserRepository,
 	hasher ports.PasswordHasher,
 	token ports.TokenProvider,
 	broker ports.EventPublisher,
+	securityLog ports.SecurityEventRepository,
+	challengeSigner ports.ChallengeSigner,
+	challengeStore ports.ChallengeStore,
+	challengePolicy ChallengePolicy,
 ) *IdentityService {
 	return &IdentityService{
-		repo:          repo,
-		hasher:        hasher,
-		tokenProvider: token,
-		broker:        broker,
+		repo:            repo,
+		hasher:          hasher,
+		tokenProvider:   token,
+		broker:          broker,
+		securityLog:     securityLog,
+		challengeSigner: challengeSigner,
+		challengeStore:  challengeStore,
+		challengePolicy: challengePolicy,
 	}
 }
 
 // --- AUTHENTIFICATION ---
 
 func (s *IdentityService) Register(ctx context.Context, cmd ports.RegisterCmd) (*ports.AuthResponse, error) {
+	// 0. Anti-bot : le client doit prouver qu'il a payé le coût CPU du défi
+	challenge, err := s.verifyChallenge(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	// 1. Fail Fast : Vérifier l'unicité de l'email
 	// Note: C'est une vérification "soft". La contrainte UNIQUE de la DB est la sécurité ultime (Race condition).
 	existingUser, err := s.repo.GetByEmail(ctx, cmd.Email)
@@ -44,23 +75,35 @@ func (s *IdentityService) Register(ctx context.Context, cmd ports.RegisterCmd) (
 		return nil, domain.ErrEmailAlreadyExists
 	}
 
-	// 2. Sécurité : Hachage du mot de passe
-	hashedPassword, err := s.hasher.Hash(cmd.Password)
+	// 2. Domaine : Création de l'agrégat User (Validation des invariants ici via NewUser)
+	user, err := domain.NewUser(cmd.Email, cmd.Username, "", cmd.FullName)
 	if err != nil {
-		return nil, fmt.Errorf("hashing failed: %w", err)
+		return nil, err // Retourne l'erreur du domaine (ex: ErrInvalidEmail)
+	}
+
+	// Le défi n'est consommé qu'une fois l'email libre et le profil valide : une saisie refusée
+	// ne coûte pas un nouveau proof-of-work. Seul état côté serveur : un défi résolu ne sert qu'une fois.
+	// Le hachage (coûteux) vient après : un défi rejoué ne le déclenche pas.
+	if err := s.challengeStore.ConsumeChallenge(ctx, challenge.ID, challenge.ExpiresAt); err != nil {
+		return nil, err
 	}
 
-	// 3. Domaine : Création de l'agrégat User (Validation des invariants ici via NewUser)
-	user, err := domain.NewUser(cmd.Email, cmd.Username, hashedPassword, cmd.FullName)
+	// 3. Sécurité : Hachage du mot de passe
+	hashedPassword, err := s.hasher.Hash(cmd.Password)
 	if err != nil {
-		return nil, err // Retourne l'erreur du domaine (ex: ErrInvalidEmail)
+		return nil, fmt.Errorf("hashing failed: %w", err)
 	}
+	user.PasswordHash = hashedPassword
 
 	// 4. Persistance : Sauvegarde atomique
 	if err := s.repo.Save(ctx, user); err != nil {
 		return nil, fmt.Errorf("repository save failed: %w", err)
 	}
 
+	// Alimente le calcul de difficulté des prochains défis pour cette IP (Best effort).
+	// Les inscriptions sorties de la fenêtre d'observation ne servent plus : elles sont purgées au passage.
+	_ = s.challengeStore.RecordSignup(ctx, cmd.IP, user.CreatedAt, user.CreatedAt.Add(-s.challengePolicy.RateWindow))
+
 	// 5. Side Effects : Génération des tokens + Publication événement
 	// Note : Idéalement, utiliser le pattern "Transactional Outbox" pour garantir que l'event part si la DB commit.
 	accessToken, refreshToken, err := s.tokenProvider.GenerateTokens(user)
@@ -93,15 +136,23 @@ func (s *IdentityService) Login(ctx context.Context, cmd ports.LoginCmd) (*ports
 
 	// 2. Vérification Mot de passe
 	if err := s.hasher.Compare(user.PasswordHash, cmd.Password); err != nil {
+		s.recordSecurityEvent(ctx, domain.NewSecurityEvent(user.ID, domain.SecurityLoginFailed, cmd.IP, cmd.Device, ""))
 		return nil, domain.ErrInvalidCredentials
 	}
 
+	// Vérifié APRÈS le mot de passe : on ne révèle pas la suspension à qui ne connaît que l'email
+	if user.IsSuspendedAt(time.Now().UTC()) {
+		return nil, domain.ErrAccountSuspended
+	}
+
 	// 3. Génération Tokens
 	accessToken, refreshToken, err := s.tokenProvider.GenerateTokens(user)
 	if err != nil {
 		return nil, fmt.Errorf("login token gen failed: %w", err)
 	}
 
+	s.recordSecurityEvent(ctx, domain.NewSecurityEvent(user.ID, domain.SecurityLoginSucceeded, cmd.IP, cmd.Device, ""))
+
 	return &ports.AuthResponse{
 		User:         user,
 		AccessToken:  accessToken,
@@ -110,6 +161,56 @@ func (s *IdentityService) Login(ctx context.Context, cmd ports.LoginCmd) (*ports
 	}, nil
 }
 
+// --- ANTI-BOT (PROOF-OF-WORK) ---
+
+// GetRegistrationChallenge émet un défi signé dont la difficulté dépend du débit d'inscriptions de l'IP.
+func (s *IdentityService) GetRegistrationChallenge(ctx context.Context, ip string) (*domain.RegistrationChallenge, error) {
+	now := time.Now().UTC()
+
+	recent, err := s.challengeStore.CountSignupsSince(ctx, ip, now.Add(-s.challengePolicy.RateWindow))
+	if err != nil {
+		return nil, fmt.Errorf("count recent signups: %w", err)
+	}
+
+	challenge := &domain.RegistrationChallenge{
+		ID:         uuid.NewString(),
+		IP:         ip,
+		Difficulty: domain.ChallengeDifficulty(recent, s.challengePolicy.BaseDifficulty, s.challengePolicy.MaxDifficulty),
+		ExpiresAt:  now.Add(s.challengePolicy.TTL),
+	}
+
+	// La signature rend la vérification stateless : aucun stockage à l'émission
+	if _, err := s.challengeSigner.Sign(challenge); err != nil {
+		return nil, fmt.Errorf("sign challenge: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// verifyChallenge contrôle signature, expiration, IP et preuve de travail (stateless).
+// L'appelant consomme le défi (anti-rejeu) une fois les autres vérifications passées.
+func (s *IdentityService) verifyChallenge(cmd ports.RegisterCmd) (*domain.RegistrationChallenge, error) {
+	if cmd.Challenge == "" {
+		return nil, domain.ErrChallengeRequired
+	}
+
+	challenge, err := s.challengeSigner.Verify(cmd.Challenge)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.IsExpired(time.Now().UTC()) {
+		return nil, domain.ErrChallengeExpired
+	}
+	// Un défi émis pour une IP ne peut pas être résolu par une ferme et rejoué depuis une autre
+	if challenge.IP != cmd.IP {
+		return nil, domain.ErrChallengeInvalid
+	}
+	if !challenge.IsSolvedBy(cmd.ChallengeNonce) {
+		return nil, domain.ErrChallengeUnsolved
+	}
+	return challenge, nil
+}
+
 // --- GESTION UTILISATEUR ---
 
 func (s *IdentityService) UpdateProfile(ctx context.Context, cmd ports.UpdateProfileCmd) (*domain.User, error) {
@@ -129,6 +230,7 @@ func (s *IdentityService) UpdateProfile(ctx context.Context, cmd ports.UpdatePro
 		isUpdated = true
 	}
 
+	emailChanged := false
 	if cmd.Email != nil && *cmd.Email != user.Email {
 		// Si changement d'email, vérifier l'unicité à nouveau !
 		if _, err := s.repo.GetByEmail(ctx, *cmd.Email); err == nil {
@@ -138,6 +240,12 @@ func (s *IdentityService) UpdateProfile(ctx context.Context, cmd ports.UpdatePro
 		// Pour l'instant on update direct.
 		user.Email = *cmd.Email
 		isUpdated = true
+		emailChanged = true
+	}
+
+	if cmd.ShowSensitiveMedia != nil && *cmd.ShowSensitiveMedia != user.ShowSensitiveMedia {
+		user.SetShowSensitiveMedia(*cmd.ShowSensitiveMedia)
+		isUpdated = true
 	}
 
 	// 3. Persister uniquement si nécessaire
@@ -146,6 +254,9 @@ func (s *IdentityService) UpdateProfile(ctx context.Context, cmd ports.UpdatePro
 			return nil, fmt.Errorf("update profile failed: %w", err)
 		}
 	}
+	if emailChanged {
+		s.recordSecurityEvent(ctx, domain.NewSecurityEvent(user.ID, domain.SecurityEmailChanged, "", "", ""))
+	}
 
 	return user, nil
 }
@@ -171,13 +282,34 @@ func (s *IdentityService) ChangePassword(ctx context.Context, userID, oldPass, n
 	user.UpdatePassword(newHash)
 
 	// Sauvegarde
-	return s.repo.Update(ctx, user)
+	if err := s.repo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	s.recordSecurityEvent(ctx, domain.NewSecurityEvent(user.ID, domain.SecurityPasswordChanged, "", "", ""))
+	return nil
 }
 
 // --- TOKEN MANAGEMENT (Boilerplate) ---
 
-func (s *IdentityService) ValidateToken(ctx context.Context, token string) (string, error) {
-	return s.tokenProvider.Validate(token)
+// ValidateToken recharge le titulaire : une suspension prend effet sans attendre l'expiration du JWT
+func (s *IdentityService) ValidateToken(ctx context.Context, token string) (*domain.User, error) {
+	userID, err := s.tokenProvider.Validate(token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+	if !user.IsActive {
+		return nil, domain.ErrInvalidToken
+	}
+	if user.IsSuspendedAt(time.Now().UTC()) {
+		return nil, domain.ErrAccountSuspended
+	}
+	return user, nil
 }
 
 func (s *IdentityService) RefreshToken(ctx context.Context, refreshToken string) (*ports.AuthResponse, error) {
@@ -189,3 +321,69 @@ func (s *IdentityService) RefreshToken(ctx context.Context, refreshToken string)
 func (s *IdentityService) GetUser(ctx context.Context, userID string) (*domain.User, error) {
 	return s.repo.GetByID(ctx, userID)
 }
+
+// --- MODÉRATION ---
+
+// SuspendUser applique une sanction décidée par la modération (Post Service).
+// Une nouvelle suspension remplace la précédente (prolongation comme levée anticipée).
+func (s *IdentityService) SuspendUser(ctx context.Context, cmd ports.SuspendUserCmd) (*domain.User, error) {
+	user, err := s.repo.GetByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.Suspend(cmd.Until, cmd.Reason, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveSuspension(ctx, user); err != nil {
+		return nil, fmt.Errorf("suspend user failed: %w", err)
+	}
+
+	s.recordSecurityEvent(ctx, domain.NewSecurityEvent(user.ID, domain.SecurityAccountSuspended, "", "", cmd.Reason))
+
+	// Best effort, comme à l'inscription
+	_ = s.broker.PublishUserSuspended(ctx, user, cmd.ModeratorID)
+
+	return user, nil
+}
+
+// recordSecurityEvent : best effort, un journal indisponible ne bloque ni connexion ni sanction
+func (s *IdentityService) recordSecurityEvent(ctx context.Context, event *domain.SecurityEvent) {
+	if err := s.securityLog.RecordSecurityEvent(ctx, event); err != nil {
+		slog.Error("Failed to record security event", "user_id", event.UserID, "kind", event.Kind, "error", err)
+	}
+}
+
+// MaxResolveUsernames borne la taille d'un batch de résolution (un post ne mentionne pas 1000 personnes)
+const MaxResolveUsernames = 100
+
+func (s *IdentityService) ResolveUsernames(ctx context.Context, usernames []string) (map[string]string, error) {
+	// Normalisation + dédoublonnage
+	seen := make(map[string]bool, len(usernames))
+	normalized := make([]string, 0, len(usernames))
+	for _, u := range usernames {
+		u = strings.ToLower(strings.TrimSpace(u))
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		normalized = append(normalized, u)
+	}
+	if len(normalized) > MaxResolveUsernames {
+		normalized = normalized[:MaxResolveUsernames]
+	}
+
+	ids := make(map[string]string, len(normalized))
+	if len(normalized) == 0 {
+		return ids, nil
+	}
+
+	users, err := s.repo.GetByUsernames(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		ids[strings.ToLower(u.Username)] = u.ID
+	}
+	return ids, nil
+}
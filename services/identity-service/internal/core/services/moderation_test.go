@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/identity-service/internal/core/ports"
+)
+
+// fakeUserRepo : utilisateurs en mémoire, compte les écritures par méthode
+type fakeUserRepo struct {
+	ports.UserRepository
+	users       map[string]*domain.User
+	updates     int
+	suspensions int
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (r *fakeUserRepo) Update(ctx context.Context, user *domain.User) error {
+	r.updates++
+	return nil
+}
+
+func (r *fakeUserRepo) SaveSuspension(ctx context.Context, user *domain.User) error {
+	r.suspensions++
+	stored := r.users[user.ID]
+	stored.SuspendedUntil = user.SuspendedUntil
+	stored.SuspensionReason = user.SuspensionReason
+	return nil
+}
+
+type fakeSecurityLog struct {
+	ports.SecurityEventRepository
+	events []*domain.SecurityEvent
+}
+
+func (l *fakeSecurityLog) RecordSecurityEvent(ctx context.Context, event *domain.SecurityEvent) error {
+	l.events = append(l.events, event)
+	return nil
+}
+
+type fakePublisher struct {
+	ports.EventPublisher
+	suspended []string
+}
+
+func (p *fakePublisher) PublishUserSuspended(ctx context.Context, user *domain.User, moderatorID string) error {
+	p.suspended = append(p.suspended, user.ID)
+	return nil
+}
+
+func TestSuspendUserWritesOnlyTheSuspension(t *testing.T) {
+	repo := &fakeUserRepo{users: map[string]*domain.User{
+		"u1": {ID: "u1", Email: "alice@example.com", FullName: "Alice"},
+	}}
+	securityLog := &fakeSecurityLog{}
+	broker := &fakePublisher{}
+	s := &IdentityService{repo: repo, securityLog: securityLog, broker: broker}
+
+	until := time.Now().UTC().Add(24 * time.Hour)
+	user, err := s.SuspendUser(context.Background(), ports.SuspendUserCmd{UserID: "u1", ModeratorID: "m1", Reason: "spam", Until: until})
+	if err != nil {
+		t.Fatalf("SuspendUser: %v", err)
+	}
+
+	if repo.updates != 0 {
+		t.Errorf("Update appelé %d fois : la suspension ne doit pas réécrire le profil", repo.updates)
+	}
+	if repo.suspensions != 1 {
+		t.Errorf("SaveSuspension appelé %d fois, want 1", repo.suspensions)
+	}
+	if !user.SuspendedUntil.Equal(until) || repo.users["u1"].SuspensionReason != "spam" {
+		t.Errorf("suspension non enregistrée : %+v", repo.users["u1"])
+	}
+	if len(securityLog.events) != 1 || securityLog.events[0].Kind != domain.SecurityAccountSuspended {
+		t.Errorf("journal de sécurité = %+v", securityLog.events)
+	}
+	if len(broker.suspended) != 1 {
+		t.Errorf("user.suspended publié %d fois, want 1", len(broker.suspended))
+	}
+}
+
+func TestSuspendUserRejectsPastDeadline(t *testing.T) {
+	repo := &fakeUserRepo{users: map[string]*domain.User{"u1": {ID: "u1"}}}
+	s := &IdentityService{repo: repo, securityLog: &fakeSecurityLog{}, broker: &fakePublisher{}}
+
+	_, err := s.SuspendUser(context.Background(), ports.SuspendUserCmd{UserID: "u1", Until: time.Now().UTC().Add(-time.Hour)})
+	if !errors.Is(err, domain.ErrInvalidSuspension) {
+		t.Fatalf("err = %v, want ErrInvalidSuspension", err)
+	}
+	if repo.suspensions != 0 {
+		t.Error("aucune écriture attendue pour une sanction invalide")
+	}
+}
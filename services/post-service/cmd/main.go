@@ -11,6 +11,8 @@ import (
 	// Drivers
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/nats-io/nats.go"
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -29,8 +31,15 @@ import (
 	// Interne
 	"github.com/jupiterclapton/cenackle/services/post-service/config"
 	grpc_adapter "github.com/jupiterclapton/cenackle/services/post-service/internal/adapters/primary/grpc"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/adapters/secondary/classifier"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/adapters/secondary/clients"
 	"github.com/jupiterclapton/cenackle/services/post-service/internal/adapters/secondary/eventbroker"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/adapters/secondary/langdetect"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/adapters/secondary/linkpreview"
 	"github.com/jupiterclapton/cenackle/services/post-service/internal/adapters/secondary/repository"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/adapters/secondary/viewers"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
 	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/services"
 )
 
@@ -77,12 +86,94 @@ func main() {
 	defer nc.Close()
 	slog.Info("✅ Connected to NATS")
 
+	// 4a. Infrastructure: Redis (spectateurs uniques des statistiques, facultatif)
+	viewerCounter, closeRedis, err := initViewerCounter(ctx, cfg)
+	if err != nil {
+		slog.Error("Unable to connect to Redis", "error", err)
+		os.Exit(1)
+	}
+	defer closeRedis()
+
+	// 4b. Infrastructure: Identity Client (résolution des @mentions, suspensions décidées par la modération)
+	identityClient, err := clients.NewIdentityClient(cfg.IdentityUrl)
+	if err != nil {
+		slog.Error("Unable to connect to Identity Service", "error", err)
+		os.Exit(1)
+	}
+	defer identityClient.Close()
+
+	// 4c. Infrastructure: Graph Client (visibilité des posts)
+	graphClient, err := clients.NewGraphClient(cfg.GraphUrl)
+	if err != nil {
+		slog.Error("Unable to connect to Graph Service", "error", err)
+		os.Exit(1)
+	}
+	defer graphClient.Close()
+
 	// 5. Initialisation des Adapters (Driven)
 	postRepo := repository.NewPostgresRepo(dbPool)
-	eventPub := eventbroker.NewNatsPublisher(nc)
+	commentRepo := repository.NewCommentRepo(dbPool)
+	reactionRepo := repository.NewReactionRepo(dbPool)
+	pollRepo := repository.NewPollRepo(dbPool)
+	linkPreviewRepo := repository.NewLinkPreviewRepo(dbPool)
+	moderationRepo := repository.NewModerationRepo(dbPool)
+	idempotencyRepo := repository.NewIdempotencyRepo(dbPool)
+	bookmarkRepo := repository.NewBookmarkRepo(dbPool)
+	analyticsRepo := repository.NewAnalyticsRepo(dbPool)
+	linkFetcher := linkpreview.NewFetcher(linkpreview.Config{
+		Timeout:     cfg.LinkPreviewTimeout,
+		MaxBodySize: int64(cfg.LinkPreviewMaxBytes),
+		UserAgent:   "CenackleBot/1.0 (+link preview)",
+	})
+	outboxRepo := repository.NewOutboxRepo(dbPool)
+	eventPub, err := eventbroker.NewNatsPublisher(ctx, nc)
+	if err != nil {
+		slog.Error("Unable to set up the POSTS and MODERATION streams", "error", err)
+		os.Exit(1)
+	}
+	contentClassifier, err := initClassifier(cfg)
+	if err != nil {
+		slog.Error("Unable to load content classifier", "error", err)
+		os.Exit(1)
+	}
 
 	// 6. Initialisation du Core (Domain Logic)
-	postService := services.NewPostService(postRepo, eventPub)
+	postService := services.NewPostService(postRepo, reactionRepo, bookmarkRepo, pollRepo, identityClient, graphClient, eventPub, contentClassifier, langdetect.NewDetector(cfg.DetectLanguages), idempotencyRepo, services.PostPolicy{
+		EditWindow:         cfg.EditWindow,
+		RestoreWindow:      cfg.RestoreWindow,
+		Limits:             domain.PostLimits{MaxContentLength: cfg.PostMaxLength, MaxMedia: cfg.PostMaxMedia},
+		ClassifierFailOpen: cfg.ClassifierFailOpen,
+		IdempotencyTTL:     cfg.IdempotencyTTL,
+	})
+	commentService := services.NewCommentService(commentRepo, postRepo, graphClient, eventPub)
+	reactionService := services.NewReactionService(reactionRepo, postRepo, graphClient, eventPub)
+	moderationService := services.NewModerationService(moderationRepo, postRepo, commentRepo, identityClient, graphClient, eventPub)
+	bookmarkService := services.NewBookmarkService(bookmarkRepo, postRepo, reactionRepo, pollRepo, graphClient)
+	analyticsRecorder := services.NewAnalyticsRecorder(analyticsRepo, viewerCounter, cfg.AnalyticsFlushInterval)
+	analyticsService := services.NewAnalyticsService(analyticsRecorder, analyticsRepo, postRepo)
+
+	// 6b. Publication des posts planifiés et clôture des sondages (tourne sur chaque réplica, cf. FOR UPDATE SKIP LOCKED)
+	scheduler := services.NewScheduler(postRepo, pollRepo, graphClient, eventPub, cfg.SchedulerInterval, cfg.SchedulerBatchSize)
+	go scheduler.Run(ctx)
+
+	// 6c. Purge des posts supprimés dont la fenêtre de restauration est dépassée (et des clés d'idempotence expirées)
+	purger := services.NewPurger(postRepo, idempotencyRepo, cfg.RestoreWindow, cfg.PurgeInterval, cfg.SchedulerBatchSize)
+	go purger.Run(ctx)
+
+	// 6d. Aperçus des liens postés (requêtes sortantes hors du chemin des requêtes utilisateur)
+	linkPreviewWorker := services.NewLinkPreviewWorker(linkPreviewRepo, linkFetcher, cfg.SchedulerInterval, cfg.SchedulerBatchSize)
+	go linkPreviewWorker.Run(ctx)
+
+	// 6e. Écriture par lots des impressions et vues (chaque réplica vide son propre tampon)
+	analyticsDone := make(chan struct{})
+	go func() {
+		analyticsRecorder.Run(ctx)
+		close(analyticsDone)
+	}()
+
+	// 6f. Publication des événements de l'outbox sur JetStream (tourne sur chaque réplica, cf. FOR UPDATE SKIP LOCKED)
+	outboxRelay := services.NewOutboxRelay(outboxRepo, eventPub, cfg.OutboxPollInterval, cfg.SchedulerBatchSize)
+	go outboxRelay.Run(ctx)
 
 	// 7. Initialisation du Primary Adapter (gRPC)
 	// Ajout de l'intercepteur OTEL pour propager le contexte de trace
@@ -90,7 +181,7 @@ func main() {
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	)
 
-	serverAdapter := grpc_adapter.NewServer(postService)
+	serverAdapter := grpc_adapter.NewServer(postService, commentService, reactionService, moderationService, bookmarkService, analyticsService)
 	serverAdapter.Register(grpcServer)
 
 	// Health Check standard pour K8s/Docker
@@ -124,6 +215,9 @@ func main() {
 	slog.Info("🛑 Shutting down server...")
 
 	grpcServer.GracefulStop()
+
+	cancel()        // Arrête le scheduler, le relais de l'outbox, le purger et l'écriture des statistiques
+	<-analyticsDone // Dernier lot d'impressions et vues écrit avant de fermer la base
 	slog.Info("👋 Server exited")
 }
 
@@ -143,6 +237,31 @@ func initLogger(cfg config.Config) {
 	slog.SetDefault(slog.New(handler))
 }
 
+// initClassifier : liste de blocage (locale, instantanée) puis modèle (HTTP). nil si aucun n'est configuré.
+func initClassifier(cfg config.Config) (ports.ContentClassifier, error) {
+	var chain classifier.Chain
+	if cfg.ClassifierBlocklistFile != "" {
+		blocklist, err := classifier.LoadBlocklist(cfg.ClassifierBlocklistFile)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, blocklist)
+	}
+	if cfg.ClassifierURL != "" {
+		chain = append(chain, classifier.NewHTTPClassifier(classifier.HTTPConfig{
+			URL:             cfg.ClassifierURL,
+			Timeout:         cfg.ClassifierTimeout,
+			ReviewThreshold: cfg.ClassifierReviewThreshold,
+			RejectThreshold: cfg.ClassifierRejectThreshold,
+		}))
+	}
+	if len(chain) == 0 {
+		slog.Info("No content classifier configured, automated moderation disabled")
+		return nil, nil
+	}
+	return chain, nil
+}
+
 func initTracer(ctx context.Context, cfg config.Config) (*sdktrace.TracerProvider, error) {
 	exporter, err := otlptracegrpc.New(ctx,
 		otlptracegrpc.WithEndpoint(cfg.OtelEndpoint),
@@ -169,3 +288,22 @@ func initTracer(ctx context.Context, cfg config.Config) (*sdktrace.TracerProvide
 
 	return tp, nil
 }
+
+// initViewerCounter : REDIS_ADDR vide = statistiques sans spectateurs uniques
+func initViewerCounter(ctx context.Context, cfg config.Config) (ports.ViewerCounter, func(), error) {
+	if cfg.RedisAddr == "" {
+		slog.Warn("REDIS_ADDR not set, unique viewers will not be counted")
+		return nil, func() {}, nil
+	}
+
+	rdb := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, nil, err
+	}
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		_ = rdb.Close()
+		return nil, nil, err
+	}
+	slog.Info("✅ Connected to Redis")
+	return viewers.NewRedisCounter(rdb), func() { _ = rdb.Close() }, nil
+}
@@ -0,0 +1,24 @@
+-- --- COMMENTAIRES (1 niveau de réponses) ---
+
+CREATE TABLE IF NOT EXISTS comments (
+    id UUID PRIMARY KEY,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    -- NULL = commentaire racine. Une réponse pointe TOUJOURS vers une racine (pas de réponse de réponse).
+    parent_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    content TEXT NOT NULL,
+    replies_count INT NOT NULL DEFAULT 0, -- Dénormalisé (évite un COUNT par commentaire)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Pagination des commentaires racines d'un post (keyset sur created_at)
+CREATE INDEX IF NOT EXISTS idx_comments_post_root_timestamp
+ON comments (post_id, created_at) WHERE parent_id IS NULL;
+
+-- Pagination des réponses d'un commentaire
+CREATE INDEX IF NOT EXISTS idx_comments_parent_timestamp
+ON comments (parent_id, created_at) WHERE parent_id IS NOT NULL;
+
+-- Compteur dénormalisé sur le post (racines + réponses), maintenu dans la même transaction que l'écriture
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS comments_count INT NOT NULL DEFAULT 0;
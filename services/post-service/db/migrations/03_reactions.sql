@@ -0,0 +1,17 @@
+-- --- RÉACTIONS (like + emojis) ---
+
+-- Une seule réaction par utilisateur et par post (changer de réaction = UPDATE)
+CREATE TABLE IF NOT EXISTS post_reactions (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL, -- "like", "love", "haha", "wow", "sad", "angry"
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id)
+);
+
+-- Hydratation "viewer" du Feed : WHERE user_id = $1 AND post_id = ANY($2)
+CREATE INDEX IF NOT EXISTS idx_post_reactions_user ON post_reactions (user_id, post_id);
+
+-- Compteurs agrégés par type : {"like": 12, "love": 3}
+-- Maintenus dans la même transaction que post_reactions (la ligne du post est verrouillée)
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reaction_counts JSONB NOT NULL DEFAULT '{}';
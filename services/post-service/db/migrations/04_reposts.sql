@@ -0,0 +1,17 @@
+-- --- REPOSTS & CITATIONS ---
+
+-- Repost pur : content vide + pas de média. Citation : nouveau contenu qui référence le post.
+-- SET NULL : une citation garde son texte si l'original disparaît (les reposts purs sont supprimés avec lui)
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reposted_post_id UUID REFERENCES posts(id) ON DELETE SET NULL;
+
+-- Compteur dénormalisé (reposts + citations), maintenu dans la même transaction que l'INSERT/DELETE
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reposts_count INT NOT NULL DEFAULT 0;
+
+-- Un utilisateur ne repartage (repost pur) qu'une seule fois le même post
+CREATE UNIQUE INDEX IF NOT EXISTS idx_posts_unique_repost
+ON posts (user_id, reposted_post_id)
+WHERE reposted_post_id IS NOT NULL AND content = '' AND media = '[]'::jsonb;
+
+-- Suppression des reposts purs quand l'original est supprimé
+CREATE INDEX IF NOT EXISTS idx_posts_reposted_post
+ON posts (reposted_post_id) WHERE reposted_post_id IS NOT NULL;
@@ -0,0 +1,27 @@
+-- --- HASHTAGS & MENTIONS ---
+
+-- Entités telles qu'affichées (type, texte normalisé, offsets en caractères, user_id résolu)
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS entities JSONB NOT NULL DEFAULT '[]';
+
+-- Tables normalisées : réécrites dans la même transaction que le post (création / édition)
+CREATE TABLE IF NOT EXISTS post_hashtags (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL, -- Minuscules, sans '#'
+    created_at TIMESTAMPTZ NOT NULL, -- Copie de posts.created_at (pagination sans jointure)
+    PRIMARY KEY (post_id, tag)
+);
+
+-- 🚀 Timeline d'un hashtag : keyset sur created_at
+CREATE INDEX IF NOT EXISTS idx_post_hashtags_tag_timestamp
+ON post_hashtags (tag, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS post_mentions (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL, -- Utilisateur mentionné (résolu via identity-service)
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (post_id, user_id)
+);
+
+-- "Posts qui me mentionnent"
+CREATE INDEX IF NOT EXISTS idx_post_mentions_user_timestamp
+ON post_mentions (user_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- --- VISIBILITÉ (audience) ---
+-- Appliquée à la lecture par le service (le graphe social vit dans graph-service)
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS visibility TEXT NOT NULL DEFAULT 'public'
+    CHECK (visibility IN ('public', 'followers', 'mentioned', 'close_friends'));
@@ -0,0 +1,13 @@
+-- --- BROUILLONS & PUBLICATION PROGRAMMÉE ---
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published'
+    CHECK (status IN ('draft', 'scheduled', 'published'));
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ; -- NULL sauf pour un post planifié/publié par le scheduler
+
+-- Scheduler : "les posts planifiés arrivés à échéance" (index partiel = minuscule)
+CREATE INDEX IF NOT EXISTS idx_posts_scheduled_publish_at
+ON posts (publish_at) WHERE status = 'scheduled';
+
+-- ListDrafts : brouillons + planifiés d'un auteur (keyset sur updated_at)
+CREATE INDEX IF NOT EXISTS idx_posts_author_drafts
+ON posts (user_id, updated_at DESC) WHERE status <> 'published';
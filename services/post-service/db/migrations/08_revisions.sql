@@ -0,0 +1,19 @@
+-- --- HISTORIQUE DES ÉDITIONS ---
+
+-- Date de la dernière édition d'un post publié (NULL = jamais édité).
+-- Distincte de updated_at, qui bouge aussi pour les brouillons et la programmation.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS edited_at TIMESTAMPTZ;
+
+-- Une ligne par version REMPLACÉE (la version courante reste dans posts)
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(), -- Écrite par la DB elle-même (cf. saveRevision)
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    media JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL,  -- Date à laquelle cette version est devenue courante
+    replaced_at TIMESTAMPTZ NOT NULL  -- Date de l'édition qui l'a remplacée
+);
+
+-- ListPostRevisions : plus récentes d'abord (keyset sur replaced_at)
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_timestamp
+ON post_revisions (post_id, replaced_at DESC);
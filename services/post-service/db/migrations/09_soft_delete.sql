@@ -0,0 +1,9 @@
+-- --- SUPPRESSION DOUCE (restauration possible, purge différée) ---
+
+-- NULL = post vivant. Sinon le post est une "pierre tombale" : invisible dans les listes,
+-- restaurable par son auteur pendant la fenêtre de restauration, puis purgé.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- Job de purge : "les posts supprimés depuis plus de X" (index partiel = minuscule)
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at
+ON posts (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,18 @@
+-- --- RECHERCHE PLEIN TEXTE ---
+
+-- Langue du post (ISO 639-1, vide = inconnue) : choisit la configuration linguistique (stemming, mots vides)
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT '';
+
+-- Colonne générée : toujours à jour, sans trigger. Le CASE (et non un cast text::regconfig)
+-- garde l'expression IMMUTABLE, condition pour une colonne générée.
+-- Langue inconnue = 'simple' (pas de stemming, mais préfixes et phrases fonctionnent).
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    CASE language
+        WHEN 'fr' THEN to_tsvector('french', content)
+        WHEN 'en' THEN to_tsvector('english', content)
+        ELSE to_tsvector('simple', content)
+    END
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_posts_search_vector
+ON posts USING GIN (search_vector);
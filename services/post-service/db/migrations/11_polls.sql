@@ -0,0 +1,36 @@
+-- --- SONDAGES (au plus un par post) ---
+
+-- Le sondage est créé avec son post et disparaît avec lui (purge des pierres tombales incluse)
+CREATE TABLE IF NOT EXISTS polls (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    closes_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ, -- NULL tant que la clôture n'a pas été traitée (événement post.poll_closed)
+    voters_count INT NOT NULL DEFAULT 0
+);
+
+-- Options ordonnées (position = index renvoyé par l'API, 0..3)
+CREATE TABLE IF NOT EXISTS poll_options (
+    post_id UUID NOT NULL REFERENCES polls(post_id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    label TEXT NOT NULL,
+    votes_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, position)
+);
+
+-- Un vote = une ligne par option choisie (plusieurs lignes en choix multiple)
+-- Les compteurs sont maintenus dans la même transaction (la ligne du sondage est verrouillée)
+CREATE TABLE IF NOT EXISTS poll_votes (
+    post_id UUID NOT NULL REFERENCES polls(post_id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    position SMALLINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id, position)
+);
+
+-- Hydratation "viewer" : WHERE user_id = $1 AND post_id = ANY($2)
+CREATE INDEX IF NOT EXISTS idx_poll_votes_user ON poll_votes (user_id, post_id);
+
+-- Job de clôture : "les sondages échus pas encore clôturés" (index partiel)
+CREATE INDEX IF NOT EXISTS idx_polls_due
+ON polls (closes_at) WHERE closed_at IS NULL;
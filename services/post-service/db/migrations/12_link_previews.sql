@@ -0,0 +1,16 @@
+-- --- APERÇUS DE LIENS (OpenGraph / Twitter card / oEmbed) ---
+
+-- NULL = pas de lien, ou aperçu pas encore récupéré / indisponible
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS link_preview JSONB;
+
+-- File de travail du worker : une ligne par post dont le premier lien doit être (re)visité.
+-- Alimentée par Save/Update dans la transaction du post, vidée après succès ou abandon.
+CREATE TABLE IF NOT EXISTS link_preview_jobs (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_preview_jobs_next_attempt
+ON link_preview_jobs (next_attempt_at);
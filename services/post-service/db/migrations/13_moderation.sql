@@ -0,0 +1,64 @@
+-- --- MODÉRATION (signalements, file de traitement, historique des décisions) ---
+
+-- Masquage par la modération : le contenu reste en base (appel possible) mais n'est plus servi
+-- qu'à son auteur. NULL = visible.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+
+-- Un dossier agrège tous les signalements d'une même cible tant qu'il n'est pas résolu.
+-- target_id est en TEXT : un utilisateur n'est pas une ligne de ce service.
+CREATE TABLE IF NOT EXISTS moderation_cases (
+    id UUID PRIMARY KEY,
+    target_type VARCHAR(20) NOT NULL, -- post, comment, user
+    target_id TEXT NOT NULL,
+    target_author_id TEXT NOT NULL,   -- Auteur du contenu (ou l'utilisateur signalé lui-même)
+    status VARCHAR(20) NOT NULL DEFAULT 'open', -- open, claimed, resolved
+    reports_count INT NOT NULL DEFAULT 0,
+    reason_counts JSONB NOT NULL DEFAULT '{}'::jsonb, -- {"spam": 3, "harassment": 1}
+    claimed_by TEXT,
+    claimed_at TIMESTAMPTZ,
+    resolution VARCHAR(20), -- Action retenue (dismiss, hide, delete, warn, suspend)
+    resolved_by TEXT,
+    resolved_at TIMESTAMPTZ,
+    first_reported_at TIMESTAMPTZ NOT NULL,
+    last_reported_at TIMESTAMPTZ NOT NULL
+);
+
+-- Au plus un dossier ouvert par cible : un nouveau signalement après résolution rouvre un dossier neuf
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_cases_open_target
+ON moderation_cases (target_type, target_id) WHERE status <> 'resolved';
+
+-- File de traitement : les plus anciens d'abord (pagination keyset sur first_reported_at)
+CREATE INDEX IF NOT EXISTS idx_moderation_cases_queue
+ON moderation_cases (status, first_reported_at);
+
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY,
+    case_id UUID NOT NULL REFERENCES moderation_cases(id) ON DELETE CASCADE,
+    reporter_id TEXT NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (case_id, reporter_id) -- Un signalement par personne et par dossier
+);
+
+-- Historique des décisions : append-only, jamais purgé (appels, audit).
+-- Pas de clé étrangère vers posts : la décision survit à la purge du contenu.
+CREATE TABLE IF NOT EXISTS moderation_decisions (
+    id UUID PRIMARY KEY,
+    case_id UUID NOT NULL REFERENCES moderation_cases(id),
+    target_type VARCHAR(20) NOT NULL,
+    target_id TEXT NOT NULL,
+    target_author_id TEXT NOT NULL,
+    moderator_id TEXT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    suspended_until TIMESTAMPTZ, -- Renseigné pour une suspension temporaire
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_decisions_target
+ON moderation_decisions (target_type, target_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_decisions_author
+ON moderation_decisions (target_author_id, created_at DESC);
@@ -0,0 +1,7 @@
+-- --- MODÉRATION AUTOMATIQUE ---
+
+-- "held" : post retenu par le classifieur, publié à l'approbation d'un modérateur
+-- (visible de son seul auteur entre-temps, il apparaît dans ses brouillons via idx_posts_author_drafts)
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS posts_status_check;
+ALTER TABLE posts ADD CONSTRAINT posts_status_check
+    CHECK (status IN ('draft', 'scheduled', 'published', 'held'));
@@ -0,0 +1,20 @@
+-- --- CLÉS D'IDEMPOTENCE (CreatePost / UpdatePost / DeletePost rejoués par les clients mobiles) ---
+
+-- Une ligne par (auteur, opération, clé) : réservée avant l'opération, complétée après.
+-- post_id est connu dès la réservation (CreatePost tire l'ID du post avant de l'enregistrer).
+-- Pas de clé étrangère : un post purgé ne doit pas faire "oublier" la clé avant son expiration.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id UUID NOT NULL,
+    operation VARCHAR(20) NOT NULL, -- create_post, update_post, delete_post
+    key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL, -- SHA-256 de la requête : une clé réutilisée pour autre chose est refusée
+    post_id UUID NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, operation, key)
+);
+
+-- Purge des clés expirées
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at
+ON idempotency_keys (expires_at);
@@ -0,0 +1,31 @@
+-- --- SIGNETS & COLLECTIONS (privés : seul leur propriétaire les voit) ---
+
+CREATE TABLE IF NOT EXISTS bookmark_collections (
+    id UUID PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    position INT NOT NULL, -- Ordre choisi par l'utilisateur (0 = première)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Un nom par utilisateur, sans tenir compte de la casse ("Recettes" = "recettes")
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookmark_collections_user_name
+ON bookmark_collections (user_id, lower(name));
+
+-- Un post est enregistré au plus une fois, dans au plus une collection (NULL = non classé).
+-- La purge d'un post emporte ses signets ; pendant la fenêtre de restauration, ils s'affichent en pierre tombale.
+CREATE TABLE IF NOT EXISTS bookmarks (
+    user_id TEXT NOT NULL,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    collection_id UUID REFERENCES bookmark_collections(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+-- ListBookmarks : tous les signets, ou ceux d'une collection (keyset sur created_at).
+-- Hydratation "viewer" (isBookmarkedByMe) : la clé primaire suffit.
+CREATE INDEX IF NOT EXISTS idx_bookmarks_user_created
+ON bookmarks (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_collection_created
+ON bookmarks (collection_id, created_at DESC) WHERE collection_id IS NOT NULL;
@@ -0,0 +1,8 @@
+-- --- POSTS ÉPINGLÉS (profil) ---
+
+-- NULL = non épinglé. Une suppression désépingle (un post restauré ne reprend pas sa place).
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS pinned_at TIMESTAMPTZ;
+
+-- Première page du profil : posts épinglés de l'auteur, derniers épinglés d'abord
+CREATE INDEX IF NOT EXISTS idx_posts_user_pinned
+ON posts (user_id, pinned_at DESC) WHERE pinned_at IS NOT NULL;
@@ -0,0 +1,26 @@
+-- --- STATISTIQUES DES POSTS (auteur uniquement) ---
+-- Alimentées par lots (cf. AnalyticsRecorder) : impressions (post affiché dans un fil) et vues (post ouvert).
+-- Les spectateurs uniques sont dédoublonnés dans Redis (HyperLogLog) ; la cardinalité est recopiée ici à chaque lot.
+
+-- Totaux par post
+CREATE TABLE IF NOT EXISTS post_analytics (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    impressions BIGINT NOT NULL DEFAULT 0,
+    views BIGINT NOT NULL DEFAULT 0,
+    unique_viewers BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Tranches horaires (UTC, début de l'heure)
+CREATE TABLE IF NOT EXISTS post_analytics_hourly (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    bucket TIMESTAMPTZ NOT NULL,
+    impressions BIGINT NOT NULL DEFAULT 0,
+    views BIGINT NOT NULL DEFAULT 0,
+    unique_viewers BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, bucket)
+);
+
+-- Commentaires par heure (GetPostAnalytics) : les index existants sont partiels (racines / réponses)
+CREATE INDEX IF NOT EXISTS idx_comments_post_created
+ON comments (post_id, created_at);
@@ -0,0 +1,19 @@
+-- --- OUTBOX TRANSACTIONNELLE (événements à publier sur JetStream) ---
+
+-- Écrite dans la même transaction que le changement d'état : un événement n'est jamais perdu
+-- (NATS indisponible, réplica tombé après le commit). L'OutboxRelay publie puis supprime la ligne.
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY, -- Nats-Msg-Id : JetStream écarte les doublons d'un essai rejoué
+    subject TEXT NOT NULL,
+    payload BYTEA NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}', -- Contexte de trace W3C de la requête d'origine
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Réclamation (FOR UPDATE SKIP LOCKED) et nouvelles tentatives
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT
+);
+
+-- File du relais : messages dus, plus anciens d'abord
+CREATE INDEX IF NOT EXISTS idx_outbox_next_attempt ON outbox (next_attempt_at, created_at);
@@ -0,0 +1,8 @@
+-- --- AVERTISSEMENTS DE CONTENU ET MÉDIAS SENSIBLES ---
+
+-- Avertissement libre affiché avant le contenu ('' = aucun), posé par l'auteur ou un modérateur.
+-- Le drapeau "sensitive" et le blurhash de chaque média vivent dans le JSONB posts.media.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS content_warning TEXT NOT NULL DEFAULT '';
+
+-- Décision mark_sensitive : avertissement imposé par le modérateur ('' = celui de l'auteur conservé)
+ALTER TABLE moderation_decisions ADD COLUMN IF NOT EXISTS content_warning TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+-- --- FILS DE DISCUSSION (THREADS) ---
+
+-- Posts publiés ensemble et numérotés : thread_id est l'ID du premier post (la tête),
+-- thread_position commence à 1 (0 = hors fil). Pas de clé étrangère : la tête peut être purgée
+-- avant la suite.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS thread_id UUID;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS thread_position INT NOT NULL DEFAULT 0;
+
+-- Lecture d'un fil dans l'ordre (et une seule place par position)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_posts_thread ON posts (thread_id, thread_position) WHERE thread_id IS NOT NULL;
@@ -0,0 +1,14 @@
+-- --- SIGNETS : identifiants typés et pagination sur (date, post) ---
+
+-- Les utilisateurs sont identifiés par un UUID (Identity Service)
+ALTER TABLE bookmark_collections ALTER COLUMN user_id TYPE UUID USING user_id::uuid;
+ALTER TABLE bookmarks ALTER COLUMN user_id TYPE UUID USING user_id::uuid;
+
+-- ListBookmarks : keyset sur (created_at, post_id), des signets peuvent partager leur date
+DROP INDEX IF EXISTS idx_bookmarks_user_created;
+CREATE INDEX IF NOT EXISTS idx_bookmarks_user_created_post
+ON bookmarks (user_id, created_at DESC, post_id DESC);
+
+DROP INDEX IF EXISTS idx_bookmarks_collection_created;
+CREATE INDEX IF NOT EXISTS idx_bookmarks_collection_created_post
+ON bookmarks (collection_id, created_at DESC, post_id DESC) WHERE collection_id IS NOT NULL;
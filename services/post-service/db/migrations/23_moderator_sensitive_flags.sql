@@ -0,0 +1,17 @@
+-- --- DÉCISIONS mark_sensitive DURABLES ---
+
+-- Une édition de l'auteur ne doit pas défaire la décision du modérateur : le post garde la trace
+-- du marquage (médias ajoutés ensuite marqués sensibles) et de l'avertissement imposé ('' = aucun).
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS marked_sensitive BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS moderator_warning TEXT NOT NULL DEFAULT '';
+
+-- Décisions déjà prises : la plus récente fait foi
+UPDATE posts p
+SET marked_sensitive = true, moderator_warning = d.content_warning
+FROM (
+    SELECT DISTINCT ON (target_id) target_id, content_warning
+    FROM moderation_decisions
+    WHERE target_type = 'post' AND action = 'mark_sensitive'
+    ORDER BY target_id, created_at DESC
+) d
+WHERE p.id::text = d.target_id;
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- STATISTIQUES ---
+
+func (s *Server) RecordPostEvents(ctx context.Context, req *postv1.RecordPostEventsRequest) (*emptypb.Empty, error) {
+	events := make([]domain.PostEvent, len(req.Events))
+	for i, e := range req.Events {
+		events[i] = domain.PostEvent{PostID: e.PostId, Kind: domain.PostEventKind(e.Kind)}
+	}
+
+	if err := s.analytics.RecordPostEvents(ctx, req.ViewerId, req.SessionId, events); err != nil {
+		return nil, mapAnalyticsError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetPostAnalytics(ctx context.Context, req *postv1.GetPostAnalyticsRequest) (*postv1.GetPostAnalyticsResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	var from, to time.Time
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+
+	stats, err := s.analytics.GetPostAnalytics(ctx, req.PostId, req.UserId, from, to)
+	if err != nil {
+		return nil, mapAnalyticsError(err)
+	}
+	return &postv1.GetPostAnalyticsResponse{Analytics: mapPostAnalyticsToProto(stats)}, nil
+}
+
+// --- HELPERS ---
+
+func mapPostAnalyticsToProto(a *domain.PostAnalytics) *postv1.PostAnalytics {
+	buckets := make([]*postv1.AnalyticsBucket, len(a.Buckets))
+	for i, b := range a.Buckets {
+		buckets[i] = &postv1.AnalyticsBucket{
+			Start:         timestamppb.New(b.Start),
+			Impressions:   int64(b.Impressions),
+			Views:         int64(b.Views),
+			UniqueViewers: int64(b.UniqueViewers),
+			Reactions:     int32(b.Reactions),
+			Comments:      int32(b.Comments),
+		}
+	}
+
+	return &postv1.PostAnalytics{
+		PostId:        a.PostID,
+		Impressions:   int64(a.Impressions),
+		Views:         int64(a.Views),
+		UniqueViewers: int64(a.UniqueViewers),
+		Reactions:     int32(a.Reactions),
+		Comments:      int32(a.Comments),
+		From:          timestamppb.New(a.From),
+		To:            timestamppb.New(a.To),
+		Buckets:       buckets,
+	}
+}
+
+func mapAnalyticsError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPostEvent), errors.Is(err, domain.ErrTooManyPostEvents),
+		errors.Is(err, domain.ErrInvalidAnalyticsRange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return mapPostError("analytics", err)
+	}
+}
@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- SIGNETS ---
+
+func (s *Server) Bookmark(ctx context.Context, req *postv1.BookmarkRequest) (*postv1.BookmarkResponse, error) {
+	if req.UserId == "" || req.PostId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and post_id are required")
+	}
+
+	bookmark, err := s.bookmarks.Bookmark(ctx, req.UserId, req.PostId, req.CollectionId)
+	if err != nil {
+		return nil, mapBookmarkError(err)
+	}
+	return &postv1.BookmarkResponse{Bookmark: mapBookmarkToProto(bookmark)}, nil
+}
+
+func (s *Server) Unbookmark(ctx context.Context, req *postv1.UnbookmarkRequest) (*emptypb.Empty, error) {
+	if req.UserId == "" || req.PostId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and post_id are required")
+	}
+
+	if err := s.bookmarks.Unbookmark(ctx, req.UserId, req.PostId); err != nil {
+		return nil, mapBookmarkError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListBookmarks(ctx context.Context, req *postv1.ListBookmarksRequest) (*postv1.ListBookmarksResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	bookmarks, nextCursor, err := s.bookmarks.ListBookmarks(ctx, req.UserId, req.CollectionId, req.ShowSensitiveMedia, limit, req.PageToken)
+	if err != nil {
+		return nil, mapBookmarkError(err)
+	}
+
+	protoBookmarks := make([]*postv1.Bookmark, len(bookmarks))
+	for i, b := range bookmarks {
+		protoBookmarks[i] = mapBookmarkToProto(b)
+	}
+	return &postv1.ListBookmarksResponse{Bookmarks: protoBookmarks, NextPageToken: nextCursor}, nil
+}
+
+// --- COLLECTIONS ---
+
+func (s *Server) CreateBookmarkCollection(ctx context.Context, req *postv1.CreateBookmarkCollectionRequest) (*postv1.CreateBookmarkCollectionResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	c, err := s.bookmarks.CreateCollection(ctx, req.UserId, req.Name)
+	if err != nil {
+		return nil, mapBookmarkError(err)
+	}
+	return &postv1.CreateBookmarkCollectionResponse{Collection: mapCollectionToProto(c)}, nil
+}
+
+func (s *Server) RenameBookmarkCollection(ctx context.Context, req *postv1.RenameBookmarkCollectionRequest) (*postv1.RenameBookmarkCollectionResponse, error) {
+	if req.UserId == "" || req.CollectionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and collection_id are required")
+	}
+
+	c, err := s.bookmarks.RenameCollection(ctx, req.UserId, req.CollectionId, req.Name)
+	if err != nil {
+		return nil, mapBookmarkError(err)
+	}
+	return &postv1.RenameBookmarkCollectionResponse{Collection: mapCollectionToProto(c)}, nil
+}
+
+func (s *Server) ReorderBookmarkCollections(ctx context.Context, req *postv1.ReorderBookmarkCollectionsRequest) (*postv1.ReorderBookmarkCollectionsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	collections, err := s.bookmarks.ReorderCollections(ctx, req.UserId, req.CollectionIds)
+	if err != nil {
+		return nil, mapBookmarkError(err)
+	}
+	return &postv1.ReorderBookmarkCollectionsResponse{Collections: mapCollectionsToProto(collections)}, nil
+}
+
+func (s *Server) DeleteBookmarkCollection(ctx context.Context, req *postv1.DeleteBookmarkCollectionRequest) (*emptypb.Empty, error) {
+	if req.UserId == "" || req.CollectionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and collection_id are required")
+	}
+
+	if err := s.bookmarks.DeleteCollection(ctx, req.UserId, req.CollectionId); err != nil {
+		return nil, mapBookmarkError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListBookmarkCollections(ctx context.Context, req *postv1.ListBookmarkCollectionsRequest) (*postv1.ListBookmarkCollectionsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	collections, err := s.bookmarks.ListCollections(ctx, req.UserId)
+	if err != nil {
+		return nil, mapBookmarkError(err)
+	}
+	return &postv1.ListBookmarkCollectionsResponse{Collections: mapCollectionsToProto(collections)}, nil
+}
+
+// --- HELPERS ---
+
+func mapBookmarkToProto(b *domain.Bookmark) *postv1.Bookmark {
+	return &postv1.Bookmark{
+		Post:         mapDomainToProto(b.Post),
+		CollectionId: b.CollectionID,
+		CreatedAt:    timestamppb.New(b.CreatedAt),
+		Unavailable:  b.Unavailable,
+	}
+}
+
+func mapCollectionToProto(c *domain.Collection) *postv1.BookmarkCollection {
+	return &postv1.BookmarkCollection{
+		Id:             c.ID,
+		Name:           c.Name,
+		Position:       int32(c.Position),
+		BookmarksCount: int32(c.BookmarksCount),
+		CreatedAt:      timestamppb.New(c.CreatedAt),
+		UpdatedAt:      timestamppb.New(c.UpdatedAt),
+	}
+}
+
+func mapCollectionsToProto(collections []*domain.Collection) []*postv1.BookmarkCollection {
+	out := make([]*postv1.BookmarkCollection, len(collections))
+	for i, c := range collections {
+		out[i] = mapCollectionToProto(c)
+	}
+	return out
+}
+
+// mapBookmarkError : erreurs des collections, le reste comme les posts
+func mapBookmarkError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrCollectionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidCollectionName), errors.Is(err, domain.ErrInvalidCollectionOrder):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrCollectionNameTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrTooManyCollections):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return mapPostError("bookmark", err)
+	}
+}
@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- COMMENTAIRES ---
+
+func (s *Server) CreateComment(ctx context.Context, req *postv1.CreateCommentRequest) (*postv1.CreateCommentResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	comment, err := s.comments.CreateComment(ctx, req.PostId, req.ParentId, req.UserId, req.Content)
+	if err != nil {
+		return nil, mapCommentError(err)
+	}
+
+	return &postv1.CreateCommentResponse{Comment: mapCommentToProto(comment)}, nil
+}
+
+func (s *Server) EditComment(ctx context.Context, req *postv1.EditCommentRequest) (*postv1.EditCommentResponse, error) {
+	if req.CommentId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "comment_id and user_id are required")
+	}
+
+	comment, err := s.comments.EditComment(ctx, req.CommentId, req.UserId, req.Content)
+	if err != nil {
+		return nil, mapCommentError(err)
+	}
+
+	return &postv1.EditCommentResponse{Comment: mapCommentToProto(comment)}, nil
+}
+
+func (s *Server) DeleteComment(ctx context.Context, req *postv1.DeleteCommentRequest) (*emptypb.Empty, error) {
+	if req.CommentId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "comment_id and user_id are required")
+	}
+
+	if err := s.comments.DeleteComment(ctx, req.CommentId, req.UserId); err != nil {
+		return nil, mapCommentError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListComments(ctx context.Context, req *postv1.ListCommentsRequest) (*postv1.ListCommentsResponse, error) {
+	if req.PostId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	comments, nextCursor, err := s.comments.ListComments(ctx, req.PostId, req.ParentId, req.ViewerId, limit, req.PageToken)
+	if err != nil {
+		return nil, mapCommentError(err)
+	}
+
+	protoComments := make([]*postv1.Comment, len(comments))
+	for i, c := range comments {
+		protoComments[i] = mapCommentToProto(c)
+	}
+
+	return &postv1.ListCommentsResponse{
+		Comments:      protoComments,
+		NextPageToken: nextCursor,
+	}, nil
+}
+
+// --- HELPERS ---
+
+func mapCommentToProto(c *domain.Comment) *postv1.Comment {
+	if c == nil {
+		return nil
+	}
+
+	replies := make([]*postv1.Comment, len(c.Replies))
+	for i, r := range c.Replies {
+		replies[i] = mapCommentToProto(r)
+	}
+
+	return &postv1.Comment{
+		Id:           c.ID,
+		PostId:       c.PostID,
+		ParentId:     c.ParentID,
+		AuthorId:     c.UserID,
+		Content:      c.Content,
+		RepliesCount: int32(c.RepliesCount),
+		Replies:      replies,
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+		UpdatedAt:    timestamppb.New(c.UpdatedAt),
+	}
+}
+
+// mapCommentError traduit les erreurs métier en codes gRPC
+func mapCommentError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrCommentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrCommentForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrEmptyComment),
+		errors.Is(err, domain.ErrCommentTooLong),
+		errors.Is(err, domain.ErrInvalidParent),
+		errors.Is(err, domain.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		slog.Error("Comment operation failed", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- BROUILLONS & PUBLICATION PROGRAMMÉE ---
+
+func (s *Server) SaveDraft(ctx context.Context, req *postv1.SaveDraftRequest) (*postv1.SaveDraftResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	post, err := s.service.SaveDraft(ctx, req.DraftId, req.UserId, req.Content, req.ContentWarning, mapProtoMediaToDomain(req.Media), domain.Visibility(req.Visibility))
+	if err != nil {
+		return nil, mapDraftError(err)
+	}
+
+	return &postv1.SaveDraftResponse{Post: mapDomainToProto(post)}, nil
+}
+
+func (s *Server) ListDrafts(ctx context.Context, req *postv1.ListDraftsRequest) (*postv1.ListDraftsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	drafts, nextCursor, err := s.service.ListDrafts(ctx, req.UserId, limit, req.PageToken)
+	if err != nil {
+		return nil, mapDraftError(err)
+	}
+
+	protoPosts := make([]*postv1.Post, len(drafts))
+	for i, p := range drafts {
+		protoPosts[i] = mapDomainToProto(p)
+	}
+
+	return &postv1.ListDraftsResponse{
+		Posts:         protoPosts,
+		NextPageToken: nextCursor,
+	}, nil
+}
+
+func (s *Server) SchedulePost(ctx context.Context, req *postv1.SchedulePostRequest) (*postv1.SchedulePostResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	var publishAt time.Time
+	if req.PublishAt != nil {
+		publishAt = req.PublishAt.AsTime()
+	}
+
+	post, err := s.service.SchedulePost(ctx, req.PostId, req.UserId, publishAt)
+	if err != nil {
+		return nil, mapDraftError(err)
+	}
+
+	return &postv1.SchedulePostResponse{Post: mapDomainToProto(post)}, nil
+}
+
+func (s *Server) CancelScheduledPost(ctx context.Context, req *postv1.CancelScheduledPostRequest) (*postv1.CancelScheduledPostResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	post, err := s.service.CancelScheduledPost(ctx, req.PostId, req.UserId)
+	if err != nil {
+		return nil, mapDraftError(err)
+	}
+
+	return &postv1.CancelScheduledPostResponse{Post: mapDomainToProto(post)}, nil
+}
+
+// mapDraftError traduit les erreurs propres aux brouillons, puis celles des posts (cf. mapPostError)
+func mapDraftError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPublishAt):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrNotADraft), errors.Is(err, domain.ErrNotScheduled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return mapPostError("draft", err)
+	}
+}
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// mapPostError traduit les erreurs métier des posts en codes gRPC.
+// Le message des erreurs métier est transmis tel quel (la Gateway le présente au client) ;
+// une erreur inattendue est journalisée ici et masquée derrière Internal.
+func mapPostError(op string, err error) error {
+	if st := mapIdempotencyError(err); st != nil {
+		return st
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrPostForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrEmptyPost), errors.Is(err, domain.ErrPostTooLong),
+		errors.Is(err, domain.ErrTooManyMedia), errors.Is(err, domain.ErrInvalidMedia),
+		errors.Is(err, domain.ErrInvalidMediaType), errors.Is(err, domain.ErrInvalidVisibility),
+		errors.Is(err, domain.ErrInvalidPoll), errors.Is(err, domain.ErrInvalidPollExpiry),
+		errors.Is(err, domain.ErrContentRejected), errors.Is(err, domain.ErrInvalidPageToken),
+		errors.Is(err, domain.ErrContentWarningTooLong), errors.Is(err, domain.ErrInvalidBlurhash),
+		errors.Is(err, domain.ErrThreadTooShort), errors.Is(err, domain.ErrThreadTooLong):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrEditWindowExpired), errors.Is(err, domain.ErrPostHeld),
+		errors.Is(err, domain.ErrCannotPin), errors.Is(err, domain.ErrTooManyPinnedPosts),
+		errors.Is(err, domain.ErrRestoreWindowExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		slog.Error("Post operation failed", "op", op, "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
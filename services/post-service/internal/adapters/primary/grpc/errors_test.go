@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+func TestMapPostError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{domain.ErrPostNotFound, codes.NotFound},
+		{domain.ErrPostForbidden, codes.PermissionDenied},
+		{domain.ErrEmptyPost, codes.InvalidArgument},
+		{domain.ErrPostTooLong, codes.InvalidArgument},
+		{domain.ErrTooManyMedia, codes.InvalidArgument},
+		{domain.ErrInvalidMediaType, codes.InvalidArgument},
+		{domain.ErrContentRejected, codes.InvalidArgument},
+		{domain.ErrThreadTooLong, codes.InvalidArgument},
+		{domain.ErrEditWindowExpired, codes.FailedPrecondition},
+		{domain.ErrPostHeld, codes.FailedPrecondition},
+		{domain.ErrTooManyPinnedPosts, codes.FailedPrecondition},
+		{domain.ErrIdempotencyKeyReused, codes.InvalidArgument},
+		{domain.ErrRequestInProgress, codes.Aborted},
+		// Erreur métier enveloppée : errors.Is, pas une comparaison directe
+		{fmt.Errorf("update: %w", domain.ErrPostNotFound), codes.NotFound},
+		{errors.New("connexion refusée"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			st, ok := status.FromError(mapPostError("test", tt.err))
+			if !ok {
+				t.Fatal("mapPostError doit renvoyer un statut gRPC")
+			}
+			if st.Code() != tt.want {
+				t.Errorf("code = %v, want %v", st.Code(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMapPostErrorHidesInternalDetails(t *testing.T) {
+	st, _ := status.FromError(mapPostError("test", errors.New("pq: password authentication failed")))
+	if st.Message() != "internal error" {
+		t.Errorf("message = %q : une erreur inattendue ne doit pas fuiter vers le client", st.Message())
+	}
+}
@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+)
+
+// Bornes d'une page d'export (appel interne : pages plus grandes que pour l'UI)
+const (
+	defaultExportPageSize = 200
+	maxExportPageSize     = 500
+)
+
+// ExportUserPosts : appelé par l'Identity Service pour l'export des données personnelles
+func (s *Server) ExportUserPosts(ctx context.Context, req *postv1.ExportUserPostsRequest) (*postv1.ExportUserPostsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultExportPageSize
+	}
+	limit = min(limit, maxExportPageSize)
+
+	posts, next, err := s.service.ExportUserPosts(ctx, req.UserId, limit, req.PageToken)
+	if err != nil {
+		return nil, mapPostError("export", err)
+	}
+
+	protoPosts := make([]*postv1.Post, len(posts))
+	for i, p := range posts {
+		protoPosts[i] = mapDomainToProto(p)
+	}
+	return &postv1.ExportUserPostsResponse{Posts: protoPosts, NextPageToken: next}, nil
+}
@@ -0,0 +1,22 @@
+package grpc
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// mapIdempotencyError traduit les erreurs de clé d'idempotence (nil pour toute autre erreur).
+// ABORTED : le premier essai est encore en cours, le client peut réessayer un peu plus tard.
+func mapIdempotencyError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidIdempotencyKey), errors.Is(err, domain.ErrIdempotencyKeyReused):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrRequestInProgress):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- MODÉRATION ---
+
+func (s *Server) ReportContent(ctx context.Context, req *postv1.ReportContentRequest) (*postv1.ReportContentResponse, error) {
+	if req.ReporterId == "" || req.TargetId == "" {
+		return nil, status.Error(codes.InvalidArgument, "reporter_id and target_id are required")
+	}
+	targetType := domain.ReportTarget(req.TargetType)
+	if !targetType.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "target_type must be post, comment or user")
+	}
+
+	report, err := s.moderation.ReportContent(ctx, req.ReporterId, targetType, req.TargetId, domain.ReportReason(req.Reason), req.Details)
+	if err != nil {
+		return nil, mapModerationError(err)
+	}
+
+	return &postv1.ReportContentResponse{Report: mapReportToProto(report)}, nil
+}
+
+func (s *Server) ListModerationCases(ctx context.Context, req *postv1.ListModerationCasesRequest) (*postv1.ListModerationCasesResponse, error) {
+	caseStatus := domain.CaseStatus(req.Status)
+	switch caseStatus {
+	case "", domain.CaseOpen, domain.CaseClaimed, domain.CaseResolved:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "status must be open, claimed or resolved")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	cases, nextCursor, err := s.moderation.ListCases(ctx, caseStatus, limit, req.PageToken)
+	if err != nil {
+		return nil, mapModerationError(err)
+	}
+
+	protoCases := make([]*postv1.ModerationCase, len(cases))
+	for i, c := range cases {
+		protoCases[i] = mapModerationCaseToProto(c)
+	}
+	return &postv1.ListModerationCasesResponse{Cases: protoCases, NextPageToken: nextCursor}, nil
+}
+
+func (s *Server) GetModerationCase(ctx context.Context, req *postv1.GetModerationCaseRequest) (*postv1.GetModerationCaseResponse, error) {
+	if req.CaseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id is required")
+	}
+
+	c, err := s.moderation.GetCase(ctx, req.CaseId)
+	if err != nil {
+		return nil, mapModerationError(err)
+	}
+	return &postv1.GetModerationCaseResponse{Case: mapModerationCaseToProto(c)}, nil
+}
+
+func (s *Server) ClaimModerationCase(ctx context.Context, req *postv1.ClaimModerationCaseRequest) (*postv1.ClaimModerationCaseResponse, error) {
+	if req.CaseId == "" || req.ModeratorId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id and moderator_id are required")
+	}
+
+	c, err := s.moderation.ClaimCase(ctx, req.CaseId, req.ModeratorId)
+	if err != nil {
+		return nil, mapModerationError(err)
+	}
+	return &postv1.ClaimModerationCaseResponse{Case: mapModerationCaseToProto(c)}, nil
+}
+
+func (s *Server) ResolveModerationCase(ctx context.Context, req *postv1.ResolveModerationCaseRequest) (*postv1.ResolveModerationCaseResponse, error) {
+	if req.CaseId == "" || req.ModeratorId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case_id and moderator_id are required")
+	}
+
+	res := domain.Resolution{
+		Action:         domain.ModerationAction(req.Action),
+		Note:           req.Note,
+		ContentWarning: req.ContentWarning,
+	}
+	if req.SuspendUntil != nil {
+		res.SuspendUntil = req.SuspendUntil.AsTime()
+	}
+
+	c, err := s.moderation.ResolveCase(ctx, req.CaseId, req.ModeratorId, res)
+	if err != nil {
+		return nil, mapModerationError(err)
+	}
+	return &postv1.ResolveModerationCaseResponse{Case: mapModerationCaseToProto(c)}, nil
+}
+
+// --- HELPERS ---
+
+func mapReportToProto(r *domain.Report) *postv1.Report {
+	return &postv1.Report{
+		Id:         r.ID,
+		CaseId:     r.CaseID,
+		ReporterId: r.ReporterID,
+		Reason:     string(r.Reason),
+		Details:    r.Details,
+		CreatedAt:  timestamppb.New(r.CreatedAt),
+	}
+}
+
+func mapModerationCaseToProto(c *domain.ModerationCase) *postv1.ModerationCase {
+	reasons := make(map[string]int32, len(c.ReasonCounts))
+	for reason, n := range c.ReasonCounts {
+		reasons[string(reason)] = int32(n)
+	}
+	reports := make([]*postv1.Report, len(c.Reports))
+	for i, r := range c.Reports {
+		reports[i] = mapReportToProto(r)
+	}
+	history := make([]*postv1.ModerationDecision, len(c.History))
+	for i, d := range c.History {
+		history[i] = &postv1.ModerationDecision{
+			Id:             d.ID,
+			CaseId:         d.CaseID,
+			ModeratorId:    d.ModeratorID,
+			Action:         string(d.Action),
+			Note:           d.Note,
+			SuspendedUntil: optionalTimestamp(d.SuspendedUntil),
+			ContentWarning: d.ContentWarning,
+			CreatedAt:      timestamppb.New(d.CreatedAt),
+		}
+	}
+
+	return &postv1.ModerationCase{
+		Id:              c.ID,
+		TargetType:      string(c.TargetType),
+		TargetId:        c.TargetID,
+		TargetAuthorId:  c.TargetAuthorID,
+		Status:          string(c.Status),
+		ReportsCount:    int32(c.ReportsCount),
+		ReasonCounts:    reasons,
+		ClaimedBy:       c.ClaimedBy,
+		ClaimedAt:       optionalTimestamp(c.ClaimedAt),
+		Resolution:      string(c.Resolution),
+		ResolvedBy:      c.ResolvedBy,
+		ResolvedAt:      optionalTimestamp(c.ResolvedAt),
+		FirstReportedAt: timestamppb.New(c.FirstReportedAt),
+		LastReportedAt:  timestamppb.New(c.LastReportedAt),
+		Reports:         reports,
+		History:         history,
+	}
+}
+
+// optionalTimestamp : date zéro = champ absent
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// mapModerationError traduit les erreurs métier en codes gRPC
+func mapModerationError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrCommentNotFound),
+		errors.Is(err, domain.ErrCaseNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidReport), errors.Is(err, domain.ErrInvalidReportReason),
+		errors.Is(err, domain.ErrReportTooLong), errors.Is(err, domain.ErrCannotReportSelf),
+		errors.Is(err, domain.ErrInvalidModerationAction), errors.Is(err, domain.ErrInvalidPageToken),
+		errors.Is(err, domain.ErrContentWarningTooLong):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrAlreadyReported):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrCaseAlreadyClaimed), errors.Is(err, domain.ErrCaseNotClaimed),
+		errors.Is(err, domain.ErrCaseResolved):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		slog.Error("Moderation operation failed", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
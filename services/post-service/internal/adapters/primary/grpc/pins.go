@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+)
+
+// --- ÉPINGLAGE ---
+
+func (s *Server) PinPost(ctx context.Context, req *postv1.PinPostRequest) (*postv1.PinPostResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	post, err := s.service.PinPost(ctx, req.PostId, req.UserId)
+	if err != nil {
+		return nil, mapPostError("pin", err)
+	}
+	return &postv1.PinPostResponse{Post: mapDomainToProto(post)}, nil
+}
+
+func (s *Server) UnpinPost(ctx context.Context, req *postv1.UnpinPostRequest) (*postv1.UnpinPostResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	post, err := s.service.UnpinPost(ctx, req.PostId, req.UserId)
+	if err != nil {
+		return nil, mapPostError("unpin", err)
+	}
+	return &postv1.UnpinPostResponse{Post: mapDomainToProto(post)}, nil
+}
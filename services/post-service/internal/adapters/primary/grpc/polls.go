@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- SONDAGES ---
+
+func (s *Server) VotePoll(ctx context.Context, req *postv1.VotePollRequest) (*postv1.VotePollResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	choices := make([]int, len(req.Choices))
+	for i, c := range req.Choices {
+		choices[i] = int(c)
+	}
+
+	poll, err := s.service.VotePoll(ctx, req.PostId, req.UserId, choices)
+	if err != nil {
+		return nil, mapPollError(err)
+	}
+
+	return &postv1.VotePollResponse{Poll: mapPollToProto(poll)}, nil
+}
+
+func (s *Server) GetPollResults(ctx context.Context, req *postv1.GetPollResultsRequest) (*postv1.GetPollResultsResponse, error) {
+	if req.PostId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+	}
+
+	poll, err := s.service.GetPollResults(ctx, req.PostId, req.ViewerId)
+	if err != nil {
+		return nil, mapPollError(err)
+	}
+
+	return &postv1.GetPollResultsResponse{Poll: mapPollToProto(poll)}, nil
+}
+
+// --- HELPERS ---
+
+func mapProtoPollInputToDomain(in *postv1.PollInput) *domain.PollInput {
+	if in == nil {
+		return nil
+	}
+
+	var closesAt time.Time
+	if in.ClosesAt != nil {
+		closesAt = in.ClosesAt.AsTime()
+	}
+	return &domain.PollInput{
+		Options:        in.Options,
+		MultipleChoice: in.MultipleChoice,
+		ClosesAt:       closesAt,
+	}
+}
+
+func mapPollToProto(p *domain.Poll) *postv1.Poll {
+	if p == nil {
+		return nil
+	}
+
+	options := make([]*postv1.PollOption, len(p.Options))
+	for i, o := range p.Options {
+		options[i] = &postv1.PollOption{Label: o.Label, VotesCount: int32(o.VotesCount)}
+	}
+	choices := make([]int32, len(p.ViewerChoices))
+	for i, c := range p.ViewerChoices {
+		choices[i] = int32(c)
+	}
+
+	return &postv1.Poll{
+		Options:        options,
+		MultipleChoice: p.MultipleChoice,
+		ClosesAt:       timestamppb.New(p.ClosesAt),
+		Closed:         p.IsClosedAt(time.Now()),
+		VotersCount:    int32(p.VotersCount),
+		ResultsVisible: p.ResultsVisible,
+		ViewerChoices:  choices,
+	}
+}
+
+// mapPollError traduit les erreurs métier en codes gRPC
+func mapPollError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound), errors.Is(err, domain.ErrPollNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrPollClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrAlreadyVoted):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrInvalidPollChoice):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		slog.Error("Poll operation failed", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
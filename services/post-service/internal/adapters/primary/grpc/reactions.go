@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- RÉACTIONS ---
+
+func (s *Server) React(ctx context.Context, req *postv1.ReactRequest) (*postv1.ReactResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	kind := domain.ReactionKind(req.Kind)
+	if kind == "" {
+		kind = domain.ReactionLike // Un simple "like" par défaut
+	}
+
+	summary, err := s.reactions.React(ctx, req.PostId, req.UserId, kind)
+	if err != nil {
+		return nil, mapReactionError(err)
+	}
+
+	return &postv1.ReactResponse{
+		Reaction:       string(summary.Current),
+		ReactionCounts: mapReactionCountsToProto(summary.Counts),
+	}, nil
+}
+
+func (s *Server) Unreact(ctx context.Context, req *postv1.UnreactRequest) (*postv1.UnreactResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	summary, err := s.reactions.Unreact(ctx, req.PostId, req.UserId)
+	if err != nil {
+		return nil, mapReactionError(err)
+	}
+
+	return &postv1.UnreactResponse{ReactionCounts: mapReactionCountsToProto(summary.Counts)}, nil
+}
+
+// --- HELPERS ---
+
+func mapReactionCountsToProto(counts map[domain.ReactionKind]int) map[string]int32 {
+	out := make(map[string]int32, len(counts))
+	for k, v := range counts {
+		out[string(k)] = int32(v)
+	}
+	return out
+}
+
+func mapReactionError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrPostNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidReaction):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		slog.Error("Reaction operation failed", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- REPOSTS & CITATIONS ---
+
+// repost : CreatePost avec reposted_post_id (contenu vide = repost pur, sinon citation)
+func (s *Server) repost(ctx context.Context, req *postv1.CreatePostRequest) (*postv1.CreatePostResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	post, err := s.service.Repost(ctx, req.UserId, req.RepostedPostId, req.Content, mapProtoMediaToDomain(req.Media), req.IdempotencyKey)
+	if err != nil {
+		return nil, mapRepostError(err)
+	}
+
+	return &postv1.CreatePostResponse{Post: mapDomainToProto(post)}, nil
+}
+
+func (s *Server) UndoRepost(ctx context.Context, req *postv1.UndoRepostRequest) (*emptypb.Empty, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	if err := s.service.UndoRepost(ctx, req.PostId, req.UserId); err != nil {
+		return nil, mapRepostError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func mapRepostError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrRepostNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrAlreadyReposted):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrRepostNotAllowed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return mapPostError("repost", err)
+	}
+}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- HISTORIQUE DES ÉDITIONS ---
+
+func (s *Server) ListPostRevisions(ctx context.Context, req *postv1.ListPostRevisionsRequest) (*postv1.ListPostRevisionsResponse, error) {
+	if req.PostId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	revisions, nextCursor, err := s.service.ListPostRevisions(ctx, req.PostId, req.ViewerId, req.ShowSensitiveMedia, limit, req.PageToken)
+	if err != nil {
+		return nil, mapPostError("list revisions", err)
+	}
+
+	protoRevisions := make([]*postv1.PostRevision, len(revisions))
+	for i, r := range revisions {
+		protoRevisions[i] = mapRevisionToProto(r)
+	}
+
+	return &postv1.ListPostRevisionsResponse{
+		Revisions:     protoRevisions,
+		NextPageToken: nextCursor,
+	}, nil
+}
+
+func mapRevisionToProto(r *domain.PostRevision) *postv1.PostRevision {
+	return &postv1.PostRevision{
+		Id:         r.ID,
+		PostId:     r.PostID,
+		Content:    r.Content,
+		Media:      mapMediaToProto(r.Media),
+		CreatedAt:  timestamppb.New(r.CreatedAt),
+		ReplacedAt: timestamppb.New(r.ReplacedAt),
+	}
+}
@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// --- RECHERCHE ---
+
+func (s *Server) SearchPosts(ctx context.Context, req *postv1.SearchPostsRequest) (*postv1.SearchPostsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	query := domain.SearchQuery{
+		Text:      req.Query,
+		AuthorID:  req.AuthorId,
+		MediaType: domain.MediaType(req.MediaType),
+	}
+	if req.Since != nil {
+		query.Since = req.Since.AsTime()
+	}
+	if req.Until != nil {
+		query.Until = req.Until.AsTime()
+	}
+
+	results, nextCursor, err := s.service.SearchPosts(ctx, query, req.ViewerId, req.ShowSensitiveMedia, limit, req.PageToken)
+	if errors.Is(err, domain.ErrEmptySearchQuery) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, mapPostError("search", err)
+	}
+
+	protoResults := make([]*postv1.SearchResult, len(results))
+	for i, r := range results {
+		protoResults[i] = &postv1.SearchResult{
+			Post:    mapDomainToProto(r.Post),
+			Rank:    r.Rank,
+			Snippet: r.Snippet,
+		}
+	}
+
+	return &postv1.SearchPostsResponse{
+		Results:       protoResults,
+		NextPageToken: nextCursor,
+	}, nil
+}
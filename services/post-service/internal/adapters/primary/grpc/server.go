@@ -2,7 +2,6 @@ package grpc
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 
 	"google.golang.org/grpc"
@@ -18,11 +17,16 @@ import (
 
 type Server struct {
 	postv1.UnimplementedPostServiceServer
-	service ports.PostService
+	service    ports.PostService
+	comments   ports.CommentService
+	reactions  ports.ReactionService
+	moderation ports.ModerationService
+	bookmarks  ports.BookmarkService
+	analytics  ports.AnalyticsService
 }
 
-func NewServer(service ports.PostService) *Server {
-	return &Server{service: service}
+func NewServer(service ports.PostService, comments ports.CommentService, reactions ports.ReactionService, moderation ports.ModerationService, bookmarks ports.BookmarkService, analytics ports.AnalyticsService) *Server {
+	return &Server{service: service, comments: comments, reactions: reactions, moderation: moderation, bookmarks: bookmarks, analytics: analytics}
 }
 
 func (s *Server) Register(grpcServer *grpc.Server) {
@@ -32,17 +36,23 @@ func (s *Server) Register(grpcServer *grpc.Server) {
 // --- COMMANDS (Write) ---
 
 func (s *Server) CreatePost(ctx context.Context, req *postv1.CreatePostRequest) (*postv1.CreatePostResponse, error) {
-	if req.UserId == "" || req.Content == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id and content are required")
+	if req.RepostedPostId != "" {
+		if req.Poll != nil {
+			return nil, status.Error(codes.InvalidArgument, "a repost cannot carry a poll")
+		}
+		return s.repost(ctx, req)
+	}
+
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
 	}
 
 	// Mapping Proto -> Domain
 	domainMedia := mapProtoMediaToDomain(req.Media)
 
-	post, err := s.service.CreatePost(ctx, req.UserId, req.Content, domainMedia)
+	post, err := s.service.CreatePost(ctx, req.UserId, req.Content, req.ContentWarning, domainMedia, domain.Visibility(req.Visibility), req.Language, mapProtoPollInputToDomain(req.Poll), req.IdempotencyKey)
 	if err != nil {
-		slog.Error("Failed to create post", "error", err)
-		return nil, status.Error(codes.Internal, "failed to create post")
+		return nil, mapPostError("create", err)
 	}
 
 	return &postv1.CreatePostResponse{
@@ -50,17 +60,44 @@ func (s *Server) CreatePost(ctx context.Context, req *postv1.CreatePostRequest)
 	}, nil
 }
 
+// CreateThread : tout le fil ou rien (un post invalide fait échouer la requête entière)
+func (s *Server) CreateThread(ctx context.Context, req *postv1.CreateThreadRequest) (*postv1.CreateThreadResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	inputs := make([]domain.ThreadPostInput, len(req.Posts))
+	for i, p := range req.Posts {
+		inputs[i] = domain.ThreadPostInput{
+			Content:        p.Content,
+			ContentWarning: p.ContentWarning,
+			Media:          mapProtoMediaToDomain(p.Media),
+			Language:       p.Language,
+		}
+	}
+
+	thread, err := s.service.CreateThread(ctx, req.UserId, inputs, domain.Visibility(req.Visibility), req.IdempotencyKey)
+	if err != nil {
+		return nil, mapPostError("create thread", err)
+	}
+
+	posts := make([]*postv1.Post, len(thread))
+	for i, p := range thread {
+		posts[i] = mapDomainToProto(p)
+	}
+	return &postv1.CreateThreadResponse{Posts: posts}, nil
+}
+
 func (s *Server) UpdatePost(ctx context.Context, req *postv1.UpdatePostRequest) (*postv1.UpdatePostResponse, error) {
-	if req.PostId == "" {
-		return nil, status.Error(codes.InvalidArgument, "post_id required")
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
 	}
 
 	domainMedia := mapProtoMediaToDomain(req.Media)
 
-	post, err := s.service.UpdatePost(ctx, req.PostId, req.UserId, req.Content, domainMedia)
+	post, err := s.service.UpdatePost(ctx, req.PostId, req.UserId, req.Content, req.ContentWarning, domainMedia, req.IdempotencyKey)
 	if err != nil {
-		// Gestion fine des erreurs (si on avait des erreurs typées dans le domain)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, mapPostError("update", err)
 	}
 
 	return &postv1.UpdatePostResponse{
@@ -73,13 +110,9 @@ func (s *Server) DeletePost(ctx context.Context, req *postv1.DeletePostRequest)
 		return nil, status.Error(codes.InvalidArgument, "ids required")
 	}
 
-	err := s.service.DeletePost(ctx, req.PostId, req.UserId)
+	err := s.service.DeletePost(ctx, req.PostId, req.UserId, req.IdempotencyKey)
 	if err != nil {
-		// Exemple de gestion d'erreur d'autorisation
-		if errors.Is(err, errors.New("unauthorized")) { // À remplacer par var sentinel
-			return nil, status.Error(codes.PermissionDenied, "unauthorized")
-		}
-		return nil, status.Error(codes.Internal, "failed to delete")
+		return nil, mapPostError("delete", err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -88,11 +121,23 @@ func (s *Server) DeletePost(ctx context.Context, req *postv1.DeletePostRequest)
 // --- QUERIES (Read) ---
 
 func (s *Server) GetPost(ctx context.Context, req *postv1.GetPostRequest) (*postv1.GetPostResponse, error) {
-	post, err := s.service.GetPost(ctx, req.PostId)
+	post, err := s.service.GetPost(ctx, req.PostId, req.ViewerId, req.ShowSensitiveMedia)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "post not found")
+		return nil, mapPostError("get", err)
 	}
-	return &postv1.GetPostResponse{Post: mapDomainToProto(post)}, nil
+	resp := &postv1.GetPostResponse{Post: mapDomainToProto(post)}
+
+	if req.IncludeThread && post.IsInThread() {
+		thread, err := s.service.GetThread(ctx, post.ThreadID, req.ViewerId, req.ShowSensitiveMedia)
+		if err != nil {
+			return nil, mapPostError("get thread", err)
+		}
+		resp.Thread = make([]*postv1.Post, len(thread))
+		for i, p := range thread {
+			resp.Thread[i] = mapDomainToProto(p)
+		}
+	}
+	return resp, nil
 }
 
 // GetPosts : BATCH FETCH (Pour le Feed Service)
@@ -101,7 +146,7 @@ func (s *Server) GetPosts(ctx context.Context, req *postv1.GetPostsRequest) (*po
 		return &postv1.GetPostsResponse{Posts: []*postv1.Post{}}, nil
 	}
 
-	posts, err := s.service.GetPosts(ctx, req.PostIds)
+	posts, err := s.service.GetPosts(ctx, req.PostIds, req.ViewerId, req.ShowSensitiveMedia)
 	if err != nil {
 		slog.Error("Batch fetch failed", "error", err)
 		return nil, status.Error(codes.Internal, "failed to fetch posts")
@@ -130,9 +175,9 @@ func (s *Server) ListPostsByAuthor(ctx context.Context, req *postv1.ListPostsByA
 	// Ou on le fait ici. Dans l'architecture hexagonale pure, l'adapter (ici) gère le format protocolaire.
 	// Mais pour simplifier l'interface service, passons le string.
 
-	posts, nextCursor, err := s.service.ListPostsByAuthor(ctx, req.AuthorId, limit, req.PageToken)
+	posts, nextCursor, err := s.service.ListPostsByAuthor(ctx, req.AuthorId, req.ViewerId, req.ShowSensitiveMedia, limit, req.PageToken)
 	if err != nil {
-		return nil, err
+		return nil, mapPostError("list by author", err)
 	}
 
 	protoPosts := make([]*postv1.Post, len(posts))
@@ -146,6 +191,36 @@ func (s *Server) ListPostsByAuthor(ctx context.Context, req *postv1.ListPostsByA
 	}, nil
 }
 
+// ListPostsByHashtag : PAGINATION (même contrat que ListPostsByAuthor)
+func (s *Server) ListPostsByHashtag(ctx context.Context, req *postv1.ListPostsByHashtagRequest) (*postv1.ListPostsByHashtagResponse, error) {
+	if req.Hashtag == "" {
+		return nil, status.Error(codes.InvalidArgument, "hashtag is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	posts, nextCursor, err := s.service.ListPostsByHashtag(ctx, req.Hashtag, req.ViewerId, req.ShowSensitiveMedia, limit, req.PageToken)
+	if err != nil {
+		return nil, mapPostError("list by hashtag", err)
+	}
+
+	protoPosts := make([]*postv1.Post, len(posts))
+	for i, p := range posts {
+		protoPosts[i] = mapDomainToProto(p)
+	}
+
+	return &postv1.ListPostsByHashtagResponse{
+		Posts:         protoPosts,
+		NextPageToken: nextCursor,
+	}, nil
+}
+
 // --- HELPERS (Mappers) ---
 
 func mapDomainToProto(p *domain.Post) *postv1.Post {
@@ -153,32 +228,111 @@ func mapDomainToProto(p *domain.Post) *postv1.Post {
 		return nil
 	}
 
-	protoMedia := make([]*postv1.Media, len(p.Media))
-	for i, m := range p.Media {
-		protoMedia[i] = &postv1.Media{
-			Id:   m.ID,
-			Url:  m.URL,
-			Type: string(m.Type),
+	entities := make([]*postv1.Entity, len(p.Entities))
+	for i, e := range p.Entities {
+		entities[i] = &postv1.Entity{
+			Type:   string(e.Type),
+			Text:   e.Text,
+			Start:  int32(e.Start),
+			End:    int32(e.End),
+			UserId: e.UserID,
 		}
 	}
 
+	var viewer *postv1.ViewerState
+	if p.Viewer != nil {
+		viewer = &postv1.ViewerState{Reaction: string(p.Viewer.Reaction), Bookmarked: p.Viewer.Bookmarked}
+	}
+
+	var editedAt *timestamppb.Timestamp
+	if p.IsEdited() {
+		editedAt = timestamppb.New(p.EditedAt)
+	}
+
+	var deletedAt *timestamppb.Timestamp
+	if p.IsDeleted() {
+		deletedAt = timestamppb.New(p.DeletedAt)
+	}
+
+	var hiddenAt *timestamppb.Timestamp
+	if p.IsHidden() {
+		hiddenAt = timestamppb.New(p.HiddenAt)
+	}
+
+	var publishAt *timestamppb.Timestamp
+	if !p.PublishAt.IsZero() {
+		publishAt = timestamppb.New(p.PublishAt)
+	}
+
 	return &postv1.Post{
 		Id:        p.ID,
 		AuthorId:  p.UserID,
 		Content:   p.Content,
-		Media:     protoMedia, // On renvoie l'objet riche (ID+URL+Type)
+		Media:     mapMediaToProto(p.Media), // On renvoie l'objet riche (ID+URL+Type)
 		CreatedAt: timestamppb.New(p.CreatedAt),
 		UpdatedAt: timestamppb.New(p.UpdatedAt),
+
+		ContentWarning: p.ContentWarning,
+		ThreadId:       p.ThreadID,
+		ThreadPosition: int32(p.ThreadPosition),
+
+		EditedAt:       editedAt,
+		DeletedAt:      deletedAt,
+		HiddenAt:       hiddenAt,
+		Status:         string(p.Status),
+		PublishAt:      publishAt,
+		Language:       p.Language,
+		Visibility:     string(p.Visibility),
+		Entities:       entities,
+		RepostedPostId: p.RepostedPostID,
+		RepostsCount:   int32(p.RepostsCount),
+		CommentsCount:  int32(p.CommentsCount),
+		ReactionCounts: mapReactionCountsToProto(p.ReactionCounts),
+		ViewerState:    viewer,
+		Poll:           mapPollToProto(p.Poll),
+		LinkPreview:    mapLinkPreviewToProto(p.LinkPreview),
+		Pinned:         p.IsPinned(),
+	}
+}
+
+func mapLinkPreviewToProto(l *domain.LinkPreview) *postv1.LinkPreview {
+	if l == nil {
+		return nil
+	}
+	return &postv1.LinkPreview{
+		Url:         l.URL,
+		Title:       l.Title,
+		Description: l.Description,
+		ImageUrl:    l.ImageURL,
+		SiteName:    l.SiteName,
+	}
+}
+
+func mapMediaToProto(media []domain.Media) []*postv1.Media {
+	protoMedia := make([]*postv1.Media, len(media))
+	for i, m := range media {
+		protoMedia[i] = &postv1.Media{
+			Id:        m.ID,
+			Url:       m.URL,
+			Type:      string(m.Type),
+			Sensitive: m.Sensitive,
+			Blurhash:  m.Blurhash,
+			Blurred:   m.Blurred,
+		}
 	}
+	return protoMedia
 }
 
+// mapProtoMediaToDomain : 'blurred' est ignoré, il ne décrit que la réponse faite à un lecteur
 func mapProtoMediaToDomain(protoMedia []*postv1.Media) []domain.Media {
 	domainMedia := make([]domain.Media, len(protoMedia))
 	for i, m := range protoMedia {
 		domainMedia[i] = domain.Media{
-			ID:   m.Id,
-			URL:  m.Url,
-			Type: domain.MediaType(m.Type),
+			ID:        m.Id,
+			URL:       m.Url,
+			Type:      domain.MediaType(m.Type),
+			Sensitive: m.Sensitive,
+			Blurhash:  m.Blurhash,
 		}
 	}
 	return domainMedia
@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	postv1 "github.com/jupiterclapton/cenackle/gen/post/v1"
+)
+
+// --- SUPPRESSION DOUCE ---
+
+func (s *Server) RestorePost(ctx context.Context, req *postv1.RestorePostRequest) (*postv1.RestorePostResponse, error) {
+	if req.PostId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id and user_id are required")
+	}
+
+	post, err := s.service.RestorePost(ctx, req.PostId, req.UserId)
+	if err != nil {
+		return nil, mapPostError("restore", err)
+	}
+
+	return &postv1.RestorePostResponse{Post: mapDomainToProto(post)}, nil
+}
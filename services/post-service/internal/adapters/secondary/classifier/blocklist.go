@@ -0,0 +1,110 @@
+package classifier
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// rule : une ligne de la liste de blocage
+type rule struct {
+	verdict domain.Verdict
+	label   string
+	pattern *regexp.Regexp
+}
+
+// Blocklist : mots-clés et expressions régulières maintenus par l'équipe de modération.
+// Format, une règle par ligne ("#" commente) :
+//
+//	<reject|review> <label> <motif>
+//	reject spam     casino en ligne        -> mot-clé, insensible à la casse, mots entiers
+//	review hate     /(?i)sale\s+\w+/       -> expression régulière entre "/"
+//
+// Le label devient le motif du dossier de modération s'il en est un (spam, hate...), "other" sinon.
+type Blocklist struct {
+	rules []rule
+}
+
+// LoadBlocklist lit la liste depuis un fichier (cf. CLASSIFIER_BLOCKLIST_FILE)
+func LoadBlocklist(path string) (ports.ContentClassifier, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewBlocklist(f)
+}
+
+// NewBlocklist compile toutes les règles : une ligne invalide fait échouer le démarrage
+// plutôt que de laisser passer silencieusement ce qu'elle devait bloquer.
+func NewBlocklist(r io.Reader) (ports.ContentClassifier, error) {
+	b := &Blocklist{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("blocklist line %d: expected '<reject|review> <label> <pattern>'", n)
+		}
+		verdict := domain.Verdict(fields[0])
+		if verdict != domain.VerdictReject && verdict != domain.VerdictReview {
+			return nil, fmt.Errorf("blocklist line %d: unknown verdict %q", n, fields[0])
+		}
+
+		// Le motif est le reste de la ligne (un mot-clé peut contenir des espaces)
+		raw := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(line, fields[0])), fields[1]))
+		pattern, err := compilePattern(raw)
+		if err != nil {
+			return nil, fmt.Errorf("blocklist line %d: %w", n, err)
+		}
+		b.rules = append(b.rules, rule{verdict: verdict, label: fields[1], pattern: pattern})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func compilePattern(raw string) (*regexp.Regexp, error) {
+	if len(raw) > 2 && strings.HasPrefix(raw, "/") && strings.HasSuffix(raw, "/") {
+		return regexp.Compile(raw[1 : len(raw)-1])
+	}
+	// Mot-clé : les espaces du motif tolèrent n'importe quel blanc du texte
+	words := strings.Fields(raw)
+	for i, w := range words {
+		words[i] = regexp.QuoteMeta(w)
+	}
+	// Mots entiers au sens Unicode : \b ne connaît que l'ASCII (« déchet » contiendrait « chet »)
+	return regexp.Compile(`(?i)(?:^|` + nonWordChar + `)` + strings.Join(words, `\s+`) + `(?:$|` + nonWordChar + `)`)
+}
+
+// nonWordChar : ni lettre ni chiffre, quel que soit l'alphabet
+const nonWordChar = `[^\p{L}\p{N}_]`
+
+// Classify : la règle la plus sévère l'emporte, les labels de toutes les règles déclenchées sont rapportés
+func (b *Blocklist) Classify(_ context.Context, post *domain.Post) (domain.Classification, error) {
+	result := domain.Allowed()
+	for _, r := range b.rules {
+		if !r.pattern.MatchString(post.Content) {
+			continue
+		}
+		labels := append(result.Labels, r.label)
+		result = domain.Strictest(result, domain.Classification{
+			Verdict: r.verdict,
+			Reason:  fmt.Sprintf("blocklist: %s rule matched", r.label),
+		})
+		result.Labels = labels
+	}
+	return result, nil
+}
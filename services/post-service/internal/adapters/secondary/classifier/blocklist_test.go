@@ -0,0 +1,87 @@
+package classifier
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+func TestCompilePatternKeywordBoundaries(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyword string
+		text    string
+		want    bool
+	}{
+		{"mot seul", "arnaque", "quelle arnaque !", true},
+		{"insensible à la casse", "arnaque", "ARNAQUE totale", true},
+		{"préfixe d'un mot plus long", "arnaque", "arnaques", false},
+		{"mot accentué", "épée", "range ton épée.", true},
+		{"mot accentué dans un mot plus long", "chet", "quel déchet", false},
+		{"accent en fin de mot-clé", "café", "cafés gratuits", false},
+		{"accent collé au mot-clé", "fric", "fricé", false},
+		{"lettres non latines", "спам", "это спам!", true},
+		{"lettres non latines collées", "спам", "спамер", false},
+		{"chiffre collé", "casino", "casino24", false},
+		{"expression sur plusieurs blancs", "casino en ligne", "Casino\ten   ligne", true},
+		{"début et fin de texte", "casino en ligne", "casino en ligne", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := compilePattern(tt.keyword)
+			if err != nil {
+				t.Fatalf("compilePattern(%q): %v", tt.keyword, err)
+			}
+			if got := re.MatchString(tt.text); got != tt.want {
+				t.Errorf("%q sur %q = %v, want %v", tt.keyword, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompilePatternRegexp(t *testing.T) {
+	re, err := compilePattern(`/(?i)sale\s+\w+/`)
+	if err != nil {
+		t.Fatalf("compilePattern: %v", err)
+	}
+	if !re.MatchString("Sale type") {
+		t.Error("l'expression régulière doit être utilisée telle quelle")
+	}
+}
+
+func TestBlocklistClassify(t *testing.T) {
+	b, err := NewBlocklist(strings.NewReader(`
+# commentaire
+review spam casino en ligne
+reject hate /(?i)\bhaine\b/
+`))
+	if err != nil {
+		t.Fatalf("NewBlocklist: %v", err)
+	}
+
+	got, err := b.Classify(context.Background(), &domain.Post{Content: "Casino en ligne et haine"})
+	if err != nil {
+		t.Fatalf("Classify: %v", err)
+	}
+	if got.Verdict != domain.VerdictReject {
+		t.Errorf("Verdict = %q, want %q (la règle la plus sévère l'emporte)", got.Verdict, domain.VerdictReject)
+	}
+	if len(got.Labels) != 2 {
+		t.Errorf("Labels = %v, want les deux règles", got.Labels)
+	}
+
+	got, _ = b.Classify(context.Background(), &domain.Post{Content: "rien à signaler"})
+	if got.Verdict != domain.Allowed().Verdict {
+		t.Errorf("Verdict = %q, want %q", got.Verdict, domain.Allowed().Verdict)
+	}
+}
+
+func TestNewBlocklistRejectsInvalidLines(t *testing.T) {
+	for _, line := range []string{"reject spam", "block spam casino", "review spam /(/"} {
+		if _, err := NewBlocklist(strings.NewReader(line)); err == nil {
+			t.Errorf("NewBlocklist(%q) devrait échouer", line)
+		}
+	}
+}
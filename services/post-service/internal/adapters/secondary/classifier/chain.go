@@ -0,0 +1,30 @@
+package classifier
+
+import (
+	"context"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// Chain consulte plusieurs classifieurs dans l'ordre (les moins coûteux d'abord) :
+// le verdict le plus sévère l'emporte et un refus dispense de consulter les suivants.
+type Chain []ports.ContentClassifier
+
+func (c Chain) Classify(ctx context.Context, post *domain.Post) (domain.Classification, error) {
+	result := domain.Allowed()
+	for _, classifier := range c {
+		verdict, err := classifier.Classify(ctx, post)
+		if err != nil {
+			if result.Verdict != domain.VerdictAllow {
+				return result, nil // Déjà retenu par un classifieur précédent : la panne ne change rien
+			}
+			return result, err // Arbitrage par le service (fail open / fail closed)
+		}
+		result = domain.Strictest(result, verdict)
+		if result.Verdict == domain.VerdictReject {
+			break
+		}
+	}
+	return result, nil
+}
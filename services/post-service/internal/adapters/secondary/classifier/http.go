@@ -0,0 +1,110 @@
+package classifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+const maxResponseBytes = 64 << 10
+
+type HTTPConfig struct {
+	URL             string        // Endpoint du serveur de modèle (réseau interne)
+	Timeout         time.Duration // Budget par post : CreatePost attend la réponse
+	ReviewThreshold float64       // Score à partir duquel un label retient le post
+	RejectThreshold float64       // Score à partir duquel un label le refuse
+}
+
+// HTTPClassifier interroge un serveur de modèle local.
+//
+//	POST <URL>  {"text": "...", "language": "fr", "media_urls": ["..."]}
+//	200         {"labels": {"spam": 0.12, "hate": 0.93}}
+//
+// Les labels sont libres ; ceux qui correspondent à un motif de signalement (spam, hate...) motivent le dossier.
+type HTTPClassifier struct {
+	client *http.Client
+	cfg    HTTPConfig
+}
+
+func NewHTTPClassifier(cfg HTTPConfig) ports.ContentClassifier {
+	return &HTTPClassifier{
+		client: &http.Client{Timeout: cfg.Timeout},
+		cfg:    cfg,
+	}
+}
+
+type classifyRequest struct {
+	Text      string   `json:"text"`
+	Language  string   `json:"language,omitempty"`
+	MediaURLs []string `json:"media_urls,omitempty"`
+}
+
+type classifyResponse struct {
+	Labels map[string]float64 `json:"labels"`
+}
+
+func (c *HTTPClassifier) Classify(ctx context.Context, post *domain.Post) (domain.Classification, error) {
+	body := classifyRequest{Text: post.Content, Language: post.Language}
+	for _, m := range post.Media {
+		body.MediaURLs = append(body.MediaURLs, m.URL)
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return domain.Classification{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.URL, bytes.NewReader(payload))
+	if err != nil {
+		return domain.Classification{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return domain.Classification{}, fmt.Errorf("classifier request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Classification{}, fmt.Errorf("classifier returned %s", resp.Status)
+	}
+
+	var out classifyResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&out); err != nil {
+		return domain.Classification{}, fmt.Errorf("invalid classifier response: %w", err)
+	}
+	return c.verdict(out.Labels), nil
+}
+
+// verdict : seuils appliqués au score le plus élevé ; seuls les labels au-dessus du seuil de revue sont rapportés
+func (c *HTTPClassifier) verdict(scores map[string]float64) domain.Classification {
+	labels := make([]string, 0, len(scores))
+	for label, score := range scores {
+		if score >= c.cfg.ReviewThreshold {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) == 0 {
+		return domain.Allowed()
+	}
+	sort.Slice(labels, func(i, j int) bool { return scores[labels[i]] > scores[labels[j]] })
+
+	top := labels[0]
+	result := domain.Classification{
+		Verdict: domain.VerdictReview,
+		Labels:  labels,
+		Reason:  fmt.Sprintf("model: %s=%.2f", top, scores[top]),
+	}
+	if scores[top] >= c.cfg.RejectThreshold {
+		result.Verdict = domain.VerdictReject
+	}
+	return result
+}
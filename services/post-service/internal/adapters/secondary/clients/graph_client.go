@@ -0,0 +1,77 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	graphv1 "github.com/jupiterclapton/cenackle/gen/graph/v1"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+type GraphClient struct {
+	client graphv1.GraphServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewGraphClient initialise la connexion gRPC (vérification de la visibilité des posts)
+func NewGraphClient(targetURL string) (*GraphClient, error) {
+	conn, err := grpc.NewClient(targetURL,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphClient{
+		client: graphv1.NewGraphServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+func (c *GraphClient) Close() error {
+	return c.conn.Close()
+}
+
+// CheckRelation : le lecteur (actor) face à l'auteur (target)
+func (c *GraphClient) CheckRelation(ctx context.Context, viewerID, authorID string) (domain.Relation, error) {
+	resp, err := c.client.CheckRelation(ctx, &graphv1.CheckRelationRequest{
+		ActorId:  viewerID,
+		TargetId: authorID,
+	})
+	if err != nil {
+		return domain.Relation{}, fmt.Errorf("graph-service: check relation: %w", err)
+	}
+	return mapRelation(resp), nil
+}
+
+// CheckRelations : un seul appel pour tous les auteurs d'une page
+func (c *GraphClient) CheckRelations(ctx context.Context, viewerID string, authorIDs []string) (map[string]domain.Relation, error) {
+	resp, err := c.client.CheckRelations(ctx, &graphv1.CheckRelationsRequest{
+		ActorId:   viewerID,
+		TargetIds: authorIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("graph-service: check relations: %w", err)
+	}
+
+	relations := make(map[string]domain.Relation, len(resp.Relations))
+	for authorID, rel := range resp.Relations {
+		relations[authorID] = mapRelation(rel)
+	}
+	return relations, nil
+}
+
+func mapRelation(rel *graphv1.CheckRelationResponse) domain.Relation {
+	if rel == nil {
+		return domain.Relation{}
+	}
+	return domain.Relation{
+		IsFollowing:   rel.IsFollowing,
+		IsCloseFriend: rel.IsCloseFriend,
+	}
+}
@@ -0,0 +1,81 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	identityv1 "github.com/jupiterclapton/cenackle/gen/identity/v1"
+)
+
+type IdentityClient struct {
+	client identityv1.IdentityServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewIdentityClient initialise la connexion gRPC (la trace du CreatePost est propagée)
+func NewIdentityClient(targetURL string) (*IdentityClient, error) {
+	conn, err := grpc.NewClient(targetURL,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IdentityClient{
+		client: identityv1.NewIdentityServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+func (c *IdentityClient) Close() error {
+	return c.conn.Close()
+}
+
+// ResolveUsernames : un seul appel pour toutes les @mentions d'un post
+func (c *IdentityClient) ResolveUsernames(ctx context.Context, usernames []string) (map[string]string, error) {
+	resp, err := c.client.ResolveUsernames(ctx, &identityv1.ResolveUsernamesRequest{
+		Usernames: usernames,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("identity-service: resolve usernames: %w", err)
+	}
+	return resp.UserIds, nil
+}
+
+// UserExists : cible d'un signalement de compte
+func (c *IdentityClient) UserExists(ctx context.Context, userID string) (bool, error) {
+	resp, err := c.client.GetUser(ctx, &identityv1.GetUserRequest{UserId: userID})
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("identity-service: get user: %w", err)
+	}
+	return resp.User.GetIsActive(), nil
+}
+
+// SuspendUser : sanction décidée par la modération (until zéro = définitive)
+func (c *IdentityClient) SuspendUser(ctx context.Context, userID, moderatorID, reason string, until time.Time) error {
+	req := &identityv1.SuspendUserRequest{
+		UserId:      userID,
+		ModeratorId: moderatorID,
+		Reason:      reason,
+	}
+	if !until.IsZero() {
+		req.Until = timestamppb.New(until)
+	}
+
+	if _, err := c.client.SuspendUser(ctx, req); err != nil {
+		return fmt.Errorf("identity-service: suspend user: %w", err)
+	}
+	return nil
+}
@@ -2,69 +2,257 @@ package eventbroker
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
+	eventsv1 "github.com/jupiterclapton/cenackle/gen/events/v1"
 	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	StreamName     = "POSTS"
+	SubjectPattern = "post.>" // Tous les events post.*, tous relayés par l'outbox
+	// ModerationStreamName : décisions de modération (moderation.*), relayées elles aussi par l'outbox
+	ModerationStreamName     = "MODERATION"
+	ModerationSubjectPattern = "moderation.>"
+	// StreamDuplicateWindow : un message rejoué par l'OutboxRelay (accusé perdu, relais tombé)
+	// est écarté s'il a déjà été stocké dans cette fenêtre (> domain.OutboxMaxRetryDelay)
+	StreamDuplicateWindow = 30 * time.Minute
+
+	producer     = "post-service"
+	eventVersion = 1 // Version des contrats events.v1 produits ici
 )
 
 type NatsPublisher struct {
-	nc *nats.Conn
+	js jetstream.JetStream
 }
 
-func NewNatsPublisher(nc *nats.Conn) *NatsPublisher {
-	return &NatsPublisher{nc: nc}
-}
+// NewNatsPublisher s'assure que les streams POSTS et MODERATION existent (idempotent)
+func NewNatsPublisher(ctx context.Context, nc *nats.Conn) (*NatsPublisher, error) {
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, fmt.Errorf("jetstream init: %w", err)
+	}
 
-// Structure de l'event (Contract implicite avec Feed-Service)
-type PostCreatedEvent struct {
-	ID        string    `json:"id"`
-	AuthorID  string    `json:"author_id"`
-	Content   string    `json:"content"` // Snippet optionnel
-	Type      string    `json:"type"`    // "post", "video", "image"
-	CreatedAt time.Time `json:"created_at"`
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	streams := map[string]string{StreamName: SubjectPattern, ModerationStreamName: ModerationSubjectPattern}
+	for name, subject := range streams {
+		_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+			Name:       name,
+			Subjects:   []string{subject},
+			Storage:    jetstream.FileStorage,
+			Replicas:   1, // Mettre 3 en cluster
+			Duplicates: StreamDuplicateWindow,
+			MaxAge:     7 * 24 * time.Hour, // Un consommateur arrêté plus longtemps se resynchronise autrement
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create stream %s: %w", name, err)
+		}
+	}
+
+	return &NatsPublisher{js: js}, nil
 }
 
-func (p *NatsPublisher) PublishPostCreated(ctx context.Context, post *domain.Post) error {
+// PostCreatedMessage : chaque appel produit un nouvel ID (une restauration republie le même post)
+func (p *NatsPublisher) PostCreatedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error) {
 	contentType := "post"
 	if len(post.Media) > 0 {
 		contentType = string(post.Media[0].Type) // Simplification : type basé sur le 1er média
 	}
+	if post.IsRepost() {
+		contentType = "repost" // Type distinct : pas de nouveau contenu, juste un partage
+	}
 
-	event := PostCreatedEvent{
-		ID:        post.ID,
-		AuthorID:  post.UserID,
+	env := newEnvelope(ctx, "post.created")
+	env.Payload = &eventsv1.EventEnvelope_PostCreated{PostCreated: &eventsv1.PostCreated{
+		Id:        post.ID,
+		AuthorId:  post.UserID,
 		Content:   post.Content,
 		Type:      contentType,
-		CreatedAt: post.CreatedAt,
+		CreatedAt: timestamppb.New(post.CreatedAt),
+
+		RepostedPostId: post.RepostedPostID,
+
+		Visibility:       string(post.Visibility),
+		MentionedUserIds: post.MentionedUserIDs(),
+
+		Language: post.Language,
+	}}
+	return outboxMessage(ctx, env)
+}
+
+// PublishOutbox : JetStream attend que le stream ait persisté le message (ou reconnu un doublon)
+func (p *NatsPublisher) PublishOutbox(ctx context.Context, m *domain.OutboxMessage) error {
+	msg := &nats.Msg{
+		Subject: m.Subject,
+		Data:    m.Payload,
+		Header:  nats.Header{},
+	}
+	for k, v := range m.Headers {
+		msg.Header[k] = []string{v} // Clés déjà canoniques (HeaderCarrier)
 	}
 
-	data, err := json.Marshal(event)
+	ack, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(m.ID))
 	if err != nil {
-		return fmt.Errorf("marshalling error: %w", err)
+		return fmt.Errorf("jetstream publish: %w", err)
 	}
 
-	// Topic NATS
-	topic := &nats.Msg{
-		Subject: "post.created",
-		Data:    data,
-		Header:  nats.Header{},
+	slog.Info("📢 Event published to stream", "subject", m.Subject, "msg_id", m.ID, "seq", ack.Sequence, "duplicate", ack.Duplicate)
+	return nil
+}
+
+func (p *NatsPublisher) CommentCreatedMessage(ctx context.Context, comment *domain.Comment, postAuthorID string) (*domain.OutboxMessage, error) {
+	env := newEnvelope(ctx, "post.comment_created")
+	env.Payload = &eventsv1.EventEnvelope_CommentCreated{CommentCreated: &eventsv1.CommentCreated{
+		Id:           comment.ID,
+		PostId:       comment.PostID,
+		PostAuthorId: postAuthorID,
+		ParentId:     comment.ParentID,
+		AuthorId:     comment.UserID,
+		CreatedAt:    timestamppb.New(comment.CreatedAt),
+	}}
+	return outboxMessage(ctx, env)
+}
+
+func (p *NatsPublisher) PostUpdatedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error) {
+	env := newEnvelope(ctx, "post.updated")
+	env.Payload = &eventsv1.EventEnvelope_PostUpdated{PostUpdated: &eventsv1.PostUpdated{
+		Id:               post.ID,
+		AuthorId:         post.UserID,
+		Content:          post.Content,
+		Visibility:       string(post.Visibility),
+		Hashtags:         post.Hashtags(),
+		MentionedUserIds: post.MentionedUserIDs(),
+		EditedAt:         timestamppb.New(post.EditedAt),
+	}}
+	return outboxMessage(ctx, env)
+}
+
+func (p *NatsPublisher) PostDeletedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error) {
+	env := newEnvelope(ctx, "post.deleted")
+	env.Payload = &eventsv1.EventEnvelope_PostDeleted{PostDeleted: &eventsv1.PostDeleted{
+		Id:             post.ID,
+		AuthorId:       post.UserID,
+		DeletedAt:      timestamppb.New(post.DeletedAt),
+		RepostedPostId: post.RepostedPostID,
+	}}
+	return outboxMessage(ctx, env)
+}
+
+func (p *NatsPublisher) PollClosedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error) {
+	options := make([]*eventsv1.PollOptionResult, len(post.Poll.Options))
+	for i, o := range post.Poll.Options {
+		options[i] = &eventsv1.PollOptionResult{Label: o.Label, VotesCount: int32(o.VotesCount)}
 	}
-	// 👇 INJECTION DU TRACE ID DANS LES HEADERS NATS
-	// Cela prend le contexte actuel (qui contient le TraceID du gRPC) et le met dans msg.Header
-	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(topic.Header))
 
-	slog.Info("📢 Publishing event with trace context", "topic", topic.Subject, "post_id", post.ID)
+	env := newEnvelope(ctx, "post.poll_closed")
+	env.Payload = &eventsv1.EventEnvelope_PollClosed{PollClosed: &eventsv1.PollClosed{
+		PostId:      post.ID,
+		AuthorId:    post.UserID,
+		VotersCount: int32(post.Poll.VotersCount),
+		Options:     options,
+		ClosedAt:    timestamppb.New(post.Poll.ClosedAt),
+	}}
+	return outboxMessage(ctx, env)
+}
+
+// ModerationDecisionMessage : sujet moderation.<action>
+func (p *NatsPublisher) ModerationDecisionMessage(ctx context.Context, d *domain.ModerationDecision) (*domain.OutboxMessage, error) {
+	event := &eventsv1.ModerationDecision{
+		DecisionId:     d.ID,
+		CaseId:         d.CaseID,
+		TargetType:     string(d.TargetType),
+		TargetId:       d.TargetID,
+		TargetAuthorId: d.TargetAuthorID,
+		ModeratorId:    d.ModeratorID,
+		Action:         string(d.Action),
+		Note:           d.Note,
+		ContentWarning: d.ContentWarning,
+		CreatedAt:      timestamppb.New(d.CreatedAt),
+	}
+	if !d.SuspendedUntil.IsZero() {
+		event.SuspendedUntil = timestamppb.New(d.SuspendedUntil)
+	}
+
+	env := newEnvelope(ctx, "moderation."+string(d.Action))
+	env.Payload = &eventsv1.EventEnvelope_ModerationDecision{ModerationDecision: event}
+	return outboxMessage(ctx, env)
+}
+
+func (p *NatsPublisher) PostReactedMessage(ctx context.Context, reaction *domain.Reaction, summary *domain.ReactionSummary) (*domain.OutboxMessage, error) {
+	env := newEnvelope(ctx, "post.reacted")
+	env.Payload = &eventsv1.EventEnvelope_PostReacted{PostReacted: &eventsv1.PostReacted{
+		PostId:       reaction.PostID,
+		PostAuthorId: summary.PostAuthorID,
+		UserId:       reaction.UserID,
+		Kind:         string(reaction.Kind),
+		PreviousKind: string(summary.Previous),
+		CreatedAt:    timestamppb.New(reaction.CreatedAt),
+	}}
+	return outboxMessage(ctx, env)
+}
+
+func (p *NatsPublisher) UserMentionedMessage(ctx context.Context, post *domain.Post, mentionedUserID string) (*domain.OutboxMessage, error) {
+	env := newEnvelope(ctx, "post.user_mentioned")
+	env.Payload = &eventsv1.EventEnvelope_UserMentioned{UserMentioned: &eventsv1.UserMentioned{
+		PostId:          post.ID,
+		AuthorId:        post.UserID,
+		MentionedUserId: mentionedUserID,
+		CreatedAt:       timestamppb.New(post.UpdatedAt), // Date de la mention (création ou édition)
+	}}
+	return outboxMessage(ctx, env)
+}
+
+// --- Helpers ---
 
-	// On utilise PublishMsg au lieu de Publish
-	return p.nc.PublishMsg(topic)
+// newEnvelope : ID, date et contexte de trace de la requête en cours. L'appelant fixe le payload.
+func newEnvelope(ctx context.Context, eventType string) *eventsv1.EventEnvelope {
+	trace := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, trace)
+
+	return &eventsv1.EventEnvelope{
+		EventId:      uuid.New().String(),
+		EventType:    eventType,
+		Version:      eventVersion,
+		OccurredAt:   timestamppb.Now(),
+		Producer:     producer,
+		TraceContext: trace,
+	}
 }
 
-func (p *NatsPublisher) PublishPostDeleted(ctx context.Context, postID string) error {
-	return p.nc.Publish("post.deleted", []byte(postID))
+// outboxMessage : l'enveloppe sérialisée, prête à être enregistrée avec le changement d'état qui la produit
+func outboxMessage(ctx context.Context, env *eventsv1.EventEnvelope) (*domain.OutboxMessage, error) {
+	data, err := proto.Marshal(env)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling error: %w", err)
+	}
+
+	// 👇 INJECTION DU TRACE ID DANS LES HEADERS NATS (en plus de l'enveloppe)
+	// Cela prend le contexte actuel (qui contient le TraceID du gRPC) et le garde avec le message,
+	// pour que le relais le publie tel quel, même bien après la requête
+	header := nats.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+
+	headers := make(map[string]string, len(header))
+	for k := range header {
+		headers[k] = header.Get(k)
+	}
+
+	return &domain.OutboxMessage{
+		ID:        env.EventId, // Nats-Msg-Id = event_id
+		Subject:   env.EventType,
+		Payload:   data,
+		Headers:   headers,
+		CreatedAt: env.OccurredAt.AsTime(),
+	}, nil
 }
@@ -0,0 +1,40 @@
+package langdetect
+
+import (
+	"strings"
+
+	"github.com/abadojack/whatlanggo"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// Detector : détection hors ligne par trigrammes (profils embarqués dans le binaire, aucun appel réseau).
+// Restreindre les langues candidates à celles de la communauté fiabilise nettement les textes courts.
+type Detector struct {
+	options whatlanggo.Options
+}
+
+// NewDetector : languages = codes ISO 639-1 candidats ("fr", "en"), vide = toutes les langues connues.
+// Les codes inconnus du détecteur sont ignorés.
+func NewDetector(languages []string) ports.LanguageDetector {
+	candidates := make(map[string]bool, len(languages))
+	for _, l := range languages {
+		candidates[strings.ToLower(strings.TrimSpace(l))] = true
+	}
+
+	whitelist := make(map[whatlanggo.Lang]bool)
+	for lang := whatlanggo.Afr; lang <= whatlanggo.Zul; lang++ {
+		if candidates[lang.Iso6391()] {
+			whitelist[lang] = true
+		}
+	}
+	return &Detector{options: whatlanggo.Options{Whitelist: whitelist}}
+}
+
+// Detect : vide si la confiance est insuffisante (l'auteur peut toujours préciser la langue)
+func (d *Detector) Detect(text string) string {
+	info := whatlanggo.DetectWithOptions(text, d.options)
+	if info.Lang < 0 || !info.IsReliable() {
+		return ""
+	}
+	return info.Lang.Iso6391()
+}
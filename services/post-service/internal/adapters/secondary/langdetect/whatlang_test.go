@@ -0,0 +1,31 @@
+package langdetect
+
+import "testing"
+
+const (
+	frenchText  = "Nous partons demain matin pour une longue randonnée dans les montagnes avec toute la famille"
+	englishText = "We are leaving tomorrow morning for a long hike in the mountains with the whole family"
+)
+
+func TestDetector(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages []string
+		text      string
+		want      string
+	}{
+		{"français parmi les candidates", []string{"fr", "en"}, frenchText, "fr"},
+		{"anglais parmi les candidates", []string{"fr", "en"}, englishText, "en"},
+		{"codes normalisés", []string{" FR ", "En"}, frenchText, "fr"},
+		{"codes inconnus ignorés", []string{"fr", "en", "xx"}, englishText, "en"},
+		{"toutes les langues connues", nil, frenchText, "fr"},
+		{"texte sans lettres", []string{"fr", "en"}, "1234 5678 !!!", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDetector(tt.languages).Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
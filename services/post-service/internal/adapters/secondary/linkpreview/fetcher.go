@@ -0,0 +1,255 @@
+package linkpreview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/html/charset"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+const (
+	maxRedirects   = 3
+	maxOEmbedBytes = 64 << 10
+)
+
+type Config struct {
+	Timeout     time.Duration // Budget total par lien (redirections et oEmbed compris)
+	MaxBodySize int64         // Octets lus au plus par page
+	UserAgent   string
+}
+
+// Fetcher : client HTTP durci pour visiter des URLs fournies par les utilisateurs.
+// Protection SSRF : l'adresse est vérifiée au moment du dial, APRÈS la résolution DNS
+// (un nom qui pointe vers 10.0.0.1 ou un rebinding DNS sont bloqués), à chaque redirection.
+type Fetcher struct {
+	client      *http.Client
+	maxBodySize int64
+	userAgent   string
+	timeout     time.Duration
+}
+
+func NewFetcher(cfg Config) ports.LinkFetcher {
+	return newFetcher(cfg, isPublicAddr)
+}
+
+// newFetcher : 'allow' décide des adresses joignables (les tests autorisent le loopback)
+func newFetcher(cfg Config, allow func(netip.AddrPort) bool) *Fetcher {
+	dialer := &net.Dialer{
+		Timeout: cfg.Timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addr, err := netip.ParseAddrPort(address)
+			if err != nil || !allow(addr) {
+				return fmt.Errorf("%w: %s", domain.ErrLinkNotAllowed, address)
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil, // Un proxy contournerait la vérification des adresses
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   cfg.Timeout,
+		ResponseHeaderTimeout: cfg.Timeout,
+		MaxIdleConns:          20,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &Fetcher{
+		client: &http.Client{
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return errors.New("too many redirects")
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return fmt.Errorf("%w: redirect to %s", domain.ErrLinkNotAllowed, req.URL.Scheme)
+				}
+				return nil
+			},
+		},
+		maxBodySize: cfg.MaxBodySize,
+		userAgent:   cfg.UserAgent,
+		timeout:     cfg.Timeout,
+	}
+}
+
+// Fetch lit le <head> de la page (OpenGraph, Twitter card) et complète avec oEmbed si besoin
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*domain.LinkPreview, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return nil, fmt.Errorf("%w: %q", domain.ErrLinkNotAllowed, rawURL)
+	}
+
+	resp, err := f.get(ctx, target.String(), "text/html,application/xhtml+xml;q=0.9,image/*;q=0.5")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	preview := &domain.LinkPreview{URL: rawURL, FetchedAt: time.Now().UTC()}
+	pageURL := resp.Request.URL // Après redirections : base des URLs relatives
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case strings.HasPrefix(mediaType, "image/"):
+		// Lien direct vers une image : elle est son propre aperçu
+		preview.ImageURL = pageURL.String()
+		preview.SiteName = siteName(pageURL)
+		return preview, nil
+	case mediaType != "text/html" && mediaType != "application/xhtml+xml":
+		return nil, fmt.Errorf("%w: content type %q", domain.ErrNoLinkPreview, mediaType)
+	}
+
+	body, err := charset.NewReader(io.LimitReader(resp.Body, f.maxBodySize), resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrNoLinkPreview, err)
+	}
+	meta := parseHead(body)
+
+	preview.Title = firstNonEmpty(meta.og["og:title"], meta.twitter["twitter:title"])
+	preview.Description = firstNonEmpty(meta.og["og:description"], meta.twitter["twitter:description"], meta.description)
+	preview.ImageURL = resolve(pageURL, firstNonEmpty(meta.og["og:image:secure_url"], meta.og["og:image"], meta.twitter["twitter:image"], meta.twitter["twitter:image:src"]))
+	preview.SiteName = meta.og["og:site_name"]
+
+	// oEmbed : utile pour les plateformes (vidéo, musique) qui n'exposent pas tout en OpenGraph
+	if meta.oembedURL != "" && (preview.Title == "" || preview.ImageURL == "" || preview.SiteName == "") {
+		if oembedURL := resolve(pageURL, meta.oembedURL); oembedURL != "" {
+			if oe, err := f.fetchOEmbed(ctx, oembedURL); err == nil {
+				preview.Title = firstNonEmpty(preview.Title, oe.Title)
+				preview.ImageURL = firstNonEmpty(preview.ImageURL, resolve(pageURL, oe.ThumbnailURL))
+				preview.SiteName = firstNonEmpty(preview.SiteName, oe.ProviderName)
+			}
+		}
+	}
+
+	preview.Title = firstNonEmpty(preview.Title, meta.title)
+	preview.SiteName = firstNonEmpty(preview.SiteName, siteName(pageURL))
+	preview.Normalize()
+	if preview.IsEmpty() {
+		return nil, domain.ErrNoLinkPreview
+	}
+	return preview, nil
+}
+
+// oEmbedResponse : sous-ensemble de https://oembed.com (champs communs à tous les types)
+type oEmbedResponse struct {
+	Title        string `json:"title"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+func (f *Fetcher) fetchOEmbed(ctx context.Context, oembedURL string) (*oEmbedResponse, error) {
+	resp, err := f.get(ctx, oembedURL, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var oe oEmbedResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOEmbedBytes)).Decode(&oe); err != nil {
+		return nil, err
+	}
+	return &oe, nil
+}
+
+func (f *Fetcher) get(ctx context.Context, target, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if f.userAgent != "" {
+		req.Header.Set("User-Agent", f.userAgent)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, resp.Request.URL.Host)
+	}
+	return resp, nil
+}
+
+// --- Protection SSRF ---
+
+// Plages non routables sur Internet en plus de celles que netip sait reconnaître
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "Ce réseau"
+	netip.MustParsePrefix("100.64.0.0/10"),  // CGNAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // Affectations IETF
+	netip.MustParsePrefix("198.18.0.0/15"),  // Bancs de test
+	netip.MustParsePrefix("240.0.0.0/4"),    // Réservé (+ broadcast)
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64 : peut cacher une IPv4 privée
+	netip.MustParsePrefix("64:ff9b:1::/48"), // NAT64 local
+	netip.MustParsePrefix("2001:db8::/32"),  // Documentation
+	netip.MustParsePrefix("2002::/16"),      // 6to4 : peut cacher une IPv4 privée
+	netip.MustParsePrefix("fec0::/10"),      // Site-local (obsolète)
+}
+
+// isPublicAddr : adresse publique, ports web standard uniquement (pas de scan de services internes)
+func isPublicAddr(ap netip.AddrPort) bool {
+	if ap.Port() != 80 && ap.Port() != 443 {
+		return false
+	}
+
+	addr := ap.Addr().Unmap() // ::ffff:10.0.0.1 est 10.0.0.1
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, p := range blockedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// --- Helpers ---
+
+func resolve(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
+
+// siteName : "www.example.com" -> "example.com" (dernier recours)
+func siteName(u *url.URL) string {
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,233 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+var testConfig = Config{Timeout: 2 * time.Second, MaxBodySize: 1 << 20, UserAgent: "test"}
+
+// allowLoopback : les serveurs httptest écoutent sur 127.0.0.1
+func allowLoopback(ap netip.AddrPort) bool {
+	return ap.Addr().Unmap().IsLoopback()
+}
+
+func serveHTML(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(body))
+	}
+}
+
+func TestFetchOpenGraph(t *testing.T) {
+	srv := httptest.NewServer(serveHTML(`<!doctype html><html><head>
+		<title>Titre HTML</title>
+		<meta property="og:title" content="  Tarte   tatin ">
+		<meta property="og:description" content="La recette de grand-mère">
+		<meta property="og:image" content="/img/tarte.jpg">
+		<meta property="og:site_name" content="Cuisine &amp; Co">
+		<meta property="og:title" content="Ignoré (doublon)">
+	</head><body><meta property="og:title" content="Ignoré (body)"></body></html>`))
+	defer srv.Close()
+
+	preview, err := newFetcher(testConfig, allowLoopback).Fetch(context.Background(), srv.URL+"/recette")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	want := domain.LinkPreview{
+		URL:         srv.URL + "/recette",
+		Title:       "Tarte tatin",
+		Description: "La recette de grand-mère",
+		ImageURL:    srv.URL + "/img/tarte.jpg",
+		SiteName:    "Cuisine & Co",
+	}
+	preview.FetchedAt = time.Time{}
+	if *preview != want {
+		t.Errorf("preview = %+v, want %+v", *preview, want)
+	}
+}
+
+func TestFetchTwitterCardFallback(t *testing.T) {
+	srv := httptest.NewServer(serveHTML(`<html><head>
+		<title>Titre de la page</title>
+		<meta name="description" content="Description classique">
+		<meta name="twitter:image" content="https://cdn.example.com/card.png">
+	</head></html>`))
+	defer srv.Close()
+
+	preview, err := newFetcher(testConfig, allowLoopback).Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if preview.Title != "Titre de la page" {
+		t.Errorf("Title = %q, want the <title> fallback", preview.Title)
+	}
+	if preview.Description != "Description classique" {
+		t.Errorf("Description = %q", preview.Description)
+	}
+	if preview.ImageURL != "https://cdn.example.com/card.png" {
+		t.Errorf("ImageURL = %q", preview.ImageURL)
+	}
+	if preview.SiteName != "127.0.0.1" {
+		t.Errorf("SiteName = %q, want the hostname fallback", preview.SiteName)
+	}
+}
+
+func TestFetchOEmbed(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/video", serveHTML(`<html><head>
+		<link rel="alternate" type="application/json+oembed" href="/oembed?url=video">
+	</head></html>`))
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"type":"video","title":"Ma vidéo","provider_name":"VideoHub","thumbnail_url":"/thumb.jpg"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	preview, err := newFetcher(testConfig, allowLoopback).Fetch(context.Background(), srv.URL+"/video")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if preview.Title != "Ma vidéo" || preview.SiteName != "VideoHub" || preview.ImageURL != srv.URL+"/thumb.jpg" {
+		t.Errorf("preview = %+v", *preview)
+	}
+}
+
+func TestFetchDirectImage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG"))
+	}))
+	defer srv.Close()
+
+	preview, err := newFetcher(testConfig, allowLoopback).Fetch(context.Background(), srv.URL+"/photo.png")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if preview.ImageURL != srv.URL+"/photo.png" {
+		t.Errorf("ImageURL = %q", preview.ImageURL)
+	}
+}
+
+func TestFetchWithoutMetadata(t *testing.T) {
+	srv := httptest.NewServer(serveHTML(`<html><head></head><body>Rien</body></html>`))
+	defer srv.Close()
+
+	_, err := newFetcher(testConfig, allowLoopback).Fetch(context.Background(), srv.URL)
+	if !errors.Is(err, domain.ErrNoLinkPreview) {
+		t.Errorf("err = %v, want ErrNoLinkPreview", err)
+	}
+}
+
+func TestFetchBodySizeLimit(t *testing.T) {
+	// Les métadonnées arrivent après la limite : elles ne sont pas lues
+	srv := httptest.NewServer(serveHTML(`<html><head><!--` + strings.Repeat("x", 4096) + `-->
+		<meta property="og:title" content="Trop loin"></head></html>`))
+	defer srv.Close()
+
+	cfg := testConfig
+	cfg.MaxBodySize = 1024
+	_, err := newFetcher(cfg, allowLoopback).Fetch(context.Background(), srv.URL)
+	if !errors.Is(err, domain.ErrNoLinkPreview) {
+		t.Errorf("err = %v, want ErrNoLinkPreview", err)
+	}
+}
+
+func TestFetchTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	cfg := testConfig
+	cfg.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	_, err := newFetcher(cfg, allowLoopback).Fetch(context.Background(), srv.URL)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Fetch took %v, the timeout was not enforced", elapsed)
+	}
+}
+
+func TestFetchBlocksPrivateAddresses(t *testing.T) {
+	srv := httptest.NewServer(serveHTML(`<html><head><meta property="og:title" content="Interne"></head></html>`))
+	defer srv.Close()
+
+	// Fetcher de production : le loopback est refusé au moment du dial
+	_, err := NewFetcher(testConfig).Fetch(context.Background(), srv.URL)
+	if !errors.Is(err, domain.ErrLinkNotAllowed) {
+		t.Errorf("err = %v, want ErrLinkNotAllowed", err)
+	}
+}
+
+func TestFetchBlocksRedirectToForbiddenAddress(t *testing.T) {
+	internal := httptest.NewServer(serveHTML(`<html><head><meta property="og:title" content="Secret"></head></html>`))
+	defer internal.Close()
+	public := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusFound))
+	defer public.Close()
+
+	// Seul le serveur "public" est joignable : la redirection est vérifiée comme une connexion directe
+	publicAddr := netip.MustParseAddrPort(strings.TrimPrefix(public.URL, "http://"))
+	allow := func(ap netip.AddrPort) bool { return ap == publicAddr }
+
+	_, err := newFetcher(testConfig, allow).Fetch(context.Background(), public.URL)
+	if !errors.Is(err, domain.ErrLinkNotAllowed) {
+		t.Errorf("err = %v, want ErrLinkNotAllowed", err)
+	}
+}
+
+func TestFetchRejectsNonHTTPSchemes(t *testing.T) {
+	for _, raw := range []string{"file:///etc/passwd", "gopher://example.com", "http://"} {
+		_, err := NewFetcher(testConfig).Fetch(context.Background(), raw)
+		if !errors.Is(err, domain.ErrLinkNotAllowed) {
+			t.Errorf("Fetch(%q) err = %v, want ErrLinkNotAllowed", raw, err)
+		}
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34:443", true},
+		{"93.184.216.34:80", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"93.184.216.34:22", false}, // Port non web
+		{"127.0.0.1:80", false},
+		{"10.1.2.3:443", false},
+		{"172.16.0.1:443", false},
+		{"192.168.1.1:80", false},
+		{"169.254.169.254:80", false}, // Métadonnées cloud
+		{"100.64.0.1:443", false},
+		{"0.0.0.0:80", false},
+		{"[::1]:443", false},
+		{"[fd00::1]:443", false},
+		{"[fe80::1]:443", false},
+		{"[::ffff:10.0.0.1]:443", false}, // IPv4 privée déguisée en IPv6
+		{"[64:ff9b::a00:1]:443", false},  // NAT64 vers 10.0.0.1
+	}
+	for _, tt := range tests {
+		if got := isPublicAddr(netip.MustParseAddrPort(tt.addr)); got != tt.want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
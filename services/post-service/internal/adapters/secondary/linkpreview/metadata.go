@@ -0,0 +1,107 @@
+package linkpreview
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// pageMeta : balises utiles du <head> (la première occurrence de chaque propriété l'emporte)
+type pageMeta struct {
+	og          map[string]string // og:title, og:image...
+	twitter     map[string]string // twitter:title, twitter:image...
+	title       string            // <title>
+	description string            // <meta name="description">
+	oembedURL   string            // <link rel="alternate" type="application/json+oembed">
+}
+
+// parseHead lit le document jusqu'à <body> (les métadonnées sont dans <head>)
+func parseHead(r io.Reader) pageMeta {
+	meta := pageMeta{og: map[string]string{}, twitter: map[string]string{}}
+	z := html.NewTokenizer(r)
+	inTitle := false
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return meta // EOF, limite de taille atteinte ou HTML illisible : on garde ce qu'on a
+
+		case html.TextToken:
+			if inTitle && meta.title == "" {
+				meta.title = string(z.Text())
+			}
+
+		case html.EndTagToken:
+			if name, _ := z.TagName(); atom.Lookup(name) == atom.Title {
+				inTitle = false
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Body:
+				return meta
+			case atom.Title:
+				inTitle = true
+			case atom.Meta:
+				if hasAttr {
+					meta.addMeta(attributes(z))
+				}
+			case atom.Link:
+				if hasAttr {
+					meta.addLink(attributes(z))
+				}
+			}
+		}
+	}
+}
+
+func (m *pageMeta) addMeta(attrs map[string]string) {
+	content := attrs["content"]
+	if content == "" {
+		return
+	}
+
+	// OpenGraph utilise "property", Twitter "name" (mais beaucoup de sites mélangent)
+	key := strings.ToLower(strings.TrimSpace(attrs["property"]))
+	if key == "" {
+		key = strings.ToLower(strings.TrimSpace(attrs["name"]))
+	}
+
+	switch {
+	case strings.HasPrefix(key, "og:"):
+		setOnce(m.og, key, content)
+	case strings.HasPrefix(key, "twitter:"):
+		setOnce(m.twitter, key, content)
+	case key == "description" && m.description == "":
+		m.description = content
+	}
+}
+
+func (m *pageMeta) addLink(attrs map[string]string) {
+	if m.oembedURL != "" || attrs["href"] == "" {
+		return
+	}
+	if strings.EqualFold(attrs["type"], "application/json+oembed") && strings.Contains(strings.ToLower(attrs["rel"]), "alternate") {
+		m.oembedURL = attrs["href"]
+	}
+}
+
+func attributes(z *html.Tokenizer) map[string]string {
+	attrs := map[string]string{}
+	for {
+		key, val, more := z.TagAttr()
+		attrs[strings.ToLower(string(key))] = string(val)
+		if !more {
+			return attrs
+		}
+	}
+}
+
+func setOnce(m map[string]string, key, value string) {
+	if _, ok := m[key]; !ok {
+		m[key] = value
+	}
+}
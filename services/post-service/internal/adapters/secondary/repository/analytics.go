@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+type AnalyticsRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAnalyticsRepo(db *pgxpool.Pool) ports.AnalyticsRepository {
+	return &AnalyticsRepo{db: db}
+}
+
+// ApplyDeltas : deux requêtes pour tout le lot (unnest). Les lignes sont triées pour que deux réplicas
+// qui écrivent les mêmes tranches prennent les verrous dans le même ordre (pas d'interblocage).
+func (r *AnalyticsRepo) ApplyDeltas(ctx context.Context, deltas []domain.AnalyticsDelta) error {
+	sort.Slice(deltas, func(i, j int) bool {
+		if deltas[i].PostID != deltas[j].PostID {
+			return deltas[i].PostID < deltas[j].PostID
+		}
+		return deltas[i].Bucket.Before(deltas[j].Bucket)
+	})
+
+	n := len(deltas)
+	postIDs, buckets := make([]string, n), make([]time.Time, n)
+	impressions, views := make([]int64, n), make([]int64, n)
+	bucketUniques, uniques := make([]int64, n), make([]int64, n)
+	for i, d := range deltas {
+		postIDs[i], buckets[i] = d.PostID, d.Bucket
+		impressions[i], views[i] = int64(d.Impressions), int64(d.Views)
+		bucketUniques[i], uniques[i] = int64(d.BucketUniqueViewers), int64(d.UniqueViewers)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Tranches horaires (une ligne par couple post/heure dans le lot). Le JOIN écarte les posts purgés.
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO post_analytics_hourly (post_id, bucket, impressions, views, unique_viewers)
+		SELECT d.post_id, d.bucket, d.impressions, d.views, d.unique_viewers
+		FROM unnest($1::uuid[], $2::timestamptz[], $3::bigint[], $4::bigint[], $5::bigint[])
+			AS d(post_id, bucket, impressions, views, unique_viewers)
+		JOIN posts p ON p.id = d.post_id
+		ORDER BY d.post_id, d.bucket
+		ON CONFLICT (post_id, bucket) DO UPDATE SET
+			impressions = post_analytics_hourly.impressions + EXCLUDED.impressions,
+			views = post_analytics_hourly.views + EXCLUDED.views,
+			unique_viewers = GREATEST(post_analytics_hourly.unique_viewers, EXCLUDED.unique_viewers)
+	`, postIDs, buckets, impressions, views, bucketUniques); err != nil {
+		return err
+	}
+
+	// Totaux (plusieurs tranches d'un même post sont regroupées)
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO post_analytics (post_id, impressions, views, unique_viewers, updated_at)
+		SELECT d.post_id, SUM(d.impressions), SUM(d.views), MAX(d.unique_viewers), NOW()
+		FROM unnest($1::uuid[], $2::bigint[], $3::bigint[], $4::bigint[])
+			AS d(post_id, impressions, views, unique_viewers)
+		JOIN posts p ON p.id = d.post_id
+		GROUP BY d.post_id
+		ORDER BY d.post_id
+		ON CONFLICT (post_id) DO UPDATE SET
+			impressions = post_analytics.impressions + EXCLUDED.impressions,
+			views = post_analytics.views + EXCLUDED.views,
+			unique_viewers = GREATEST(post_analytics.unique_viewers, EXCLUDED.unique_viewers),
+			updated_at = EXCLUDED.updated_at
+	`, postIDs, impressions, views, uniques); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *AnalyticsRepo) GetTotals(ctx context.Context, postID string) (int, int, int, error) {
+	var impressions, views, uniques int
+	err := r.db.QueryRow(ctx,
+		`SELECT impressions, views, unique_viewers FROM post_analytics WHERE post_id = $1`,
+		postID,
+	).Scan(&impressions, &views, &uniques)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, 0, 0, nil // Aucun événement reçu
+	}
+	return impressions, views, uniques, err
+}
+
+// ListBuckets : les trois sources sont regroupées par heure (UTC) puis fusionnées (FULL JOIN)
+func (r *AnalyticsRepo) ListBuckets(ctx context.Context, postID string, from, to time.Time) ([]domain.AnalyticsBucket, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH a AS (
+			SELECT bucket, impressions, views, unique_viewers
+			FROM post_analytics_hourly
+			WHERE post_id = $1 AND bucket >= $2 AND bucket < $3
+		), re AS (
+			SELECT date_trunc('hour', created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket, COUNT(*) AS n
+			FROM post_reactions
+			WHERE post_id = $1 AND created_at >= $2 AND created_at < $3
+			GROUP BY 1
+		), c AS (
+			SELECT date_trunc('hour', created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket, COUNT(*) AS n
+			FROM comments
+			WHERE post_id = $1 AND created_at >= $2 AND created_at < $3 AND hidden_at IS NULL
+			GROUP BY 1
+		)
+		SELECT COALESCE(a.bucket, re.bucket, c.bucket) AS bucket,
+			COALESCE(a.impressions, 0), COALESCE(a.views, 0), COALESCE(a.unique_viewers, 0),
+			COALESCE(re.n, 0), COALESCE(c.n, 0)
+		FROM a
+		FULL JOIN re ON re.bucket = a.bucket
+		FULL JOIN c ON c.bucket = COALESCE(a.bucket, re.bucket)
+		ORDER BY bucket ASC
+	`, postID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := []domain.AnalyticsBucket{}
+	for rows.Next() {
+		var b domain.AnalyticsBucket
+		if err := rows.Scan(&b.Start, &b.Impressions, &b.Views, &b.UniqueViewers, &b.Reactions, &b.Comments); err != nil {
+			return nil, err
+		}
+		b.Start = b.Start.UTC()
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
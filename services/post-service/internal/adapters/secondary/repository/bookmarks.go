@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// collectionColumns : le compteur est calculé à la lecture (idx_bookmarks_collection_created_post)
+const collectionColumns = `c.id, c.user_id, c.name, c.position,
+	(SELECT COUNT(*) FROM bookmarks b WHERE b.collection_id = c.id), c.created_at, c.updated_at`
+
+type BookmarkRepo struct {
+	db    *pgxpool.Pool
+	posts *PostgresRepo // Lecture des posts joints (scanPostRows)
+}
+
+func NewBookmarkRepo(db *pgxpool.Pool) ports.BookmarkRepository {
+	return &BookmarkRepo{db: db, posts: &PostgresRepo{db: db}}
+}
+
+// SaveBookmark : upsert. Re-enregistrer un post le déplace sans changer sa place dans la liste.
+func (r *BookmarkRepo) SaveBookmark(ctx context.Context, b *domain.Bookmark) (*domain.Bookmark, error) {
+	var collectionID *string
+	if b.CollectionID != "" {
+		collectionID = &b.CollectionID
+	}
+
+	saved := *b
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO bookmarks (user_id, post_id, collection_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, post_id) DO UPDATE SET collection_id = EXCLUDED.collection_id
+		RETURNING created_at
+	`, b.UserID, b.PostID, collectionID, b.CreatedAt).Scan(&saved.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		// Code 23503 = Foreign Key Violation (post purgé, ou collection supprimée entre-temps)
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			if pgErr.ConstraintName == "bookmarks_collection_id_fkey" {
+				return nil, domain.ErrCollectionNotFound
+			}
+			return nil, domain.ErrPostNotFound
+		}
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *BookmarkRepo) DeleteBookmark(ctx context.Context, userID, postID string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM bookmarks WHERE user_id = $1 AND post_id = $2`, userID, postID)
+	return err
+}
+
+// ListBookmarks : PAGINATION KEYSET sur (date d'enregistrement, post). Les posts supprimés (en attente de purge)
+// sont renvoyés tels quels : le service en fait des pierres tombales.
+func (r *BookmarkRepo) ListBookmarks(ctx context.Context, userID, collectionID string, limit int, cursor *domain.KeysetCursor) ([]*domain.Bookmark, error) {
+	var cursorAt, cursorID any // NULL = première page
+	if cursor != nil {
+		cursorAt, cursorID = cursor.At, cursor.ID
+	}
+
+	// Le filtre sur la collection reste littéral pour utiliser idx_bookmarks_collection_created_post
+	var query string
+	var args []any
+	if collectionID == "" {
+		query = `
+			SELECT ` + prefixedPostColumns + `, COALESCE(b.collection_id::text, ''), b.created_at
+			FROM bookmarks b
+			JOIN posts p ON p.id = b.post_id
+			WHERE b.user_id = $1 AND ($2::timestamptz IS NULL OR (b.created_at, b.post_id) < ($2, $3::uuid))
+			ORDER BY b.created_at DESC, b.post_id DESC
+			LIMIT $4
+		`
+		args = []any{userID, cursorAt, cursorID, limit}
+	} else {
+		query = `
+			SELECT ` + prefixedPostColumns + `, COALESCE(b.collection_id::text, ''), b.created_at
+			FROM bookmarks b
+			JOIN posts p ON p.id = b.post_id
+			WHERE b.collection_id = $1 AND b.user_id = $2 AND ($3::timestamptz IS NULL OR (b.created_at, b.post_id) < ($3, $4::uuid))
+			ORDER BY b.created_at DESC, b.post_id DESC
+			LIMIT $5
+		`
+		args = []any{collectionID, userID, cursorAt, cursorID, limit}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookmarks := []*domain.Bookmark{}
+	for rows.Next() {
+		b := &domain.Bookmark{UserID: userID}
+		post, err := r.posts.scanPostRows(rows, &b.CollectionID, &b.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		b.PostID = post.ID
+		b.Post = post
+		bookmarks = append(bookmarks, b)
+	}
+	return bookmarks, rows.Err()
+}
+
+// ViewerBookmarks : BATCH (une requête pour toute une page de Feed)
+func (r *BookmarkRepo) ViewerBookmarks(ctx context.Context, viewerID string, postIDs []string) (map[string]bool, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT post_id FROM bookmarks WHERE user_id = $1 AND post_id = ANY($2::uuid[])`,
+		viewerID, postIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookmarked := make(map[string]bool)
+	for rows.Next() {
+		var postID string
+		if err := rows.Scan(&postID); err != nil {
+			return nil, err
+		}
+		bookmarked[postID] = true
+	}
+	return bookmarked, rows.Err()
+}
+
+// CreateCollection : la limite et la position sont calculées dans la même requête.
+// Sans GROUP BY, l'agrégat renvoie toujours une ligne (aucune si HAVING échoue : limite atteinte).
+// Le verrou consultatif (par utilisateur, jusqu'au COMMIT) sérialise les créations concurrentes :
+// sans lui, deux requêtes compteraient chacune 99 collections et en créeraient 101.
+func (r *BookmarkRepo) CreateCollection(ctx context.Context, c *domain.Collection) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx,
+		`SELECT pg_advisory_xact_lock(hashtextextended('bookmark_collections:' || $1::text, 0))`,
+		c.UserID,
+	); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO bookmark_collections (id, user_id, name, position, created_at, updated_at)
+		SELECT $1, $2, $3, COALESCE(MAX(position) + 1, 0), $4, $4
+		FROM bookmark_collections
+		WHERE user_id = $2
+		HAVING COUNT(*) < $5
+		RETURNING position
+	`, c.ID, c.UserID, c.Name, c.CreatedAt, domain.MaxCollections).Scan(&c.Position)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTooManyCollections
+		}
+		if isUniqueViolation(err) {
+			return domain.ErrCollectionNameTaken
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *BookmarkRepo) FindCollection(ctx context.Context, userID, collectionID string) (*domain.Collection, error) {
+	c, err := scanCollection(r.db.QueryRow(ctx,
+		`SELECT `+collectionColumns+` FROM bookmark_collections c WHERE c.id = $1 AND c.user_id = $2`,
+		collectionID, userID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrCollectionNotFound
+	}
+	return c, err
+}
+
+func (r *BookmarkRepo) ListCollections(ctx context.Context, userID string) ([]*domain.Collection, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+collectionColumns+`
+		FROM bookmark_collections c
+		WHERE c.user_id = $1
+		ORDER BY c.position ASC, c.created_at ASC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := []*domain.Collection{}
+	for rows.Next() {
+		c, err := scanCollection(rows)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, c)
+	}
+	return collections, rows.Err()
+}
+
+func (r *BookmarkRepo) RenameCollection(ctx context.Context, c *domain.Collection) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE bookmark_collections SET name = $3, updated_at = $4 WHERE id = $1 AND user_id = $2`,
+		c.ID, c.UserID, c.Name, c.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrCollectionNameTaken
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+// ReorderCollections : une seule requête, position = rang dans orderedIDs
+func (r *BookmarkRepo) ReorderCollections(ctx context.Context, userID string, orderedIDs []string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE bookmark_collections c
+		SET position = o.ord - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+		WHERE c.id = o.id AND c.user_id = $1
+	`, userID, orderedIDs)
+	return err
+}
+
+// DeleteCollection : ON DELETE SET NULL laisse ses signets non classés
+func (r *BookmarkRepo) DeleteCollection(ctx context.Context, userID, collectionID string) error {
+	tag, err := r.db.Exec(ctx,
+		`DELETE FROM bookmark_collections WHERE id = $1 AND user_id = $2`,
+		collectionID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+// --- Helpers ---
+
+func scanCollection(row pgx.Row) (*domain.Collection, error) {
+	var c domain.Collection
+	if err := row.Scan(&c.ID, &c.UserID, &c.Name, &c.Position, &c.BookmarksCount, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// isUniqueViolation : code 23505 (ici idx_bookmark_collections_user_name)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+const commentColumns = `id, post_id, COALESCE(parent_id::text, ''), user_id, content, replies_count, created_at, updated_at`
+
+type CommentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCommentRepo(db *pgxpool.Pool) ports.CommentRepository {
+	return &CommentRepo{db: db}
+}
+
+// SaveComment : insertion + compteurs + outbox dans la même transaction
+func (r *CommentRepo) SaveComment(ctx context.Context, c *domain.Comment, announce ports.CommentAnnouncer) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // No-op si Commit a réussi
+
+	// 1. Le compteur du post sert aussi de vérification d'existence (et verrouille la ligne) :
+	// un post masqué ou dépublié depuis la vérification de visibilité n'est plus commentable
+	var postAuthorID string
+	err = tx.QueryRow(ctx,
+		`UPDATE posts SET comments_count = comments_count + 1
+		WHERE id = $1 AND status = 'published' AND hidden_at IS NULL AND deleted_at IS NULL
+		RETURNING user_id`,
+		c.PostID,
+	).Scan(&postAuthorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPostNotFound
+		}
+		return err
+	}
+
+	// 2. Compteur de réponses du parent
+	if c.IsReply() {
+		tag, err := tx.Exec(ctx,
+			`UPDATE comments SET replies_count = replies_count + 1 WHERE id = $1 AND post_id = $2`,
+			c.ParentID, c.PostID,
+		)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrCommentNotFound // Parent supprimé entre-temps
+		}
+	}
+
+	// 3. Insertion
+	_, err = tx.Exec(ctx, `
+		INSERT INTO comments (id, post_id, parent_id, user_id, content, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7)
+	`, c.ID, c.PostID, c.ParentID, c.UserID, c.Content, c.CreatedAt, c.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert comment: %w", err)
+	}
+
+	// 4. Notification (outbox)
+	events, err := announce(postAuthorID)
+	if err != nil {
+		return err
+	}
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *CommentRepo) FindCommentByID(ctx context.Context, commentID string) (*domain.Comment, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+commentColumns+` FROM comments WHERE id = $1`, commentID)
+	c, err := scanComment(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *CommentRepo) UpdateComment(ctx context.Context, c *domain.Comment) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE comments SET content = $1, updated_at = $2 WHERE id = $3`,
+		c.Content, c.UpdatedAt, c.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+// DeleteComment : la FK "ON DELETE CASCADE" supprime les réponses, on retire donc 1 + replies_count du post
+func (r *CommentRepo) DeleteComment(ctx context.Context, c *domain.Comment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// On relit replies_count dans la transaction (il a pu bouger depuis FindCommentByID)
+	var removed int
+	err = tx.QueryRow(ctx,
+		`DELETE FROM comments WHERE id = $1 RETURNING 1 + replies_count`,
+		c.ID,
+	).Scan(&removed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrCommentNotFound
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE posts SET comments_count = GREATEST(comments_count - $1, 0) WHERE id = $2`,
+		removed, c.PostID,
+	); err != nil {
+		return err
+	}
+
+	if c.IsReply() {
+		if _, err := tx.Exec(ctx,
+			`UPDATE comments SET replies_count = GREATEST(replies_count - 1, 0) WHERE id = $1`,
+			c.ParentID,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListComments : PAGINATION KEYSET (ordre chronologique)
+func (r *CommentRepo) ListComments(ctx context.Context, postID, parentID string, limit int, cursorTime time.Time) ([]*domain.Comment, error) {
+	// Le filtre "parent_id IS NULL" / "parent_id = $x" doit rester littéral pour utiliser les index partiels
+	var query string
+	var args []any
+	if parentID == "" {
+		query = `
+			SELECT ` + commentColumns + `
+			FROM comments
+			WHERE post_id = $1 AND parent_id IS NULL AND created_at > $2 AND hidden_at IS NULL
+			ORDER BY created_at ASC
+			LIMIT $3
+		`
+		args = []any{postID, cursorTime, limit}
+	} else {
+		query = `
+			SELECT ` + commentColumns + `
+			FROM comments
+			WHERE parent_id = $1 AND post_id = $2 AND created_at > $3 AND hidden_at IS NULL
+			ORDER BY created_at ASC
+			LIMIT $4
+		`
+		args = []any{parentID, postID, cursorTime, limit}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectComments(rows)
+}
+
+// ListRepliesPreview : LATERAL JOIN = "top N par groupe" en une seule requête
+func (r *CommentRepo) ListRepliesPreview(ctx context.Context, parentIDs []string, perParent int) ([]*domain.Comment, error) {
+	query := `
+		SELECT c.id, c.post_id, COALESCE(c.parent_id::text, ''), c.user_id, c.content, c.replies_count, c.created_at, c.updated_at
+		FROM unnest($1::uuid[]) AS p(id)
+		CROSS JOIN LATERAL (
+			SELECT * FROM comments
+			WHERE parent_id = p.id AND hidden_at IS NULL
+			ORDER BY created_at ASC
+			LIMIT $2
+		) c
+		ORDER BY c.created_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, parentIDs, perParent)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return collectComments(rows)
+}
+
+// --- Helpers ---
+
+func scanComment(row pgx.Row) (*domain.Comment, error) {
+	var c domain.Comment
+	err := row.Scan(&c.ID, &c.PostID, &c.ParentID, &c.UserID, &c.Content, &c.RepliesCount, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func collectComments(rows pgx.Rows) ([]*domain.Comment, error) {
+	comments := []*domain.Comment{}
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
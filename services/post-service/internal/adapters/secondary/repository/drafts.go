@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// ListDrafts : brouillons, posts planifiés et posts retenus par la modération d'un auteur, PAGINATION KEYSET sur updated_at
+// (un brouillon retouché remonte en tête de liste)
+func (r *PostgresRepo) ListDrafts(ctx context.Context, authorID string, limit int, cursorTime time.Time) ([]*domain.Post, error) {
+	// Cas 1: Première page (pas de curseur)
+	query := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE user_id = $1 AND status <> 'published' AND deleted_at IS NULL
+		ORDER BY updated_at DESC
+		LIMIT $2
+	`
+	args := []any{authorID, limit}
+
+	// Cas 2: Page suivante
+	if !cursorTime.IsZero() {
+		query = `
+			SELECT ` + postColumns + `
+			FROM posts
+			WHERE user_id = $1 AND status <> 'published' AND deleted_at IS NULL AND updated_at < $2
+			ORDER BY updated_at DESC
+			LIMIT $3
+		`
+		args = []any{authorID, cursorTime, limit}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.collectRows(rows)
+}
+
+// UpdateSchedule enregistre le statut/PublishAt d'un brouillon ou d'un post planifié.
+// Le filtre sur le statut protège de la course avec le scheduler : si le post vient
+// d'être publié, la ligne ne correspond plus et on renvoie domain.ErrNotADraft.
+// Un post retenu par la modération automatique l'est avec son dossier, dans la même transaction.
+func (r *PostgresRepo) UpdateSchedule(ctx context.Context, post *domain.Post) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE posts SET status = $1, publish_at = $2, updated_at = $3
+		WHERE id = $4 AND status IN ('draft', 'scheduled') AND deleted_at IS NULL
+	`, string(post.Status), nullableTime(post.PublishAt), post.UpdatedAt, post.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotADraft
+	}
+
+	if err := openReview(ctx, tx, post); err != nil {
+		return fmt.Errorf("failed to open moderation case: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// PublishDue publie au plus 'limit' posts planifiés arrivés à échéance et les renvoie.
+// FOR UPDATE SKIP LOCKED : plusieurs réplicas peuvent tourner en parallèle, chacun réclame
+// des lignes différentes et aucun post n'est publié deux fois.
+// created_at prend la date de publication prévue : le post se range à sa place dans les timelines.
+func (r *PostgresRepo) PublishDue(ctx context.Context, now time.Time, limit int, announce ports.PostAnnouncer) ([]*domain.Post, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		UPDATE posts SET status = 'published', created_at = publish_at, updated_at = $1
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE status = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+			ORDER BY publish_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+postColumns,
+		now, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := r.collectRows(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return posts, nil
+	}
+
+	ids := make([]string, len(posts))
+	for i, p := range posts {
+		ids[i] = p.ID
+	}
+
+	// Les tables normalisées recopient posts.created_at (pagination des timelines de hashtag)
+	if _, err := tx.Exec(ctx, `
+		UPDATE post_hashtags h SET created_at = p.created_at
+		FROM posts p WHERE p.id = h.post_id AND p.id = ANY($1::uuid[])
+	`, ids); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE post_mentions m SET created_at = p.created_at
+		FROM posts p WHERE p.id = m.post_id AND p.id = ANY($1::uuid[])
+	`, ids); err != nil {
+		return nil, err
+	}
+
+	events, err := announce(posts)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return nil, fmt.Errorf("failed to write outbox: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ReleaseHeld publie un post retenu par la modération (domain.ErrNotHeld s'il ne l'est pas/plus).
+// created_at est conservé : le post reprend sa place d'origine dans les timelines.
+func (r *PostgresRepo) ReleaseHeld(ctx context.Context, postID string, now time.Time, events ...*domain.OutboxMessage) (*domain.Post, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, `
+		UPDATE posts SET status = 'published', updated_at = $2
+		WHERE id = $1 AND status = 'held' AND deleted_at IS NULL
+		RETURNING `+postColumns,
+		postID, now,
+	)
+	post, err := r.scanPost(row)
+	if errors.Is(err, domain.ErrPostNotFound) {
+		return nil, domain.ErrNotHeld
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return nil, fmt.Errorf("failed to write outbox: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// --- Helpers ---
+
+// postStatus : un Post construit sans statut explicite est publié (valeur par défaut de la colonne)
+func postStatus(post *domain.Post) domain.PostStatus {
+	if post.Status == "" {
+		return domain.PostStatusPublished
+	}
+	return post.Status
+}
+
+// nullableTime : time.Time{} -> NULL
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// DTO interne pour la colonne posts.entities (JSONB)
+type entityDTO struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Start  int    `json:"start"`
+	End    int    `json:"end"`
+	UserID string `json:"user_id,omitempty"`
+}
+
+// ListByHashtag : PAGINATION KEYSET sur post_hashtags.created_at (copie de posts.created_at)
+func (r *PostgresRepo) ListByHashtag(ctx context.Context, tag string, limit int, cursorTime time.Time) ([]*domain.Post, error) {
+	// Cas 1: Première page (pas de curseur)
+	query := `
+		SELECT ` + prefixedPostColumns + `
+		FROM post_hashtags h
+		JOIN posts p ON p.id = h.post_id
+		WHERE h.tag = $1 AND p.status = 'published' AND p.deleted_at IS NULL
+		ORDER BY h.created_at DESC
+		LIMIT $2
+	`
+	args := []any{tag, limit}
+
+	// Cas 2: Page suivante (on cherche ce qui est plus vieux que le curseur)
+	if !cursorTime.IsZero() {
+		query = `
+			SELECT ` + prefixedPostColumns + `
+			FROM post_hashtags h
+			JOIN posts p ON p.id = h.post_id
+			WHERE h.tag = $1 AND p.status = 'published' AND p.deleted_at IS NULL AND h.created_at < $2
+			ORDER BY h.created_at DESC
+			LIMIT $3
+		`
+		args = []any{tag, cursorTime, limit}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.collectRows(rows)
+}
+
+// writeEntities réécrit les tables normalisées d'un post (création ou édition), dans la transaction appelante
+func writeEntities(ctx context.Context, tx pgx.Tx, post *domain.Post) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM post_hashtags WHERE post_id = $1`, post.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM post_mentions WHERE post_id = $1`, post.ID); err != nil {
+		return err
+	}
+
+	if tags := post.Hashtags(); len(tags) > 0 {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO post_hashtags (post_id, tag, created_at)
+			SELECT $1, t, $3 FROM unnest($2::text[]) AS t
+		`, post.ID, tags, post.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if users := post.MentionedUserIDs(); len(users) > 0 {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO post_mentions (post_id, user_id, created_at)
+			SELECT $1, u, $3 FROM unnest($2::text[]) AS u
+		`, post.ID, users, post.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func marshalEntities(entities []domain.Entity) ([]byte, error) {
+	dtos := make([]entityDTO, len(entities))
+	for i, e := range entities {
+		dtos[i] = entityDTO{Type: string(e.Type), Text: e.Text, Start: e.Start, End: e.End, UserID: e.UserID}
+	}
+	return json.Marshal(dtos)
+}
+
+func unmarshalEntities(data []byte) []domain.Entity {
+	var dtos []entityDTO
+	if err := json.Unmarshal(data, &dtos); err != nil {
+		return []domain.Entity{} // Fallback safe
+	}
+
+	entities := make([]domain.Entity, len(dtos))
+	for i, d := range dtos {
+		entities[i] = domain.Entity{
+			Type:   domain.EntityType(d.Type),
+			Text:   d.Text,
+			Start:  d.Start,
+			End:    d.End,
+			UserID: d.UserID,
+		}
+	}
+	return entities
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+type IdempotencyRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyRepo(db *pgxpool.Pool) ports.IdempotencyStore {
+	return &IdempotencyRepo{db: db}
+}
+
+// Reserve : la clé primaire arbitre les essais concurrents (un seul INSERT gagne).
+// Une clé expirée, ou réservée puis abandonnée, est reprise par le DO UPDATE conditionnel.
+func (r *IdempotencyRepo) Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	abandonedBefore := rec.CreatedAt.Add(-domain.IdempotencyPendingTimeout)
+
+	// La réservation concurrente peut être libérée entre l'INSERT et sa lecture : on recommence
+	for attempt := 0; attempt < 3; attempt++ {
+		var reserved bool
+		err := r.db.QueryRow(ctx, `
+			INSERT INTO idempotency_keys (user_id, operation, key, fingerprint, post_id, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (user_id, operation, key) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint, post_id = EXCLUDED.post_id, completed = FALSE,
+				created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+				OR (NOT idempotency_keys.completed AND idempotency_keys.created_at < $8)
+			RETURNING TRUE
+		`, rec.UserID, rec.Operation, rec.Key, rec.Fingerprint, rec.PostID, rec.CreatedAt, rec.ExpiresAt, abandonedBefore).Scan(&reserved)
+		if err == nil {
+			return nil, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, err
+		}
+
+		var existing domain.IdempotencyRecord
+		err = r.db.QueryRow(ctx, `
+			SELECT user_id, operation, key, fingerprint, post_id, completed, created_at, expires_at
+			FROM idempotency_keys
+			WHERE user_id = $1 AND operation = $2 AND key = $3
+		`, rec.UserID, rec.Operation, rec.Key).Scan(&existing.UserID, &existing.Operation, &existing.Key,
+			&existing.Fingerprint, &existing.PostID, &existing.Completed, &existing.CreatedAt, &existing.ExpiresAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return &existing, false, nil
+	}
+	return nil, false, domain.ErrRequestInProgress
+}
+
+// Complete et Release ne touchent que NOTRE réservation (created_at), pas celle d'un essai qui l'aurait reprise
+func (r *IdempotencyRepo) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE idempotency_keys SET completed = TRUE
+		WHERE user_id = $1 AND operation = $2 AND key = $3 AND created_at = $4
+	`, rec.UserID, rec.Operation, rec.Key, rec.CreatedAt)
+	return err
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	_, err := r.db.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND operation = $2 AND key = $3 AND created_at = $4 AND NOT completed
+	`, rec.UserID, rec.Operation, rec.Key, rec.CreatedAt)
+	return err
+}
+
+func (r *IdempotencyRepo) PurgeExpired(ctx context.Context, now time.Time, limit int) (int, error) {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE (user_id, operation, key) IN (
+			SELECT user_id, operation, key FROM idempotency_keys
+			WHERE expires_at < $1
+			ORDER BY expires_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// DTO interne du JSONB posts.link_preview
+type linkPreviewDTO struct {
+	URL         string    `json:"url"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	ImageURL    string    `json:"image_url,omitempty"`
+	SiteName    string    `json:"site_name,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+type LinkPreviewRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLinkPreviewRepo(db *pgxpool.Pool) ports.LinkPreviewRepository {
+	return &LinkPreviewRepo{db: db}
+}
+
+// ClaimJobs réserve au plus 'limit' jobs échus : next_attempt_at est repoussé AVANT la récupération
+// (backoff linéaire), un réplica qui meurt en cours de route libère donc ses jobs tout seul.
+// FOR UPDATE SKIP LOCKED : plusieurs réplicas se partagent la file sans doublon.
+func (r *LinkPreviewRepo) ClaimJobs(ctx context.Context, now time.Time, backoff time.Duration, limit int) ([]domain.LinkPreviewJob, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE link_preview_jobs
+		SET attempts = attempts + 1,
+			next_attempt_at = $1 + make_interval(secs => $2 * (attempts + 1))
+		WHERE post_id IN (
+			SELECT post_id FROM link_preview_jobs
+			WHERE next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING post_id, url, attempts
+	`, now, backoff.Seconds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []domain.LinkPreviewJob{}
+	for rows.Next() {
+		var j domain.LinkPreviewJob
+		if err := rows.Scan(&j.PostID, &j.URL, &j.Attempts); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+// CompleteJob enregistre l'aperçu (nil = abandon) et retire le job.
+// Si le post a été édité entre-temps avec un autre lien, le job a changé d'URL : on n'écrase rien.
+func (r *LinkPreviewRepo) CompleteJob(ctx context.Context, job domain.LinkPreviewJob, preview *domain.LinkPreview) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `DELETE FROM link_preview_jobs WHERE post_id = $1 AND url = $2`, job.PostID, job.URL)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 || preview == nil {
+		return tx.Commit(ctx)
+	}
+
+	previewJSON, err := marshalLinkPreview(preview)
+	if err != nil {
+		return fmt.Errorf("failed to marshal link preview: %w", err)
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE posts SET link_preview = $1 WHERE id = $2 AND deleted_at IS NULL`,
+		previewJSON, job.PostID,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// --- Helpers ---
+
+// queueLinkPreview : appelé par Save/Update, dans la transaction du post.
+// L'aperçu existant est conservé tant que le premier lien ne change pas.
+func queueLinkPreview(ctx context.Context, tx pgx.Tx, post *domain.Post) error {
+	link := domain.FirstLink(post.Content)
+	if link == "" {
+		if _, err := tx.Exec(ctx, `DELETE FROM link_preview_jobs WHERE post_id = $1`, post.ID); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `UPDATE posts SET link_preview = NULL WHERE id = $1 AND link_preview IS NOT NULL`, post.ID)
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE posts SET link_preview = NULL
+		WHERE id = $1 AND (link_preview IS NULL OR link_preview->>'url' <> $2)
+	`, post.ID, link)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil // Même lien, aperçu déjà en place
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO link_preview_jobs (post_id, url) VALUES ($1, $2)
+		ON CONFLICT (post_id) DO UPDATE SET url = EXCLUDED.url, attempts = 0, next_attempt_at = NOW()
+		WHERE link_preview_jobs.url <> EXCLUDED.url
+	`, post.ID, link)
+	return err
+}
+
+func marshalLinkPreview(l *domain.LinkPreview) ([]byte, error) {
+	return json.Marshal(linkPreviewDTO{
+		URL:         l.URL,
+		Title:       l.Title,
+		Description: l.Description,
+		ImageURL:    l.ImageURL,
+		SiteName:    l.SiteName,
+		FetchedAt:   l.FetchedAt,
+	})
+}
+
+func unmarshalLinkPreview(data []byte) *domain.LinkPreview {
+	if len(data) == 0 {
+		return nil
+	}
+	var d linkPreviewDTO
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil // Fallback safe
+	}
+	return &domain.LinkPreview{
+		URL:         d.URL,
+		Title:       d.Title,
+		Description: d.Description,
+		ImageURL:    d.ImageURL,
+		SiteName:    d.SiteName,
+		FetchedAt:   d.FetchedAt,
+	}
+}
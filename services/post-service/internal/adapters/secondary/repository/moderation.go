@@ -0,0 +1,346 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+const caseColumns = `id, target_type, target_id, target_author_id, status, reports_count, reason_counts,
+	COALESCE(claimed_by, ''), claimed_at, COALESCE(resolution, ''), COALESCE(resolved_by, ''), resolved_at,
+	first_reported_at, last_reported_at`
+
+type ModerationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewModerationRepo(db *pgxpool.Pool) ports.ModerationRepository {
+	return &ModerationRepo{db: db}
+}
+
+// AddReport : dossier + signalement + compteurs dans la même transaction.
+// L'index unique partiel (un dossier non résolu par cible) sert de point de rendez-vous des signalements concurrents.
+func (r *ModerationRepo) AddReport(ctx context.Context, target *domain.ModerationCase, report *domain.Report) (*domain.ModerationCase, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	c, err := addReport(ctx, tx, target, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// openReview ouvre le dossier d'un post retenu par la modération automatique, dans la transaction qui
+// enregistre le post. Un dossier déjà ouvert par une version précédente suffit (le modérateur lit la dernière).
+func openReview(ctx context.Context, tx pgx.Tx, post *domain.Post) error {
+	if post.Review == nil {
+		return nil
+	}
+	_, err := addReport(ctx, tx, &domain.ModerationCase{
+		TargetType:     domain.TargetPost,
+		TargetID:       post.ID,
+		TargetAuthorID: post.UserID,
+	}, post.Review)
+	if errors.Is(err, domain.ErrAlreadyReported) {
+		return nil
+	}
+	return err
+}
+
+// addReport : cf. AddReport, dans la transaction de l'appelant
+func addReport(ctx context.Context, tx pgx.Tx, target *domain.ModerationCase, report *domain.Report) (*domain.ModerationCase, error) {
+	// 1. Dossier ouvert de la cible (créé au besoin). Le DO UPDATE "à vide" verrouille la ligne et renvoie son ID.
+	var caseID string
+	err := tx.QueryRow(ctx, `
+		INSERT INTO moderation_cases (id, target_type, target_id, target_author_id, first_reported_at, last_reported_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (target_type, target_id) WHERE status <> 'resolved'
+		DO UPDATE SET last_reported_at = moderation_cases.last_reported_at
+		RETURNING id
+	`, uuid.NewString(), target.TargetType, target.TargetID, target.TargetAuthorID, report.CreatedAt).Scan(&caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Signalement (un par rapporteur et par dossier)
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO reports (id, case_id, reporter_id, reason, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (case_id, reporter_id) DO NOTHING
+	`, report.ID, caseID, report.ReporterID, report.Reason, report.Details, report.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, domain.ErrAlreadyReported
+	}
+
+	// 3. Compteurs agrégés
+	row := tx.QueryRow(ctx, `
+		UPDATE moderation_cases
+		SET reports_count = reports_count + 1,
+			reason_counts = jsonb_set(reason_counts, ARRAY[$2::text], to_jsonb(COALESCE((reason_counts->>$2)::int, 0) + 1)),
+			last_reported_at = $3
+		WHERE id = $1
+		RETURNING `+caseColumns,
+		caseID, string(report.Reason), report.CreatedAt,
+	)
+	return scanCase(row)
+}
+
+func (r *ModerationRepo) FindCase(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	c, err := scanCase(r.db.QueryRow(ctx, `SELECT `+caseColumns+` FROM moderation_cases WHERE id = $1`, caseID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrCaseNotFound
+	}
+	return c, err
+}
+
+// ListCases : PAGINATION KEYSET, file FIFO (le premier signalé est traité en premier)
+func (r *ModerationRepo) ListCases(ctx context.Context, status domain.CaseStatus, limit int, cursorTime time.Time) ([]*domain.ModerationCase, error) {
+	var rows pgx.Rows
+	var err error
+	if status == "" {
+		rows, err = r.db.Query(ctx, `
+			SELECT `+caseColumns+`
+			FROM moderation_cases
+			WHERE status <> 'resolved' AND first_reported_at > $1
+			ORDER BY first_reported_at ASC
+			LIMIT $2
+		`, cursorTime, limit)
+	} else {
+		rows, err = r.db.Query(ctx, `
+			SELECT `+caseColumns+`
+			FROM moderation_cases
+			WHERE status = $1 AND first_reported_at > $2
+			ORDER BY first_reported_at ASC
+			LIMIT $3
+		`, status, cursorTime, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cases := []*domain.ModerationCase{}
+	for rows.Next() {
+		c, err := scanCase(rows)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, c)
+	}
+	return cases, rows.Err()
+}
+
+func (r *ModerationRepo) ListReports(ctx context.Context, caseID string, limit int) ([]*domain.Report, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, case_id, reporter_id, reason, details, created_at
+		FROM reports
+		WHERE case_id = $1
+		ORDER BY created_at ASC
+		LIMIT $2
+	`, caseID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := []*domain.Report{}
+	for rows.Next() {
+		var rep domain.Report
+		if err := rows.Scan(&rep.ID, &rep.CaseID, &rep.ReporterID, &rep.Reason, &rep.Details, &rep.CreatedAt); err != nil {
+			return nil, err
+		}
+		reports = append(reports, &rep)
+	}
+	return reports, rows.Err()
+}
+
+func (r *ModerationRepo) ListDecisions(ctx context.Context, targetType domain.ReportTarget, targetID string) ([]*domain.ModerationDecision, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, case_id, target_type, target_id, target_author_id, moderator_id, action, note, suspended_until, content_warning, created_at
+		FROM moderation_decisions
+		WHERE target_type = $1 AND target_id = $2
+		ORDER BY created_at DESC
+	`, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	decisions := []*domain.ModerationDecision{}
+	for rows.Next() {
+		var d domain.ModerationDecision
+		var suspendedUntil *time.Time
+		if err := rows.Scan(&d.ID, &d.CaseID, &d.TargetType, &d.TargetID, &d.TargetAuthorID, &d.ModeratorID, &d.Action, &d.Note, &suspendedUntil, &d.ContentWarning, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		if suspendedUntil != nil {
+			d.SuspendedUntil = *suspendedUntil
+		}
+		decisions = append(decisions, &d)
+	}
+	return decisions, rows.Err()
+}
+
+// SaveClaim : verrou optimiste sur claimed_by (deux modérateurs qui cliquent en même temps : un seul gagne)
+func (r *ModerationRepo) SaveClaim(ctx context.Context, c *domain.ModerationCase, previousClaimedBy string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE moderation_cases
+		SET status = $2, claimed_by = $3, claimed_at = $4
+		WHERE id = $1 AND status <> 'resolved' AND COALESCE(claimed_by, '') = $5
+	`, c.ID, c.Status, c.ClaimedBy, c.ClaimedAt, previousClaimedBy)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCaseAlreadyClaimed
+	}
+	return nil
+}
+
+func (r *ModerationRepo) Resolve(ctx context.Context, c *domain.ModerationCase, d *domain.ModerationDecision, events ...*domain.OutboxMessage) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE moderation_cases
+		SET status = 'resolved', resolution = $2, resolved_by = $3, resolved_at = $4
+		WHERE id = $1 AND status = 'claimed' AND claimed_by = $3
+	`, c.ID, c.Resolution, c.ResolvedBy, c.ResolvedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCaseNotClaimed
+	}
+
+	var suspendedUntil *time.Time
+	if !d.SuspendedUntil.IsZero() {
+		suspendedUntil = &d.SuspendedUntil
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO moderation_decisions (id, case_id, target_type, target_id, target_author_id, moderator_id, action, note, suspended_until, content_warning, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, d.ID, d.CaseID, d.TargetType, d.TargetID, d.TargetAuthorID, d.ModeratorID, d.Action, d.Note, suspendedUntil, d.ContentWarning, d.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// HidePost s'applique aussi à un post supprimé par son auteur : sans cela, une restauration
+// le remettrait en ligne malgré la décision de modération.
+// Un post déjà masqué n'est pas retouché : ses événements sont déjà partis avec le premier masquage.
+func (r *ModerationRepo) HidePost(ctx context.Context, postID string, at time.Time, events ...*domain.OutboxMessage) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var visible bool
+	err = tx.QueryRow(ctx, `SELECT hidden_at IS NULL FROM posts WHERE id = $1 FOR UPDATE`, postID).Scan(&visible)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPostNotFound
+		}
+		return err
+	}
+	if !visible {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE posts SET hidden_at = $2 WHERE id = $1`, postID, at); err != nil {
+		return err
+	}
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// MarkSensitive : le drapeau est posé dans le JSONB, sans toucher aux médias eux-mêmes (ni créer de révision),
+// et la décision est retenue sur le post pour survivre aux éditions de l'auteur
+func (r *ModerationRepo) MarkSensitive(ctx context.Context, postID, contentWarning string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE posts
+		SET media = (
+				SELECT COALESCE(jsonb_agg(m || '{"sensitive": true}'::jsonb ORDER BY i), '[]'::jsonb)
+				FROM jsonb_array_elements(media) WITH ORDINALITY AS t(m, i)
+			),
+			content_warning = COALESCE(NULLIF($2, ''), content_warning),
+			marked_sensitive = true,
+			moderator_warning = COALESCE(NULLIF($2, ''), moderator_warning)
+		WHERE id = $1 AND deleted_at IS NULL
+	`, postID, contentWarning)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrPostNotFound
+	}
+	return nil
+}
+
+func (r *ModerationRepo) HideComment(ctx context.Context, commentID string, at time.Time) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE comments SET hidden_at = COALESCE(hidden_at, $2) WHERE id = $1`,
+		commentID, at,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+// --- Helpers ---
+
+func scanCase(row pgx.Row) (*domain.ModerationCase, error) {
+	var c domain.ModerationCase
+	var reasonsJSON []byte
+	var claimedAt, resolvedAt *time.Time
+	err := row.Scan(&c.ID, &c.TargetType, &c.TargetID, &c.TargetAuthorID, &c.Status, &c.ReportsCount, &reasonsJSON,
+		&c.ClaimedBy, &claimedAt, &c.Resolution, &c.ResolvedBy, &resolvedAt,
+		&c.FirstReportedAt, &c.LastReportedAt)
+	if err != nil {
+		return nil, err
+	}
+	if claimedAt != nil {
+		c.ClaimedAt = *claimedAt
+	}
+	if resolvedAt != nil {
+		c.ResolvedAt = *resolvedAt
+	}
+	c.ReasonCounts = map[domain.ReportReason]int{}
+	if len(reasonsJSON) > 0 {
+		_ = json.Unmarshal(reasonsJSON, &c.ReasonCounts) // Fallback safe : compteurs vides
+	}
+	return &c, nil
+}
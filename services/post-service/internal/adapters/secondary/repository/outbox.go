@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+type OutboxRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepo(db *pgxpool.Pool) ports.OutboxRepository {
+	return &OutboxRepo{db: db}
+}
+
+// ClaimPending : FOR UPDATE SKIP LOCKED, comme le Scheduler. Le bail posé sur next_attempt_at
+// rend le message invisible aux autres relais le temps de la publication.
+func (r *OutboxRepo) ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.OutboxMessage, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE outbox o
+		SET attempts = o.attempts + 1, next_attempt_at = $2
+		FROM (
+			SELECT id FROM outbox
+			WHERE next_attempt_at <= $1
+			ORDER BY next_attempt_at, created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		) due
+		WHERE o.id = due.id
+		RETURNING o.id, o.subject, o.payload, o.headers, o.created_at, o.attempts
+	`, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []*domain.OutboxMessage{}
+	for rows.Next() {
+		var m domain.OutboxMessage
+		var headers []byte
+		if err := rows.Scan(&m.ID, &m.Subject, &m.Payload, &headers, &m.CreatedAt, &m.Attempts); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(headers, &m.Headers); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING ne garantit aucun ordre : publication dans l'ordre d'écriture
+	sort.Slice(messages, func(i, j int) bool { return messages[i].CreatedAt.Before(messages[j].CreatedAt) })
+	return messages, nil
+}
+
+func (r *OutboxRepo) MarkPublished(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM outbox WHERE id = $1`, id)
+	return err
+}
+
+func (r *OutboxRepo) MarkFailed(ctx context.Context, id string, retryAt time.Time, cause string) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE outbox SET next_attempt_at = $2, last_error = $3 WHERE id = $1`,
+		id, retryAt, cause,
+	)
+	return err
+}
+
+// --- Helpers ---
+
+// execer : pool ou transaction
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// insertOutbox : appelé dans la transaction du changement d'état (une requête pour tout le lot)
+func insertOutbox(ctx context.Context, db execer, events []*domain.OutboxMessage) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(events))
+	subjects := make([]string, len(events))
+	payloads := make([][]byte, len(events))
+	headers := make([]string, len(events))
+	createdAt := make([]time.Time, len(events))
+	for i, e := range events {
+		h, err := json.Marshal(e.Headers)
+		if err != nil {
+			return err
+		}
+		ids[i], subjects[i], payloads[i], headers[i], createdAt[i] = e.ID, e.Subject, e.Payload, string(h), e.CreatedAt
+	}
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO outbox (id, subject, payload, headers, created_at, next_attempt_at)
+		SELECT id, subject, payload, headers, created_at, created_at
+		FROM unnest($1::uuid[], $2::text[], $3::bytea[], $4::jsonb[], $5::timestamptz[])
+			AS e(id, subject, payload, headers, created_at)
+	`, ids, subjects, payloads, headers, createdAt)
+	return err
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// ListPinned : au plus 'limit' lignes, servies par idx_posts_user_pinned
+func (r *PostgresRepo) ListPinned(ctx context.Context, authorID string, limit int) ([]*domain.Post, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+postColumns+`
+		FROM posts
+		WHERE user_id = $1 AND pinned_at IS NOT NULL AND status = 'published' AND hidden_at IS NULL AND deleted_at IS NULL
+		ORDER BY pinned_at DESC
+		LIMIT $2
+	`, authorID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.collectRows(rows)
+}
+
+// Pin : le verrou consultatif (par auteur, relâché au COMMIT) sérialise les épinglages concurrents,
+// sans quoi deux requêtes simultanées pourraient dépasser la limite. Seuls les posts affichés
+// par ListPinned comptent : un épinglé masqué ou retenu ne doit pas occuper de place
+func (r *PostgresRepo) Pin(ctx context.Context, post *domain.Post, max int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('pins:' || $1))`, post.UserID); err != nil {
+		return err
+	}
+
+	var pinned int
+	if err := tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM posts
+		WHERE user_id = $1 AND pinned_at IS NOT NULL AND status = 'published' AND hidden_at IS NULL AND deleted_at IS NULL
+		  AND id <> $2
+	`, post.UserID, post.ID).Scan(&pinned); err != nil {
+		return err
+	}
+	if pinned >= max {
+		return domain.ErrTooManyPinnedPosts
+	}
+
+	err = tx.QueryRow(ctx,
+		`UPDATE posts SET pinned_at = COALESCE(pinned_at, $2) WHERE id = $1 AND deleted_at IS NULL RETURNING pinned_at`,
+		post.ID, post.PinnedAt,
+	).Scan(&post.PinnedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPostNotFound
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresRepo) Unpin(ctx context.Context, postID string) error {
+	_, err := r.db.Exec(ctx, `UPDATE posts SET pinned_at = NULL WHERE id = $1`, postID)
+	return err
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+type PollRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPollRepo(db *pgxpool.Pool) ports.PollRepository {
+	return &PollRepo{db: db}
+}
+
+// GetPolls : BATCH (une page de posts = deux requêtes, quel que soit le nombre de sondages)
+func (r *PollRepo) GetPolls(ctx context.Context, postIDs []string) (map[string]*domain.Poll, error) {
+	return readPolls(ctx, r.db, postIDs)
+}
+
+// ViewerChoices : postID -> options choisies par le viewer (Batch, pour l'hydratation du Feed)
+func (r *PollRepo) ViewerChoices(ctx context.Context, viewerID string, postIDs []string) (map[string][]int, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT post_id, position FROM poll_votes WHERE user_id = $1 AND post_id = ANY($2::uuid[]) ORDER BY post_id, position`,
+		viewerID, postIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	choices := make(map[string][]int)
+	for rows.Next() {
+		var postID string
+		var position int
+		if err := rows.Scan(&postID, &position); err != nil {
+			return nil, err
+		}
+		choices[postID] = append(choices[postID], position)
+	}
+	return choices, rows.Err()
+}
+
+// Vote : votes + compteurs dans la même transaction
+func (r *PollRepo) Vote(ctx context.Context, postID, userID string, choices []int, now time.Time) (*domain.Poll, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // No-op si Commit a réussi
+
+	// 1. Verrou sur le sondage : sérialise les votes concurrents et la clôture (qui saute les lignes verrouillées)
+	var closesAt time.Time
+	var closedAt *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT pl.closes_at, pl.closed_at
+		FROM polls pl JOIN posts p ON p.id = pl.post_id
+		WHERE pl.post_id = $1 AND p.deleted_at IS NULL
+		FOR UPDATE OF pl
+	`, postID).Scan(&closesAt, &closedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPollNotFound
+		}
+		return nil, err
+	}
+	// Revérifié sous verrou : l'échéance a pu passer depuis la lecture du service
+	if closedAt != nil || !now.Before(closesAt) {
+		return nil, domain.ErrPollClosed
+	}
+
+	// 2. Un seul vote par utilisateur (en choix multiple, toutes les options sont choisies d'un coup)
+	var voted bool
+	if err := tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM poll_votes WHERE post_id = $1 AND user_id = $2)`,
+		postID, userID,
+	).Scan(&voted); err != nil {
+		return nil, err
+	}
+	if voted {
+		return nil, domain.ErrAlreadyVoted
+	}
+
+	// 3. Votes et compteurs
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO poll_votes (post_id, user_id, position, created_at)
+		SELECT $1, $2, c, $4 FROM unnest($3::smallint[]) AS c
+	`, postID, userID, choices, now); err != nil {
+		return nil, fmt.Errorf("failed to insert votes: %w", err)
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE poll_options SET votes_count = votes_count + 1 WHERE post_id = $1 AND position = ANY($2::smallint[])`,
+		postID, choices,
+	); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE polls SET voters_count = voters_count + 1 WHERE post_id = $1`,
+		postID,
+	); err != nil {
+		return nil, err
+	}
+
+	// 4. Résultats à jour (lus dans la transaction)
+	polls, err := readPolls(ctx, tx, []string{postID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return polls[postID], nil
+}
+
+// CloseDue clôture au plus 'limit' sondages échus et renvoie leur nombre.
+// FOR UPDATE SKIP LOCKED : plusieurs réplicas peuvent tourner en parallèle sans clôturer deux fois,
+// et un sondage en cours de vote est simplement repris au passage suivant.
+// Les posts supprimés entre-temps (ou pas encore publiés) sont clôturés sans être annoncés.
+func (r *PollRepo) CloseDue(ctx context.Context, now time.Time, limit int, announce ports.PostAnnouncer) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		WITH closed AS (
+			UPDATE polls SET closed_at = closes_at
+			WHERE post_id IN (
+				SELECT post_id FROM polls
+				WHERE closed_at IS NULL AND closes_at <= $1
+				ORDER BY closes_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING post_id
+		)
+		SELECT c.post_id, p.user_id, p.status = 'published' AND p.deleted_at IS NULL
+		FROM closed c JOIN posts p ON p.id = c.post_id
+	`, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	closed := 0
+	var live []*domain.Post
+	for rows.Next() {
+		post := &domain.Post{}
+		var alive bool
+		if err := rows.Scan(&post.ID, &post.UserID, &alive); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		closed++
+		if alive {
+			live = append(live, post)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if closed == 0 {
+		return 0, nil
+	}
+
+	if len(live) > 0 {
+		ids := make([]string, len(live))
+		for i, p := range live {
+			ids[i] = p.ID
+		}
+		// Lus dans la transaction : closed_at (et donc Poll.ClosedAt) est déjà posé
+		polls, err := readPolls(ctx, tx, ids)
+		if err != nil {
+			return 0, err
+		}
+		for _, p := range live {
+			p.Poll = polls[p.ID]
+		}
+
+		events, err := announce(live)
+		if err != nil {
+			return 0, err
+		}
+		if err := insertOutbox(ctx, tx, events); err != nil {
+			return 0, fmt.Errorf("failed to write outbox: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return closed, nil
+}
+
+// --- Helpers ---
+
+// querier : pool ou transaction
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// savePoll : appelé par PostgresRepo.Save, dans la transaction du post
+func savePoll(ctx context.Context, tx pgx.Tx, post *domain.Post) error {
+	poll := post.Poll
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO polls (post_id, multiple_choice, closes_at) VALUES ($1, $2, $3)`,
+		post.ID, poll.MultipleChoice, poll.ClosesAt,
+	); err != nil {
+		return err
+	}
+
+	labels := make([]string, len(poll.Options))
+	for i, o := range poll.Options {
+		labels[i] = o.Label
+	}
+	// WITH ORDINALITY commence à 1, les positions à 0
+	_, err := tx.Exec(ctx, `
+		INSERT INTO poll_options (post_id, position, label)
+		SELECT $1, o.n - 1, o.label FROM unnest($2::text[]) WITH ORDINALITY AS o(label, n)
+	`, post.ID, labels)
+	return err
+}
+
+func readPolls(ctx context.Context, q querier, postIDs []string) (map[string]*domain.Poll, error) {
+	polls := make(map[string]*domain.Poll)
+
+	rows, err := q.Query(ctx,
+		`SELECT post_id, multiple_choice, closes_at, closed_at, voters_count FROM polls WHERE post_id = ANY($1::uuid[])`,
+		postIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var p domain.Poll
+		var closedAt *time.Time
+		if err := rows.Scan(&p.PostID, &p.MultipleChoice, &p.ClosesAt, &closedAt, &p.VotersCount); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if closedAt != nil {
+			p.ClosedAt = *closedAt
+		}
+		p.Options = []domain.PollOption{}
+		polls[p.PostID] = &p
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(polls) == 0 {
+		return polls, nil
+	}
+
+	rows, err = q.Query(ctx,
+		`SELECT post_id, label, votes_count FROM poll_options WHERE post_id = ANY($1::uuid[]) ORDER BY post_id, position`,
+		postIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var postID string
+		var o domain.PollOption
+		if err := rows.Scan(&postID, &o.Label, &o.VotesCount); err != nil {
+			return nil, err
+		}
+		if p, ok := polls[postID]; ok {
+			p.Options = append(p.Options, o)
+		}
+	}
+	return polls, rows.Err()
+}
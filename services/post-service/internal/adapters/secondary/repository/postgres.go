@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
 	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
@@ -14,11 +16,19 @@ import (
 
 // DTO interne pour mapper le JSONB proprement sans polluer le Domain avec des tags JSON
 type mediaDTO struct {
-	ID   string `json:"id"`
-	URL  string `json:"url"`
-	Type string `json:"type"`
+	ID        string `json:"id"`
+	URL       string `json:"url"`
+	Type      string `json:"type"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+	Blurhash  string `json:"blurhash,omitempty"`
 }
 
+// Colonnes lues pour hydrater un domain.Post (l'ordre doit suivre scanPost/scanPostRows)
+const postColumns = `id, user_id, content, content_warning, media, language, status, publish_at, edited_at, deleted_at, hidden_at, pinned_at, visibility, entities, COALESCE(reposted_post_id::text, ''), reposts_count, comments_count, reaction_counts, link_preview, created_at, updated_at, COALESCE(thread_id::text, ''), thread_position, marked_sensitive, moderator_warning`
+
+// Même liste, préfixée par l'alias "p" (requêtes avec jointure)
+const prefixedPostColumns = `p.id, p.user_id, p.content, p.content_warning, p.media, p.language, p.status, p.publish_at, p.edited_at, p.deleted_at, p.hidden_at, p.pinned_at, p.visibility, p.entities, COALESCE(p.reposted_post_id::text, ''), p.reposts_count, p.comments_count, p.reaction_counts, p.link_preview, p.created_at, p.updated_at, COALESCE(p.thread_id::text, ''), p.thread_position, p.marked_sensitive, p.moderator_warning`
+
 type PostgresRepo struct {
 	db *pgxpool.Pool
 }
@@ -27,17 +37,57 @@ func NewPostgresRepo(db *pgxpool.Pool) ports.PostRepository {
 	return &PostgresRepo{db: db}
 }
 
-// Save : Insertion simple
-func (r *PostgresRepo) Save(ctx context.Context, post *domain.Post) error {
+// Save : Insertion (+ compteur de l'original pour un repost et sondage éventuel, dans la même transaction)
+func (r *PostgresRepo) Save(ctx context.Context, post *domain.Post, events ...*domain.OutboxMessage) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // No-op si Commit a réussi
+
+	if err := insertPost(ctx, tx, post); err != nil {
+		return err
+	}
+
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SaveThread : un fil paraît en entier ou pas du tout
+func (r *PostgresRepo) SaveThread(ctx context.Context, posts []*domain.Post, events ...*domain.OutboxMessage) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, post := range posts {
+		if err := insertPost(ctx, tx, post); err != nil {
+			return err
+		}
+	}
+
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertPost : le post et ce qui en dépend (compteur de l'original, entités, aperçu de lien, sondage)
+func insertPost(ctx context.Context, tx pgx.Tx, post *domain.Post) error {
 	query := `
-		INSERT INTO posts (id, user_id, content, media, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO posts (id, user_id, content, media, language, status, publish_at, visibility, entities, reposted_post_id, created_at, updated_at, content_warning, thread_id, thread_position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, '')::uuid, $11, $12, $13, NULLIF($14, '')::uuid, $15)
 	`
 
 	// Mapping Domain -> JSONB DTO
 	medias := make([]mediaDTO, len(post.Media))
 	for i, m := range post.Media {
-		medias[i] = mediaDTO{ID: m.ID, URL: m.URL, Type: string(m.Type)}
+		medias[i] = mediaDTO{ID: m.ID, URL: m.URL, Type: string(m.Type), Sensitive: m.Sensitive, Blurhash: m.Blurhash}
 	}
 
 	// Postgres driver gère auto le marshalling si on passe un []byte ou string,
@@ -46,33 +96,98 @@ func (r *PostgresRepo) Save(ctx context.Context, post *domain.Post) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal media: %w", err)
 	}
+	entitiesJSON, err := marshalEntities(post.Entities)
+	if err != nil {
+		return fmt.Errorf("failed to marshal entities: %w", err)
+	}
+
+	if post.RepostedPostID != "" {
+		tag, err := tx.Exec(ctx,
+			`UPDATE posts SET reposts_count = reposts_count + 1 WHERE id = $1 AND deleted_at IS NULL`,
+			post.RepostedPostID,
+		)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrPostNotFound
+		}
+	}
 
-	_, err = r.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		post.ID,
 		post.UserID,
 		post.Content,
 		mediaJSON,
+		post.Language,
+		string(postStatus(post)),
+		nullableTime(post.PublishAt),
+		string(post.Visibility),
+		entitiesJSON,
+		post.RepostedPostID,
 		post.CreatedAt,
 		post.UpdatedAt,
+		post.ContentWarning,
+		post.ThreadID,
+		post.ThreadPosition,
 	)
-	return err
+	if err != nil {
+		var pgErr *pgconn.PgError
+		// Code 23505 = Unique Violation (idx_posts_unique_repost)
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrAlreadyReposted
+		}
+		return err
+	}
+
+	if err := writeEntities(ctx, tx, post); err != nil {
+		return fmt.Errorf("failed to save entities: %w", err)
+	}
+
+	if err := queueLinkPreview(ctx, tx, post); err != nil {
+		return fmt.Errorf("failed to queue link preview: %w", err)
+	}
+
+	if post.Poll != nil {
+		if err := savePoll(ctx, tx, post); err != nil {
+			return fmt.Errorf("failed to save poll: %w", err)
+		}
+	}
+
+	if err := openReview(ctx, tx, post); err != nil {
+		return fmt.Errorf("failed to open moderation case: %w", err)
+	}
+	return nil
 }
 
-// FindByID : Récupération unitaire
+// FindByID : Récupération unitaire (un post supprimé est introuvable, cf. FindDeleted)
 func (r *PostgresRepo) FindByID(ctx context.Context, postID string) (*domain.Post, error) {
-	query := `SELECT id, user_id, content, media, created_at, updated_at FROM posts WHERE id = $1`
+	query := `SELECT ` + postColumns + ` FROM posts WHERE id = $1 AND deleted_at IS NULL`
 
 	row := r.db.QueryRow(ctx, query, postID)
 	return r.scanPost(row)
 }
 
+// FindThread : les posts supprimés disparaissent du fil (la numérotation d'origine est conservée)
+func (r *PostgresRepo) FindThread(ctx context.Context, threadID string) ([]*domain.Post, error) {
+	query := `SELECT ` + postColumns + ` FROM posts WHERE thread_id = $1 AND deleted_at IS NULL ORDER BY thread_position`
+
+	rows, err := r.db.Query(ctx, query, threadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.collectRows(rows)
+}
+
 // GetPosts : BATCH FETCH (Hydratation Feed)
-// Utilise WHERE id = ANY($1) pour récupérer plusieurs posts en une seule requête SQL
+// Utilise WHERE id = ANY($1) pour récupérer plusieurs posts en une seule requête SQL.
+// Les brouillons et posts planifiés n'existent pas pour le Feed.
 func (r *PostgresRepo) GetPosts(ctx context.Context, postIDs []string) ([]*domain.Post, error) {
 	query := `
-		SELECT id, user_id, content, media, created_at, updated_at 
+		SELECT ` + postColumns + `
 		FROM posts 
-		WHERE id = ANY($1)
+		WHERE id = ANY($1) AND status = 'published' AND deleted_at IS NULL
 	`
 
 	rows, err := r.db.Query(ctx, query, postIDs)
@@ -94,14 +209,14 @@ func (r *PostgresRepo) GetPosts(ctx context.Context, postIDs []string) ([]*domai
 
 // ListByAuthor : PAGINATION KEYSET (Cursor-based)
 // C'est la méthode experte pour éviter "OFFSET 50000" qui tue la DB.
-// cursorTime est la date du dernier post vu.
+// cursorTime est la date du dernier post vu. Les posts épinglés sont exclus (cf. ListPinned).
 func (r *PostgresRepo) ListByAuthor(ctx context.Context, authorID string, limit int, cursorTime time.Time) ([]*domain.Post, error) {
 	// Cas 1: Première page (pas de curseur)
 	if cursorTime.IsZero() {
 		query := `
-			SELECT id, user_id, content, media, created_at, updated_at 
+			SELECT ` + postColumns + `
 			FROM posts 
-			WHERE user_id = $1 
+			WHERE user_id = $1 AND status = 'published' AND deleted_at IS NULL AND pinned_at IS NULL
 			ORDER BY created_at DESC 
 			LIMIT $2
 		`
@@ -115,9 +230,9 @@ func (r *PostgresRepo) ListByAuthor(ctx context.Context, authorID string, limit
 
 	// Cas 2: Page suivante (on cherche ce qui est plus vieux que le curseur)
 	query := `
-		SELECT id, user_id, content, media, created_at, updated_at 
+		SELECT ` + postColumns + `
 		FROM posts 
-		WHERE user_id = $1 AND created_at < $2
+		WHERE user_id = $1 AND status = 'published' AND deleted_at IS NULL AND pinned_at IS NULL AND created_at < $2
 		ORDER BY created_at DESC 
 		LIMIT $3
 	`
@@ -129,57 +244,219 @@ func (r *PostgresRepo) ListByAuthor(ctx context.Context, authorID string, limit
 	return r.collectRows(rows)
 }
 
-func (r *PostgresRepo) Update(ctx context.Context, post *domain.Post) error {
+// ListAllByAuthor : sans filtre de statut, de suppression ni d'épinglage. Keyset sur (created_at, id) :
+// un fil publié d'un bloc partage sa date, aucune page ne doit en perdre un post.
+func (r *PostgresRepo) ListAllByAuthor(ctx context.Context, authorID string, limit int, after *domain.KeysetCursor) ([]*domain.Post, error) {
+	query := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE user_id = $1 AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`
+	var afterTime *time.Time
+	var afterID *string
+	if after != nil {
+		afterTime, afterID = &after.At, &after.ID
+	}
+	rows, err := r.db.Query(ctx, query, authorID, afterTime, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.collectRows(rows)
+}
+
+func (r *PostgresRepo) Update(ctx context.Context, post *domain.Post, events ...*domain.OutboxMessage) error {
+	// Une décision mark_sensitive prise entre la lecture du post et cette écriture n'est pas perdue
+	// (cf. domain.Post.KeepModeratorFlags, qui l'applique à la version lue)
 	query := `
 		UPDATE posts 
-		SET content = $1, media = $2, updated_at = $3 
-		WHERE id = $4
+		SET content = $1, language = $3, visibility = $4, entities = $5, updated_at = $6, edited_at = $7,
+			media = CASE WHEN marked_sensitive THEN (
+					SELECT COALESCE(jsonb_agg(m || '{"sensitive": true}'::jsonb ORDER BY i), '[]'::jsonb)
+					FROM jsonb_array_elements($2::jsonb) WITH ORDINALITY AS t(m, i)
+				) ELSE $2::jsonb END,
+			content_warning = COALESCE(NULLIF(moderator_warning, ''), $9),
+			status = CASE WHEN $10 THEN 'held' ELSE status END
+		WHERE id = $8
 	`
 	// Réutilisation de la logique de marshalling JSON des médias
 	medias := make([]mediaDTO, len(post.Media))
 	for i, m := range post.Media {
-		medias[i] = mediaDTO{ID: m.ID, URL: m.URL, Type: string(m.Type)}
+		medias[i] = mediaDTO{ID: m.ID, URL: m.URL, Type: string(m.Type), Sensitive: m.Sensitive, Blurhash: m.Blurhash}
 	}
 	mediaJSON, _ := json.Marshal(medias)
+	entitiesJSON, err := marshalEntities(post.Entities)
+	if err != nil {
+		return fmt.Errorf("failed to marshal entities: %w", err)
+	}
 
-	cmdTag, err := r.db.Exec(ctx, query, post.Content, mediaJSON, post.UpdatedAt, post.ID)
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Historique : la version courante (lue sous verrou, dans la transaction) devient une révision
+	if post.IsEdited() {
+		if err := saveRevision(ctx, tx, post.ID, post.EditedAt); err != nil {
+			return fmt.Errorf("failed to save revision: %w", err)
+		}
+	}
+
+	// Une version retenue par la modération automatique ne fait que retenir le post (jamais l'inverse)
+	cmdTag, err := tx.Exec(ctx, query, post.Content, mediaJSON, post.Language, string(post.Visibility), entitiesJSON, post.UpdatedAt, nullableTime(post.EditedAt), post.ID, post.ContentWarning, post.Review != nil)
 	if err != nil {
 		return err
 	}
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("post not found")
+		return domain.ErrPostNotFound
 	}
-	return nil
+
+	// Hashtags / mentions de la nouvelle version
+	if err := writeEntities(ctx, tx, post); err != nil {
+		return fmt.Errorf("failed to save entities: %w", err)
+	}
+
+	// Nouveau lien = nouvel aperçu
+	if err := queueLinkPreview(ctx, tx, post); err != nil {
+		return fmt.Errorf("failed to queue link preview: %w", err)
+	}
+
+	if err := openReview(ctx, tx, post); err != nil {
+		return fmt.Errorf("failed to open moderation case: %w", err)
+	}
+
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+
+	return tx.Commit(ctx)
 }
 
-func (r *PostgresRepo) Delete(ctx context.Context, postID string) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM posts WHERE id = $1", postID)
-	return err
+func (r *PostgresRepo) Delete(ctx context.Context, postID string, events ...*domain.OutboxMessage) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Un repost pur n'a plus de sens sans son original (les citations, elles, gardent leur texte).
+	// À faire AVANT de supprimer l'original : ON DELETE SET NULL effacerait le lien qui permet de les retrouver.
+	if _, err := tx.Exec(ctx,
+		`DELETE FROM posts WHERE reposted_post_id = $1 AND content = '' AND media = '[]'::jsonb`,
+		postID,
+	); err != nil {
+		return err
+	}
+
+	var repostedPostID string
+	err = tx.QueryRow(ctx,
+		`DELETE FROM posts WHERE id = $1 RETURNING COALESCE(reposted_post_id::text, '')`,
+		postID,
+	).Scan(&repostedPostID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil // Déjà supprimé : idempotent (déjà annoncé)
+		}
+		return err
+	}
+
+	if repostedPostID != "" {
+		if _, err := tx.Exec(ctx,
+			`UPDATE posts SET reposts_count = GREATEST(reposts_count - 1, 0) WHERE id = $1`,
+			repostedPostID,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FindRepost : le repost pur (unique) d'un post par un utilisateur
+func (r *PostgresRepo) FindRepost(ctx context.Context, userID, originalID string) (*domain.Post, error) {
+	query := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE user_id = $1 AND reposted_post_id = $2 AND content = '' AND media = '[]'::jsonb AND deleted_at IS NULL
+	`
+	p, err := r.scanPost(r.db.QueryRow(ctx, query, userID, originalID))
+	if errors.Is(err, domain.ErrPostNotFound) {
+		return nil, domain.ErrRepostNotFound
+	}
+	return p, err
 }
 
 // --- Helpers pour éviter la duplication de code ---
 
 func (r *PostgresRepo) scanPost(row pgx.Row) (*domain.Post, error) {
 	var p domain.Post
-	var mediaJSON []byte
+	var mediaJSON, entitiesJSON, reactionsJSON, previewJSON []byte
+
+	var publishAt, editedAt, deletedAt, hiddenAt, pinnedAt *time.Time
 
-	if err := row.Scan(&p.ID, &p.UserID, &p.Content, &mediaJSON, &p.CreatedAt, &p.UpdatedAt); err != nil {
+	if err := row.Scan(&p.ID, &p.UserID, &p.Content, &p.ContentWarning, &mediaJSON, &p.Language, &p.Status, &publishAt, &editedAt, &deletedAt, &hiddenAt, &pinnedAt, &p.Visibility, &entitiesJSON, &p.RepostedPostID, &p.RepostsCount, &p.CommentsCount, &reactionsJSON, &previewJSON, &p.CreatedAt, &p.UpdatedAt, &p.ThreadID, &p.ThreadPosition, &p.MarkedSensitive, &p.ModeratorWarning); err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("post not found") // Ou une erreur sentinel domain.ErrNotFound
+			return nil, domain.ErrPostNotFound
 		}
 		return nil, err
 	}
+	if publishAt != nil {
+		p.PublishAt = *publishAt
+	}
+	if editedAt != nil {
+		p.EditedAt = *editedAt
+	}
+	if deletedAt != nil {
+		p.DeletedAt = *deletedAt
+	}
+	if hiddenAt != nil {
+		p.HiddenAt = *hiddenAt
+	}
+	if pinnedAt != nil {
+		p.PinnedAt = *pinnedAt
+	}
 	p.Media = r.unmarshalMedia(mediaJSON)
+	p.Entities = unmarshalEntities(entitiesJSON)
+	p.ReactionCounts = unmarshalReactionCounts(reactionsJSON)
+	p.LinkPreview = unmarshalLinkPreview(previewJSON)
 	return &p, nil
 }
 
-func (r *PostgresRepo) scanPostRows(rows pgx.Rows) (*domain.Post, error) {
+// scanPostRows : 'extra' reçoit les colonnes lues après postColumns (ex: score de recherche)
+func (r *PostgresRepo) scanPostRows(rows pgx.Rows, extra ...any) (*domain.Post, error) {
 	var p domain.Post
-	var mediaJSON []byte
-	if err := rows.Scan(&p.ID, &p.UserID, &p.Content, &mediaJSON, &p.CreatedAt, &p.UpdatedAt); err != nil {
+	var mediaJSON, entitiesJSON, reactionsJSON, previewJSON []byte
+	var publishAt, editedAt, deletedAt, hiddenAt, pinnedAt *time.Time
+	dest := []any{&p.ID, &p.UserID, &p.Content, &p.ContentWarning, &mediaJSON, &p.Language, &p.Status, &publishAt, &editedAt, &deletedAt, &hiddenAt, &pinnedAt, &p.Visibility, &entitiesJSON, &p.RepostedPostID, &p.RepostsCount, &p.CommentsCount, &reactionsJSON, &previewJSON, &p.CreatedAt, &p.UpdatedAt, &p.ThreadID, &p.ThreadPosition, &p.MarkedSensitive, &p.ModeratorWarning}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
 		return nil, err
 	}
+	if publishAt != nil {
+		p.PublishAt = *publishAt
+	}
+	if editedAt != nil {
+		p.EditedAt = *editedAt
+	}
+	if deletedAt != nil {
+		p.DeletedAt = *deletedAt
+	}
+	if hiddenAt != nil {
+		p.HiddenAt = *hiddenAt
+	}
+	if pinnedAt != nil {
+		p.PinnedAt = *pinnedAt
+	}
 	p.Media = r.unmarshalMedia(mediaJSON)
+	p.Entities = unmarshalEntities(entitiesJSON)
+	p.ReactionCounts = unmarshalReactionCounts(reactionsJSON)
+	p.LinkPreview = unmarshalLinkPreview(previewJSON)
 	return &p, nil
 }
 
@@ -204,9 +481,11 @@ func (r *PostgresRepo) unmarshalMedia(data []byte) []domain.Media {
 	domainMedias := make([]domain.Media, len(dtos))
 	for i, d := range dtos {
 		domainMedias[i] = domain.Media{
-			ID:   d.ID,
-			URL:  d.URL,
-			Type: domain.MediaType(d.Type),
+			ID:        d.ID,
+			URL:       d.URL,
+			Type:      domain.MediaType(d.Type),
+			Sensitive: d.Sensitive,
+			Blurhash:  d.Blurhash,
 		}
 	}
 	return domainMedias
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// bumpReactionCount : +delta sur une clé du JSONB, la clé disparaît quand le compteur tombe à 0
+const bumpReactionCount = `
+	UPDATE posts SET reaction_counts = CASE
+		WHEN COALESCE((reaction_counts->>$2)::int, 0) + $3 <= 0 THEN reaction_counts - $2
+		ELSE reaction_counts || jsonb_build_object($2::text, COALESCE((reaction_counts->>$2)::int, 0) + $3)
+	END
+	WHERE id = $1
+`
+
+type ReactionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewReactionRepo(db *pgxpool.Pool) ports.ReactionRepository {
+	return &ReactionRepo{db: db}
+}
+
+// SetReaction : upsert + compteurs + outbox dans la même transaction
+func (r *ReactionRepo) SetReaction(ctx context.Context, reaction *domain.Reaction, announce ports.ReactionAnnouncer) (*domain.ReactionSummary, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // No-op si Commit a réussi
+
+	// 1. Verrou sur la ligne du post : sérialise les réactions concurrentes (compteurs exacts).
+	// Un post masqué ou dépublié depuis la vérification de visibilité ne reçoit plus de réactions.
+	summary := &domain.ReactionSummary{PostID: reaction.PostID, Current: reaction.Kind}
+	err = tx.QueryRow(ctx,
+		`SELECT user_id FROM posts WHERE id = $1 AND status = 'published' AND hidden_at IS NULL AND deleted_at IS NULL FOR UPDATE`,
+		reaction.PostID,
+	).Scan(&summary.PostAuthorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPostNotFound
+		}
+		return nil, err
+	}
+
+	// 2. Réaction précédente (changer de réaction = décrémenter l'ancienne)
+	err = tx.QueryRow(ctx,
+		`SELECT kind FROM post_reactions WHERE post_id = $1 AND user_id = $2`,
+		reaction.PostID, reaction.UserID,
+	).Scan(&summary.Previous)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	if summary.Changed() {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO post_reactions (post_id, user_id, kind, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (post_id, user_id) DO UPDATE SET kind = EXCLUDED.kind, created_at = EXCLUDED.created_at
+		`, reaction.PostID, reaction.UserID, string(reaction.Kind), reaction.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to upsert reaction: %w", err)
+		}
+
+		if summary.Previous != "" {
+			if _, err := tx.Exec(ctx, bumpReactionCount, reaction.PostID, string(summary.Previous), -1); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := tx.Exec(ctx, bumpReactionCount, reaction.PostID, string(reaction.Kind), 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if summary.Counts, err = readReactionCounts(ctx, tx, reaction.PostID); err != nil {
+		return nil, err
+	}
+
+	events, err := announce(summary)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return nil, fmt.Errorf("failed to write outbox: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// RemoveReaction : idempotent (retirer une réaction absente n'est pas une erreur)
+func (r *ReactionRepo) RemoveReaction(ctx context.Context, postID, userID string) (*domain.ReactionSummary, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	summary := &domain.ReactionSummary{PostID: postID}
+	err = tx.QueryRow(ctx,
+		`SELECT user_id FROM posts WHERE id = $1 FOR UPDATE`,
+		postID,
+	).Scan(&summary.PostAuthorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPostNotFound
+		}
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx,
+		`DELETE FROM post_reactions WHERE post_id = $1 AND user_id = $2 RETURNING kind`,
+		postID, userID,
+	).Scan(&summary.Previous)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	if summary.Previous != "" {
+		if _, err := tx.Exec(ctx, bumpReactionCount, postID, string(summary.Previous), -1); err != nil {
+			return nil, err
+		}
+	}
+
+	if summary.Counts, err = readReactionCounts(ctx, tx, postID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// ViewerReactions : BATCH (une requête pour toute une page de Feed)
+func (r *ReactionRepo) ViewerReactions(ctx context.Context, viewerID string, postIDs []string) (map[string]domain.ReactionKind, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT post_id, kind FROM post_reactions WHERE user_id = $1 AND post_id = ANY($2::uuid[])`,
+		viewerID, postIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reactions := make(map[string]domain.ReactionKind)
+	for rows.Next() {
+		var postID string
+		var kind domain.ReactionKind
+		if err := rows.Scan(&postID, &kind); err != nil {
+			return nil, err
+		}
+		reactions[postID] = kind
+	}
+	return reactions, rows.Err()
+}
+
+// --- Helpers ---
+
+func readReactionCounts(ctx context.Context, tx pgx.Tx, postID string) (map[domain.ReactionKind]int, error) {
+	var raw []byte
+	if err := tx.QueryRow(ctx, `SELECT reaction_counts FROM posts WHERE id = $1`, postID).Scan(&raw); err != nil {
+		return nil, err
+	}
+	return unmarshalReactionCounts(raw), nil
+}
+
+func unmarshalReactionCounts(data []byte) map[domain.ReactionKind]int {
+	counts := map[domain.ReactionKind]int{}
+	if len(data) == 0 {
+		return counts
+	}
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return map[domain.ReactionKind]int{} // Fallback safe
+	}
+	return counts
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// ListRevisions : PAGINATION KEYSET sur replaced_at (versions les plus récentes d'abord)
+func (r *PostgresRepo) ListRevisions(ctx context.Context, postID string, limit int, cursorTime time.Time) ([]*domain.PostRevision, error) {
+	// Cas 1: Première page (pas de curseur)
+	query := `
+		SELECT id, post_id, content, media, created_at, replaced_at
+		FROM post_revisions
+		WHERE post_id = $1
+		ORDER BY replaced_at DESC
+		LIMIT $2
+	`
+	args := []any{postID, limit}
+
+	// Cas 2: Page suivante
+	if !cursorTime.IsZero() {
+		query = `
+			SELECT id, post_id, content, media, created_at, replaced_at
+			FROM post_revisions
+			WHERE post_id = $1 AND replaced_at < $2
+			ORDER BY replaced_at DESC
+			LIMIT $3
+		`
+		args = []any{postID, cursorTime, limit}
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*domain.PostRevision{}
+	for rows.Next() {
+		var rev domain.PostRevision
+		var mediaJSON []byte
+		if err := rows.Scan(&rev.ID, &rev.PostID, &rev.Content, &mediaJSON, &rev.CreatedAt, &rev.ReplacedAt); err != nil {
+			return nil, err
+		}
+		rev.Media = r.unmarshalMedia(mediaJSON)
+		revisions = append(revisions, &rev)
+	}
+	return revisions, rows.Err()
+}
+
+// saveRevision archive la version courante du post, dans la transaction de l'édition.
+// La ligne est verrouillée d'abord : deux éditions concurrentes archivent chacune la version
+// qu'elles remplacent réellement (pas deux fois la même).
+func saveRevision(ctx context.Context, tx pgx.Tx, postID string, replacedAt time.Time) error {
+	var locked int
+	err := tx.QueryRow(ctx, `SELECT 1 FROM posts WHERE id = $1 FOR UPDATE`, postID).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPostNotFound
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_revisions (post_id, content, media, created_at, replaced_at)
+		SELECT id, content, media, COALESCE(edited_at, created_at), $2
+		FROM posts WHERE id = $1
+	`, postID, replacedAt)
+	return err
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// Délimiteurs temporaires de ts_headline : des caractères de contrôle qu'un post ne contient pas,
+// remplacés par <mark> APRÈS échappement HTML du contenu (cf. highlightSnippet)
+const (
+	snippetStartSel = "\x02"
+	snippetStopSel  = "\x03"
+)
+
+const snippetOptions = `StartSel="` + snippetStartSel + `", StopSel="` + snippetStopSel + `", MaxFragments=2, MaxWords=25, MinWords=8, FragmentDelimiter=" … "`
+
+// Configuration linguistique d'un post (même CASE que la colonne générée search_vector)
+const postSearchConfig = `CASE p.language WHEN 'fr' THEN 'french'::regconfig WHEN 'en' THEN 'english'::regconfig ELSE 'simple'::regconfig END`
+
+// SearchPosts : PAGINATION KEYSET sur (rank, id), rank décroissant.
+// La requête est analysée dans chaque langue supportée : un post français matche la version
+// "french" (stemming), un post de langue inconnue la version "simple".
+// ts_headline (coûteux) n'est calculé que pour la page renvoyée.
+func (r *PostgresRepo) SearchPosts(ctx context.Context, q domain.SearchQuery, terms []domain.SearchTerm, limit int, after *domain.SearchCursor) ([]*domain.SearchResult, error) {
+	var afterRank *float32
+	var afterID *string
+	if after != nil {
+		afterRank, afterID = &after.Rank, &after.PostID
+	}
+
+	query := `
+		WITH q AS (
+			SELECT to_tsquery('simple', $1) || to_tsquery('french', $1) || to_tsquery('english', $1) AS query
+		),
+		page AS (
+			SELECT p.*, ts_rank_cd(p.search_vector, q.query) AS rank
+			FROM posts p, q
+			WHERE p.search_vector @@ q.query
+			  AND p.status = 'published' AND p.deleted_at IS NULL
+			  AND ($2 = '' OR p.user_id = $2)
+			  AND ($3 = '' OR p.media @> jsonb_build_array(jsonb_build_object('type', $3::text)))
+			  AND ($4::timestamptz IS NULL OR p.created_at >= $4)
+			  AND ($5::timestamptz IS NULL OR p.created_at < $5)
+			  AND ($6::real IS NULL OR (ts_rank_cd(p.search_vector, q.query), p.id) < ($6::real, $7::uuid))
+			ORDER BY rank DESC, p.id DESC
+			LIMIT $8
+		)
+		SELECT ` + prefixedPostColumns + `, p.rank, ts_headline(` + postSearchConfig + `, p.content, q.query, $9)
+		FROM page p, q
+		ORDER BY p.rank DESC, p.id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query,
+		buildTSQuery(terms),
+		q.AuthorID,
+		string(q.MediaType),
+		nullableTime(q.Since),
+		nullableTime(q.Until),
+		afterRank,
+		afterID,
+		limit,
+		snippetOptions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*domain.SearchResult{}
+	for rows.Next() {
+		var rank float32
+		var snippet string
+		post, err := r.scanPostRows(rows, &rank, &snippet)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &domain.SearchResult{
+			Post:    post,
+			Rank:    rank,
+			Snippet: highlightSnippet(snippet),
+		})
+	}
+	return results, rows.Err()
+}
+
+// buildTSQuery : ["recette"], ["tarte", "tatin"], ["chocol*"] -> "recette & (tarte <-> tatin) & chocol:*"
+// Les mots ne contiennent que lettres et chiffres (cf. domain.ParseSearchTerms) : pas d'échappement nécessaire.
+func buildTSQuery(terms []domain.SearchTerm) string {
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		words := make([]string, len(t.Words))
+		copy(words, t.Words)
+		if t.Prefix {
+			words[len(words)-1] += ":*"
+		}
+
+		if t.IsPhrase() {
+			parts[i] = "(" + strings.Join(words, " <-> ") + ")"
+		} else {
+			parts[i] = words[0]
+		}
+	}
+	return strings.Join(parts, " & ")
+}
+
+// highlightSnippet : échappe le contenu (saisi par les utilisateurs) puis pose les <mark>
+func highlightSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, snippetStartSel, "<mark>")
+	return strings.ReplaceAll(escaped, snippetStopSel, "</mark>")
+}
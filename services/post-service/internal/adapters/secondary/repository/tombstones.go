@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// FindDeleted : uniquement un post supprimé (pierre tombale, restauration)
+func (r *PostgresRepo) FindDeleted(ctx context.Context, postID string) (*domain.Post, error) {
+	query := `SELECT ` + postColumns + ` FROM posts WHERE id = $1 AND deleted_at IS NOT NULL`
+	return r.scanPost(r.db.QueryRow(ctx, query, postID))
+}
+
+// SoftDelete marque le post supprimé, supprime les reposts purs (annoncés avec lui, ID + auteur suffisent).
+// Compteur de l'original décrémenté dès maintenant (restauré par Restore). Idempotent.
+func (r *PostgresRepo) SoftDelete(ctx context.Context, post *domain.Post, announce ports.PostAnnouncer) error {
+	postID, deletedAt := post.ID, post.DeletedAt
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var repostedPostID string
+	err = tx.QueryRow(ctx,
+		`UPDATE posts SET deleted_at = $2, pinned_at = NULL WHERE id = $1 AND deleted_at IS NULL RETURNING COALESCE(reposted_post_id::text, '')`,
+		postID, deletedAt,
+	).Scan(&repostedPostID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil // Déjà supprimé : idempotent (déjà annoncé)
+		}
+		return err
+	}
+
+	if repostedPostID != "" {
+		if _, err := tx.Exec(ctx,
+			`UPDATE posts SET reposts_count = GREATEST(reposts_count - 1, 0) WHERE id = $1`,
+			repostedPostID,
+		); err != nil {
+			return err
+		}
+	}
+
+	// Un repost pur n'a plus de sens sans son original (pas de contenu à restaurer : suppression définitive)
+	rows, err := tx.Query(ctx,
+		`DELETE FROM posts WHERE reposted_post_id = $1 AND content = '' AND media = '[]'::jsonb RETURNING id, user_id`,
+		postID,
+	)
+	if err != nil {
+		return err
+	}
+	reposts := []*domain.Post{}
+	for rows.Next() {
+		repost := &domain.Post{RepostedPostID: postID, DeletedAt: deletedAt}
+		if err := rows.Scan(&repost.ID, &repost.UserID); err != nil {
+			rows.Close()
+			return err
+		}
+		reposts = append(reposts, repost)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Ces reposts ne reviendront pas avec une restauration : le compteur de l'original ne doit plus les compter
+	if len(reposts) > 0 {
+		if _, err := tx.Exec(ctx,
+			`UPDATE posts SET reposts_count = GREATEST(reposts_count - $2, 0) WHERE id = $1`,
+			postID, len(reposts),
+		); err != nil {
+			return err
+		}
+	}
+
+	events, err := announce(append([]*domain.Post{post}, reposts...))
+	if err != nil {
+		return err
+	}
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Restore annule une suppression douce (domain.ErrPostNotFound si le post n'est pas supprimé)
+func (r *PostgresRepo) Restore(ctx context.Context, postID string, events ...*domain.OutboxMessage) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var repostedPostID string
+	err = tx.QueryRow(ctx,
+		`UPDATE posts SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL RETURNING COALESCE(reposted_post_id::text, '')`,
+		postID,
+	).Scan(&repostedPostID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPostNotFound
+		}
+		return err
+	}
+
+	// Citation : l'original (s'il existe encore) retrouve le +1 retiré par SoftDelete, même s'il est
+	// lui-même supprimé (sa restauration doit retrouver un compteur exact)
+	if repostedPostID != "" {
+		if _, err := tx.Exec(ctx,
+			`UPDATE posts SET reposts_count = reposts_count + 1 WHERE id = $1`,
+			repostedPostID,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := insertOutbox(ctx, tx, events); err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// PurgeDeleted supprime définitivement au plus 'limit' posts supprimés avant 'before'.
+// Commentaires, réactions, révisions et entités partent en cascade (FK), les citations gardent
+// leur texte (reposted_post_id -> NULL). SKIP LOCKED : plusieurs réplicas peuvent purger en parallèle.
+func (r *PostgresRepo) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM posts
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE deleted_at IS NOT NULL AND deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,81 @@
+package viewers
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+const (
+	// bucketTTL : la cardinalité d'une heure est recopiée en base à chaque lot, la clé n'est utile
+	// que tant que des vues peuvent encore arriver sur la tranche (retards de lot compris)
+	bucketTTL = 48 * time.Hour
+	// totalTTL : repoussée à chaque vue. Un post sans vue pendant ce délai repart d'un HyperLogLog vide ;
+	// la base garde le maximum déjà atteint (GREATEST).
+	totalTTL = 90 * 24 * time.Hour
+)
+
+// RedisCounter : un HyperLogLog par post (depuis la publication) et par tranche horaire.
+// 12 Ko au plus par clé quel que soit le nombre de spectateurs, erreur standard ~0,81 %.
+type RedisCounter struct {
+	client *redis.Client
+}
+
+func NewRedisCounter(client *redis.Client) ports.ViewerCounter {
+	return &RedisCounter{client: client}
+}
+
+// CountViewers : deux allers-retours pour tout le lot (PFADD puis PFCOUNT, en pipeline).
+// Les deltas sans spectateur connecté gardent des cardinalités à zéro (inchangées en base).
+func (c *RedisCounter) CountViewers(ctx context.Context, deltas []domain.AnalyticsDelta) error {
+	var withViewers []int
+	pipe := c.client.Pipeline()
+	for i, d := range deltas {
+		if len(d.ViewerIDs) == 0 {
+			continue
+		}
+		withViewers = append(withViewers, i)
+
+		members := make([]any, len(d.ViewerIDs))
+		for j, id := range d.ViewerIDs {
+			members[j] = id
+		}
+		bucketKey, totalKey := keys(d)
+		pipe.PFAdd(ctx, bucketKey, members...)
+		pipe.Expire(ctx, bucketKey, bucketTTL)
+		pipe.PFAdd(ctx, totalKey, members...)
+		pipe.Expire(ctx, totalKey, totalTTL)
+	}
+	if len(withViewers) == 0 {
+		return nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	type counts struct{ bucket, total *redis.IntCmd }
+	results := make([]counts, len(withViewers))
+	pipe = c.client.Pipeline()
+	for n, i := range withViewers {
+		bucketKey, totalKey := keys(deltas[i])
+		results[n] = counts{bucket: pipe.PFCount(ctx, bucketKey), total: pipe.PFCount(ctx, totalKey)}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	for n, i := range withViewers {
+		deltas[i].BucketUniqueViewers = int(results[n].bucket.Val())
+		deltas[i].UniqueViewers = int(results[n].total.Val())
+	}
+	return nil
+}
+
+func keys(d domain.AnalyticsDelta) (bucket, total string) {
+	total = "post:analytics:" + d.PostID + ":viewers"
+	return total + ":" + d.Bucket.UTC().Format("2006010215"), total
+}
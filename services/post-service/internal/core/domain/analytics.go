@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Règles des statistiques
+const (
+	MaxPostEventsPerBatch = 500 // Événements par appel d'ingestion
+	AnalyticsBucketSize   = time.Hour
+	// DefaultAnalyticsRange : période par défaut de GetPostAnalytics (MaxAnalyticsRange au plus)
+	DefaultAnalyticsRange = 7 * 24 * time.Hour
+	MaxAnalyticsRange     = 90 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidPostEvent      = errors.New("invalid post event")
+	ErrTooManyPostEvents     = errors.New("too many post events in one batch")
+	ErrInvalidAnalyticsRange = errors.New("invalid analytics time range")
+)
+
+// PostEventKind : impression = post affiché (fil, profil...), view = post ouvert
+type PostEventKind string
+
+const (
+	PostImpression PostEventKind = "impression"
+	PostView       PostEventKind = "view"
+)
+
+func (k PostEventKind) IsValid() bool {
+	return k == PostImpression || k == PostView
+}
+
+// PostEvent : un événement remonté par un client (ViewerID vide = lecteur anonyme)
+type PostEvent struct {
+	PostID   string
+	ViewerID string
+	Reader   string // Cf. ReaderKey
+	Kind     PostEventKind
+	At       time.Time
+}
+
+// ReaderKey identifie le lecteur à l'origine d'un événement : son compte, à défaut sa session.
+// Un lecteur compte une fois par post, type d'événement et tranche horaire : rejouer un appel
+// ne gonfle pas les compteurs. Vide = lecteur non identifiable, ses événements ne sont pas comptés.
+func ReaderKey(viewerID, sessionID string) string {
+	switch {
+	case viewerID != "":
+		return "user:" + viewerID
+	case sessionID != "":
+		return "session:" + sessionID
+	}
+	return ""
+}
+
+// AnalyticsDelta : événements agrégés d'un post sur une tranche horaire, en attente d'écriture.
+// Les cardinalités sont renseignées par le compteur de spectateurs uniques (0 = inchangées).
+type AnalyticsDelta struct {
+	PostID      string
+	Bucket      time.Time
+	Impressions int
+	Views       int
+	ViewerIDs   []string // Spectateurs (vues) connectés, sans doublon
+
+	BucketUniqueViewers int // Sur la tranche
+	UniqueViewers       int // Depuis la publication
+}
+
+// AnalyticsBucket : activité d'un post sur une heure (UTC)
+type AnalyticsBucket struct {
+	Start         time.Time
+	Impressions   int
+	Views         int
+	UniqueViewers int
+	Reactions     int
+	Comments      int
+}
+
+// PostAnalytics : totaux depuis la publication, et détail horaire sur [From, To)
+type PostAnalytics struct {
+	PostID        string
+	Impressions   int
+	Views         int
+	UniqueViewers int // Estimation (HyperLogLog, ~1% d'erreur)
+	Reactions     int
+	Comments      int
+
+	From    time.Time
+	To      time.Time
+	Buckets []AnalyticsBucket // Heures sans activité omises
+}
+
+// BucketOf : début de la tranche horaire (UTC) d'un instant
+func BucketOf(t time.Time) time.Time {
+	return t.UTC().Truncate(AnalyticsBucketSize)
+}
+
+// AnalyticsWindow normalise la période demandée : bornes zéro = DefaultAnalyticsRange jusqu'à maintenant,
+// arrondies à l'heure (la tranche qui contient 'to' est incluse)
+func AnalyticsWindow(from, to, now time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = now
+	}
+	to = BucketOf(to).Add(AnalyticsBucketSize)
+	if from.IsZero() {
+		from = to.Add(-DefaultAnalyticsRange)
+	}
+	from = BucketOf(from)
+
+	if !from.Before(to) || to.Sub(from) > MaxAnalyticsRange {
+		return time.Time{}, time.Time{}, ErrInvalidAnalyticsRange
+	}
+	return from, to, nil
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsWindow(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 34, 56, 0, time.UTC)
+	hour := func(day, h int) time.Time { return time.Date(2026, 10, day, h, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  error
+	}{
+		{"bornes par défaut", time.Time{}, time.Time{}, hour(11, 13), hour(18, 13), nil},
+		{"l'heure de 'to' est incluse", hour(18, 8), hour(18, 10).Add(30 * time.Minute), hour(18, 8), hour(18, 11), nil},
+		{"'from' arrondi à l'heure", hour(18, 8).Add(59 * time.Minute), hour(18, 10), hour(18, 8), hour(18, 11), nil},
+		{"'from' seul : jusqu'à maintenant", hour(17, 0), time.Time{}, hour(17, 0), hour(18, 13), nil},
+		{"période inversée", hour(18, 10), hour(18, 8), time.Time{}, time.Time{}, ErrInvalidAnalyticsRange},
+		{"au-delà de la période maximale", now.Add(-MaxAnalyticsRange - 2*time.Hour), now, time.Time{}, time.Time{}, ErrInvalidAnalyticsRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := AnalyticsWindow(tt.from, tt.to, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("window = [%v, %v), want [%v, %v)", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestBucketOf(t *testing.T) {
+	paris := time.FixedZone("Europe/Paris", 2*60*60)
+	got := BucketOf(time.Date(2026, 10, 18, 14, 45, 0, 0, paris))
+	if want := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("BucketOf = %v, want %v (UTC)", got, want)
+	}
+}
+
+func TestReaderKey(t *testing.T) {
+	tests := []struct {
+		viewerID, sessionID, want string
+	}{
+		{"u1", "203.0.113.7", "user:u1"},
+		{"", "203.0.113.7", "session:203.0.113.7"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		if got := ReaderKey(tt.viewerID, tt.sessionID); got != tt.want {
+			t.Errorf("ReaderKey(%q, %q) = %q, want %q", tt.viewerID, tt.sessionID, got, tt.want)
+		}
+	}
+	// Un compte et une session de même valeur restent deux lecteurs distincts
+	if ReaderKey("x", "") == ReaderKey("", "x") {
+		t.Error("un compte et une session ne doivent pas se confondre")
+	}
+}
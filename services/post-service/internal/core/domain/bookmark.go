@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Règles des collections
+const (
+	MaxCollectionNameLength = 50 // En caractères
+	MaxCollections          = 100
+)
+
+var (
+	ErrCollectionNotFound     = errors.New("bookmark collection not found")
+	ErrInvalidCollectionName  = errors.New("invalid bookmark collection name")
+	ErrCollectionNameTaken    = errors.New("a bookmark collection with this name already exists")
+	ErrTooManyCollections     = errors.New("too many bookmark collections")
+	ErrInvalidCollectionOrder = errors.New("collection order must list each collection exactly once")
+)
+
+// Bookmark : post enregistré par un utilisateur (privé), classé ou non dans une collection
+type Bookmark struct {
+	UserID       string
+	PostID       string
+	CollectionID string // Vide = non classé
+	CreatedAt    time.Time
+
+	// Post : rempli à la lecture. Un post supprimé, masqué ou devenu invisible pour le propriétaire
+	// du signet est remplacé par sa pierre tombale (Unavailable) : le signet reste listé.
+	Post        *Post
+	Unavailable bool
+}
+
+// Collection : dossier de signets nommé par l'utilisateur
+type Collection struct {
+	ID             string
+	UserID         string
+	Name           string
+	Position       int // Ordre d'affichage (0 = première)
+	BookmarksCount int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewCollection crée une collection valide (factory). Position est attribuée par le repository (en dernier).
+func NewCollection(userID, name string, now time.Time) (*Collection, error) {
+	name, err := cleanCollectionName(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Collection{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Rename : même règles qu'à la création
+func (c *Collection) Rename(name string, now time.Time) error {
+	name, err := cleanCollectionName(name)
+	if err != nil {
+		return err
+	}
+	c.Name = name
+	c.UpdatedAt = now
+	return nil
+}
+
+// ValidateCollectionOrder : 'ordered' doit être une permutation des collections existantes
+func ValidateCollectionOrder(existing []*Collection, ordered []string) error {
+	if len(ordered) != len(existing) {
+		return ErrInvalidCollectionOrder
+	}
+	known := make(map[string]bool, len(existing))
+	for _, c := range existing {
+		known[c.ID] = true
+	}
+	for _, id := range ordered {
+		if !known[id] {
+			return ErrInvalidCollectionOrder
+		}
+		delete(known, id) // Un doublon ne trouve plus sa clé
+	}
+	return nil
+}
+
+func cleanCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxCollectionNameLength {
+		return "", ErrInvalidCollectionName
+	}
+	return name, nil
+}
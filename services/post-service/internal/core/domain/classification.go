@@ -0,0 +1,61 @@
+package domain
+
+import "errors"
+
+var (
+	ErrContentRejected = errors.New("content rejected by automated moderation")
+	ErrPostHeld        = errors.New("post is awaiting moderation review")
+	ErrNotHeld         = errors.New("post is not awaiting moderation review")
+)
+
+// AutomatedReporterID : "rapporteur" des dossiers ouverts par la modération automatique
+const AutomatedReporterID = "system:classifier"
+
+// Verdict : décision de la modération automatique avant publication
+type Verdict string
+
+const (
+	VerdictAllow  Verdict = "allow"  // Publication immédiate
+	VerdictReview Verdict = "review" // Retenu (PostStatusHeld) jusqu'à l'approbation d'un modérateur
+	VerdictReject Verdict = "reject" // Refusé, rien n'est enregistré
+)
+
+func (v Verdict) severity() int {
+	switch v {
+	case VerdictReject:
+		return 2
+	case VerdictReview:
+		return 1
+	}
+	return 0
+}
+
+// Classification : verdict d'un classifieur et ce qui l'a motivé
+type Classification struct {
+	Verdict Verdict
+	Labels  []string // Catégories détectées ("spam", "hate"...), les plus probables d'abord
+	Reason  string   // Lisible par un modérateur (règle déclenchée, score...)
+}
+
+// Allowed : aucune objection
+func Allowed() Classification {
+	return Classification{Verdict: VerdictAllow}
+}
+
+// Strictest combine deux avis : le plus sévère l'emporte (à égalité, le premier)
+func Strictest(a, b Classification) Classification {
+	if b.Verdict.severity() > a.Verdict.severity() {
+		return b
+	}
+	return a
+}
+
+// ReportReason : motif du dossier ouvert pour un post retenu (premier label reconnu, "other" sinon)
+func (c Classification) ReportReason() ReportReason {
+	for _, label := range c.Labels {
+		if r := ReportReason(label); r.IsValid() {
+			return r
+		}
+	}
+	return ReasonOther
+}
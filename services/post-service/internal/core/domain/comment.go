@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxCommentLength est la taille max d'un commentaire (en caractères, pas en octets)
+const MaxCommentLength = 2000
+
+// --- ERREURS DU DOMAINE ---
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrCommentForbidden = errors.New("not allowed to modify this comment")
+	ErrEmptyComment     = errors.New("comment content is empty")
+	ErrCommentTooLong   = errors.New("comment content is too long")
+	ErrInvalidParent    = errors.New("parent comment belongs to another post")
+)
+
+// Comment est un commentaire sur un post.
+// Les fils sont limités à un niveau : ParentID vide = racine, sinon ParentID pointe vers une racine.
+type Comment struct {
+	ID           string
+	PostID       string
+	ParentID     string
+	UserID       string
+	Content      string
+	RepliesCount int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	// Replies contient un aperçu des premières réponses (rempli uniquement à la lecture des racines)
+	Replies []*Comment
+}
+
+// NewComment crée un commentaire valide (factory)
+func NewComment(postID, parentID, userID, content string) (*Comment, error) {
+	content, err := validateCommentContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &Comment{
+		ID:        uuid.NewString(),
+		PostID:    postID,
+		ParentID:  parentID,
+		UserID:    userID,
+		Content:   content,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsReply indique si le commentaire est une réponse
+func (c *Comment) IsReply() bool {
+	return c.ParentID != ""
+}
+
+// Edit remplace le contenu (seul l'auteur peut éditer)
+func (c *Comment) Edit(userID, content string) error {
+	if c.UserID != userID {
+		return ErrCommentForbidden
+	}
+	content, err := validateCommentContent(content)
+	if err != nil {
+		return err
+	}
+	c.Content = content
+	c.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// CanBeDeletedBy : l'auteur du commentaire ou l'auteur du post (modération de ses propres fils)
+func (c *Comment) CanBeDeletedBy(userID, postAuthorID string) bool {
+	return userID == c.UserID || userID == postAuthorID
+}
+
+func validateCommentContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", ErrEmptyComment
+	}
+	if utf8.RuneCountInString(content) > MaxCommentLength {
+		return "", ErrCommentTooLong
+	}
+	return content, nil
+}
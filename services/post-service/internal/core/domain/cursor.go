@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+// KeysetCursor : position dans une liste triée par (date, ID) décroissants. L'ID départage les
+// éléments de même date : un curseur sur la seule date sauterait ceux qui la partagent.
+type KeysetCursor struct {
+	At time.Time
+	ID string
+}
+
+// Encode : jeton opaque pour le client
+func (c KeysetCursor) Encode() string {
+	raw := c.At.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeKeysetCursor : inverse de Encode. Jeton vide = première page (nil), corrompu = ErrInvalidPageToken.
+func DecodeKeysetCursor(token string) (*KeysetCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidPageToken
+	}
+	t, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return &KeysetCursor{At: t, ID: id}, nil
+}
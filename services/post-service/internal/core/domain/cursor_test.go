@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeysetCursorRoundTrip(t *testing.T) {
+	want := KeysetCursor{At: time.Date(2026, 10, 18, 12, 0, 0, 123456000, time.UTC), ID: "0b6f7f5e-3c1a-4c8e-9a52-2f1d1f0e8a11"}
+
+	got, err := DecodeKeysetCursor(want.Encode())
+	if err != nil {
+		t.Fatalf("DecodeKeysetCursor: %v", err)
+	}
+	if !got.At.Equal(want.At) || got.ID != want.ID {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeKeysetCursor(t *testing.T) {
+	if c, err := DecodeKeysetCursor(""); c != nil || err != nil {
+		t.Errorf("jeton vide = (%v, %v), want première page", c, err)
+	}
+
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+	tests := map[string]string{
+		"pas du base64":   "%%%",
+		"sans séparateur": encode("2026-10-18T12:00:00Z"),
+		"date invalide":   encode("hier|p1"),
+		"ID vide":         encode("2026-10-18T12:00:00Z|"),
+	}
+	for name, token := range tests {
+		if _, err := DecodeKeysetCursor(token); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("%s : err = %v, want ErrInvalidPageToken", name, err)
+		}
+	}
+}
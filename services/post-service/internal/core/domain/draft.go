@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPostForbidden    = errors.New("not allowed to modify this post")
+	ErrNotADraft        = errors.New("post is already published")
+	ErrNotScheduled     = errors.New("post is not scheduled")
+	ErrInvalidPublishAt = errors.New("publish_at must be in the future")
+)
+
+// PostStatus : cycle de vie d'un post (draft -> scheduled -> published).
+// Un post retenu par la modération automatique (held) n'est publié qu'après approbation.
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "draft"
+	PostStatusScheduled PostStatus = "scheduled" // Publié par le scheduler à PublishAt
+	PostStatusPublished PostStatus = "published"
+	PostStatusHeld      PostStatus = "held" // En attente d'un modérateur (ni distribué, ni modifiable)
+)
+
+// IsPublished : seuls les posts publiés sont visibles par les autres et distribués dans les Feeds
+func (p *Post) IsPublished() bool {
+	return p.Status == "" || p.Status == PostStatusPublished
+}
+
+// IsHeld : retenu par la modération automatique
+func (p *Post) IsHeld() bool {
+	return p.Status == PostStatusHeld
+}
+
+// Schedule programme un brouillon (ou reprogramme un post déjà planifié)
+func (p *Post) Schedule(publishAt, now time.Time) error {
+	if p.IsPublished() {
+		return ErrNotADraft
+	}
+	if p.IsHeld() {
+		return ErrPostHeld
+	}
+	if publishAt.Before(now) {
+		return ErrInvalidPublishAt
+	}
+	p.Status = PostStatusScheduled
+	p.PublishAt = publishAt.UTC()
+	p.UpdatedAt = now
+	return nil
+}
+
+// CancelSchedule remet un post planifié à l'état de brouillon
+func (p *Post) CancelSchedule(now time.Time) error {
+	if p.Status != PostStatusScheduled {
+		return ErrNotScheduled
+	}
+	p.Status = PostStatusDraft
+	p.PublishAt = time.Time{}
+	p.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Limites de longueur (en caractères) d'un #hashtag et d'un @username
+const (
+	MaxHashtagLength  = 100
+	MaxUsernameLength = 50
+	minUsernameLength = 3 // Cf. identity-service : username d'au moins 3 caractères
+)
+
+type EntityType string
+
+const (
+	EntityHashtag EntityType = "hashtag"
+	EntityMention EntityType = "mention"
+)
+
+// Entity est un #hashtag ou une @mention repéré dans Content.
+// Start/End sont des offsets en CARACTÈRES (runes Unicode), pas en octets : End est exclusif
+// et le texte affiché est []rune(Content)[Start:End] (symbole '#' ou '@' inclus).
+type Entity struct {
+	Type  EntityType
+	Text  string // Forme normalisée : tag en minuscules sans '#', username en minuscules sans '@'
+	Start int
+	End   int
+
+	// UserID : utilisateur mentionné (uniquement pour EntityMention, une fois résolu)
+	UserID string
+}
+
+// ParseEntities extrait les #hashtags et @mentions du contenu.
+// Un symbole n'ouvre une entité qu'en début de texte ou après un caractère qui n'est pas un "mot"
+// (ex: "a#b" ou "mail@example.com" ne sont pas des entités).
+func ParseEntities(content string) []Entity {
+	runes := []rune(content)
+	entities := []Entity{}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '#' && r != '@' {
+			continue
+		}
+		if i > 0 && isWordRune(runes[i-1]) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		word := string(runes[i+1 : end])
+		length := end - i - 1
+
+		switch {
+		case r == '#' && length > 0 && length <= MaxHashtagLength && hasLetter(word):
+			entities = append(entities, Entity{Type: EntityHashtag, Text: strings.ToLower(word), Start: i, End: end})
+		case r == '@' && length >= minUsernameLength && length <= MaxUsernameLength:
+			entities = append(entities, Entity{Type: EntityMention, Text: strings.ToLower(word), Start: i, End: end})
+		}
+
+		i = end - 1
+	}
+
+	return entities
+}
+
+// NormalizeHashtag : "#Golang" -> "golang" (forme stockée et recherchée)
+func NormalizeHashtag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// Hashtags renvoie les tags distincts du post
+func (p *Post) Hashtags() []string {
+	return p.distinctEntities(EntityHashtag, func(e Entity) string { return e.Text })
+}
+
+// MentionedUserIDs renvoie les utilisateurs mentionnés (résolus), sans doublon
+func (p *Post) MentionedUserIDs() []string {
+	return p.distinctEntities(EntityMention, func(e Entity) string { return e.UserID })
+}
+
+func (p *Post) distinctEntities(t EntityType, key func(Entity) string) []string {
+	seen := make(map[string]bool)
+	out := []string{}
+	for _, e := range p.Entities {
+		k := key(e)
+		if e.Type != t || k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, k)
+	}
+	return out
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// hasLetter : "#1" n'est pas un hashtag (numérotation), "#2024rewind" oui
+func hasLetter(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) || r == '_' {
+			return true
+		}
+	}
+	return false
+}
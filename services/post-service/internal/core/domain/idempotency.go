@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	MaxIdempotencyKeyLength = 255
+	// IdempotencyPendingTimeout : une réservation jamais complétée (réplica tombé en cours d'opération)
+	// est abandonnée au-delà, et la clé peut de nouveau servir
+	IdempotencyPendingTimeout = time.Minute
+)
+
+var (
+	ErrInvalidIdempotencyKey = errors.New("idempotency key is too long")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key already used for a different request")
+	ErrRequestInProgress     = errors.New("a request with this idempotency key is still in progress")
+)
+
+// IdempotentOperation : les commandes rejouables avec une clé client
+type IdempotentOperation string
+
+const (
+	OpCreatePost   IdempotentOperation = "create_post"
+	OpUpdatePost   IdempotentOperation = "update_post"
+	OpDeletePost   IdempotentOperation = "delete_post"
+	OpCreateThread IdempotentOperation = "create_thread"
+)
+
+// IdempotencyRecord : (auteur, opération, clé) -> post concerné, conservé jusqu'à ExpiresAt
+type IdempotencyRecord struct {
+	UserID      string
+	Operation   IdempotentOperation
+	Key         string
+	Fingerprint string // Empreinte de la requête d'origine
+	PostID      string
+	Completed   bool
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// NewIdempotencyRecord : 'request' est tout ce qui distingue une requête d'une autre (contenu, médias...)
+func NewIdempotencyRecord(userID string, op IdempotentOperation, key, postID string, request any, now time.Time, ttl time.Duration) (*IdempotencyRecord, error) {
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(payload)
+	now = now.Truncate(time.Microsecond) // Précision de Postgres : CreatedAt identifie la réservation
+
+	return &IdempotencyRecord{
+		UserID:      userID,
+		Operation:   op,
+		Key:         key,
+		Fingerprint: hex.EncodeToString(sum[:]),
+		PostID:      postID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+// Replay : ce qu'il faut faire d'une requête dont la clé est déjà réservée par 'existing'
+func (r *IdempotencyRecord) Replay(existing *IdempotencyRecord) error {
+	if existing.Fingerprint != r.Fingerprint {
+		return ErrIdempotencyKeyReused
+	}
+	if !existing.Completed {
+		return ErrRequestInProgress
+	}
+	return nil
+}
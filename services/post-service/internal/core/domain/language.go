@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MinLanguageSampleLetters : en dessous, un texte est trop court pour que sa langue soit détectée de façon fiable
+const MinLanguageSampleLetters = 20
+
+// LanguageSample : le texte soumis à la détection de langue, sans liens, #hashtags ni @mentions
+// (ils ne sont écrits dans aucune langue et faussent les trigrammes). Vide si trop court.
+func LanguageSample(content string) string {
+	words := strings.Fields(content)
+	kept := make([]string, 0, len(words))
+	letters := 0
+	for _, w := range words {
+		if strings.HasPrefix(w, "#") || strings.HasPrefix(w, "@") || isHTTPURL(w) {
+			continue
+		}
+		kept = append(kept, w)
+		for _, r := range w {
+			if unicode.IsLetter(r) {
+				letters++
+			}
+		}
+	}
+
+	if letters < MinLanguageSampleLetters {
+		return ""
+	}
+	return strings.Join(kept, " ")
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "testing"
+
+func TestLanguageSample(t *testing.T) {
+	tests := []struct {
+		name, content, want string
+	}{
+		{"texte gardé tel quel", "Bonjour à toutes et à tous, belle journée", "Bonjour à toutes et à tous, belle journée"},
+		{"liens, hashtags et mentions retirés", "Lisez https://example.com/article #golang @alice cet article passionnant", "Lisez cet article passionnant"},
+		{"trop court pour être fiable", "Merci beaucoup !", ""},
+		{"seulement des hashtags et des mentions", "#bonjourtoutlemonde #golang @quelquunaunomtreslong", ""},
+		{"les chiffres ne comptent pas comme des lettres", "12345678901234567890 ok", ""},
+		{"vide", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LanguageSample(tt.content); got != tt.want {
+				t.Errorf("LanguageSample(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"fr", "fr"},
+		{" FR ", "fr"},
+		{"En", "en"},
+		{"", ""},
+		{"fra", ""},
+		{"f", ""},
+		{"f1", ""},
+		{"é", ""},
+		{"fr-FR", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeLanguage(tt.in); got != tt.want {
+			t.Errorf("NormalizeLanguage(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limites d'un aperçu de lien (les pages distantes ne sont pas fiables)
+const (
+	MaxLinkURLLength         = 2048
+	MaxLinkTitleLength       = 300 // En caractères
+	MaxLinkDescriptionLength = 1000
+	MaxLinkSiteNameLength    = 100
+)
+
+var (
+	ErrLinkNotAllowed = errors.New("link target is not allowed")
+	ErrNoLinkPreview  = errors.New("page has no usable preview metadata")
+)
+
+// LinkPreview : métadonnées OpenGraph / Twitter card / oEmbed du premier lien d'un post
+type LinkPreview struct {
+	URL         string // Lien tel qu'il apparaît dans le post
+	Title       string
+	Description string
+	ImageURL    string // Absolue (résolue par rapport à la page)
+	SiteName    string
+	FetchedAt   time.Time
+}
+
+// LinkPreviewJob : récupération asynchrone d'un aperçu (cf. services.LinkPreviewWorker)
+type LinkPreviewJob struct {
+	PostID   string
+	URL      string
+	Attempts int // Tentatives déjà réclamées, celle-ci comprise
+}
+
+// IsEmpty : rien d'affichable (une page sans titre ni image ne mérite pas d'aperçu)
+func (l *LinkPreview) IsEmpty() bool {
+	return l.Title == "" && l.Description == "" && l.ImageURL == ""
+}
+
+// Normalize nettoie les champs lus sur la page : espaces, longueurs, image en http(s) seulement
+func (l *LinkPreview) Normalize() {
+	l.Title = truncateRunes(collapseSpaces(l.Title), MaxLinkTitleLength)
+	l.Description = truncateRunes(collapseSpaces(l.Description), MaxLinkDescriptionLength)
+	l.SiteName = truncateRunes(collapseSpaces(l.SiteName), MaxLinkSiteNameLength)
+	if !isHTTPURL(l.ImageURL) {
+		l.ImageURL = ""
+	}
+}
+
+// FirstLink renvoie le premier lien http(s) du contenu (vide s'il n'y en a pas).
+// La ponctuation collée en fin de lien ("voir https://x.fr/a.") n'en fait pas partie.
+func FirstLink(content string) string {
+	for _, word := range strings.FieldsFunc(content, unicode.IsSpace) {
+		lower := strings.ToLower(word)
+		start := strings.Index(lower, "https://")
+		if start < 0 {
+			start = strings.Index(lower, "http://")
+		}
+		if start < 0 {
+			continue
+		}
+
+		link := strings.TrimRight(word[start:], ".,;:!?)]}>»\"'")
+		if len(link) <= MaxLinkURLLength && isHTTPURL(link) {
+			return link
+		}
+	}
+	return ""
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Hostname() != ""
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncateRunes(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return strings.TrimSpace(string([]rune(s)[:max-1])) + "…"
+}
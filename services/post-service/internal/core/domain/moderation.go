@@ -0,0 +1,258 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Règles de modération
+const (
+	MaxReportDetailsLength = 1000 // En caractères
+	MaxModerationNote      = 2000 // En caractères
+	// CaseClaimTimeout : un dossier pris en charge puis abandonné retourne dans la file
+	CaseClaimTimeout = 30 * time.Minute
+)
+
+var (
+	ErrCaseNotFound            = errors.New("moderation case not found")
+	ErrInvalidReport           = errors.New("invalid report target")
+	ErrInvalidReportReason     = errors.New("invalid report reason")
+	ErrReportTooLong           = errors.New("report details are too long")
+	ErrCannotReportSelf        = errors.New("cannot report your own content")
+	ErrAlreadyReported         = errors.New("already reported")
+	ErrCaseAlreadyClaimed      = errors.New("moderation case is claimed by another moderator")
+	ErrCaseNotClaimed          = errors.New("moderation case must be claimed before being resolved")
+	ErrCaseResolved            = errors.New("moderation case is already resolved")
+	ErrInvalidModerationAction = errors.New("action not applicable to this target")
+)
+
+// ReportTarget : ce qui peut être signalé
+type ReportTarget string
+
+const (
+	TargetPost    ReportTarget = "post"
+	TargetComment ReportTarget = "comment"
+	TargetUser    ReportTarget = "user"
+)
+
+func (t ReportTarget) IsValid() bool {
+	switch t {
+	case TargetPost, TargetComment, TargetUser:
+		return true
+	}
+	return false
+}
+
+// ReportReason : motifs proposés à l'utilisateur
+type ReportReason string
+
+const (
+	ReasonSpam           ReportReason = "spam"
+	ReasonHarassment     ReportReason = "harassment"
+	ReasonHate           ReportReason = "hate"
+	ReasonViolence       ReportReason = "violence"
+	ReasonNudity         ReportReason = "nudity"
+	ReasonMisinformation ReportReason = "misinformation"
+	ReasonSelfHarm       ReportReason = "self_harm"
+	ReasonOther          ReportReason = "other"
+)
+
+func (r ReportReason) IsValid() bool {
+	switch r {
+	case ReasonSpam, ReasonHarassment, ReasonHate, ReasonViolence, ReasonNudity,
+		ReasonMisinformation, ReasonSelfHarm, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// CaseStatus : open -> claimed -> resolved
+type CaseStatus string
+
+const (
+	CaseOpen     CaseStatus = "open"
+	CaseClaimed  CaseStatus = "claimed"
+	CaseResolved CaseStatus = "resolved"
+)
+
+// ModerationAction : décision prise sur un dossier
+type ModerationAction string
+
+const (
+	ActionDismiss ModerationAction = "dismiss" // Signalements infondés
+	ActionHide    ModerationAction = "hide"    // Contenu visible de son seul auteur
+	ActionDelete  ModerationAction = "delete"  // Suppression (post : pierre tombale puis purge)
+	ActionWarn    ModerationAction = "warn"    // Avertissement à l'auteur (notification)
+	ActionSuspend ModerationAction = "suspend" // Suspension du compte (Identity Service)
+	// Médias du post marqués sensibles (floutés), avec un avertissement de contenu éventuel
+	ActionMarkSensitive ModerationAction = "mark_sensitive"
+)
+
+// AppliesTo : masquer ou supprimer n'a de sens que pour un contenu
+func (a ModerationAction) AppliesTo(t ReportTarget) bool {
+	switch a {
+	case ActionDismiss, ActionWarn, ActionSuspend:
+		return true
+	case ActionHide, ActionDelete:
+		return t == TargetPost || t == TargetComment
+	case ActionMarkSensitive:
+		return t == TargetPost
+	}
+	return false
+}
+
+// Report : un signalement (rattaché au dossier ouvert de sa cible)
+type Report struct {
+	ID         string
+	CaseID     string
+	ReporterID string
+	Reason     ReportReason
+	Details    string
+	CreatedAt  time.Time
+}
+
+// ModerationCase : tous les signalements d'une cible, en attente d'une décision
+type ModerationCase struct {
+	ID             string
+	TargetType     ReportTarget
+	TargetID       string
+	TargetAuthorID string // Auteur du contenu, ou l'utilisateur signalé lui-même
+	Status         CaseStatus
+	ReportsCount   int
+	ReasonCounts   map[ReportReason]int
+
+	ClaimedBy string
+	ClaimedAt time.Time
+
+	Resolution ModerationAction
+	ResolvedBy string
+	ResolvedAt time.Time
+
+	FirstReportedAt time.Time
+	LastReportedAt  time.Time
+
+	// Détail (GetCase uniquement)
+	Reports []*Report
+	History []*ModerationDecision // Décisions passées sur la même cible, tous dossiers confondus
+}
+
+// ModerationDecision : entrée de l'historique (jamais modifiée ni purgée, base des appels)
+type ModerationDecision struct {
+	ID             string
+	CaseID         string
+	TargetType     ReportTarget
+	TargetID       string
+	TargetAuthorID string
+	ModeratorID    string
+	Action         ModerationAction
+	Note           string
+	SuspendedUntil time.Time // Suspension temporaire (zéro sinon, ou si définitive)
+	ContentWarning string    // ActionMarkSensitive : avertissement appliqué au post (vide = inchangé)
+	CreatedAt      time.Time
+}
+
+// Resolution : décision demandée par le modérateur
+type Resolution struct {
+	Action       ModerationAction
+	Note         string    // Motivation (transmise à l'auteur pour warn/suspend)
+	SuspendUntil time.Time // ActionSuspend : zéro = définitive
+	// ActionMarkSensitive : remplace l'avertissement de l'auteur (vide = le garder)
+	ContentWarning string
+}
+
+// NewReport valide un signalement (factory). La cible est vérifiée par le service.
+func NewReport(reporterID string, reason ReportReason, details string) (*Report, error) {
+	if !reason.IsValid() {
+		return nil, ErrInvalidReportReason
+	}
+	details = strings.TrimSpace(details)
+	if utf8.RuneCountInString(details) > MaxReportDetailsLength {
+		return nil, ErrReportTooLong
+	}
+
+	return &Report{
+		ID:         uuid.NewString(),
+		ReporterID: reporterID,
+		Reason:     reason,
+		Details:    details,
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// IsClaimedAt : pris en charge par quelqu'un, et pas abandonné
+func (c *ModerationCase) IsClaimedAt(now time.Time) bool {
+	return c.Status == CaseClaimed && now.Sub(c.ClaimedAt) < CaseClaimTimeout
+}
+
+// Claim : un dossier n'est traité que par un modérateur à la fois (reprendre son propre dossier prolonge la prise en charge)
+func (c *ModerationCase) Claim(moderatorID string, now time.Time) error {
+	if c.Status == CaseResolved {
+		return ErrCaseResolved
+	}
+	if c.IsClaimedAt(now) && c.ClaimedBy != moderatorID {
+		return ErrCaseAlreadyClaimed
+	}
+	c.Status = CaseClaimed
+	c.ClaimedBy = moderatorID
+	c.ClaimedAt = now
+	return nil
+}
+
+// Resolve clôt le dossier et produit l'entrée d'historique correspondante
+func (c *ModerationCase) Resolve(moderatorID string, res Resolution, now time.Time) (*ModerationDecision, error) {
+	switch {
+	case c.Status == CaseResolved:
+		return nil, ErrCaseResolved
+	case c.Status != CaseClaimed || c.ClaimedBy != moderatorID:
+		return nil, ErrCaseNotClaimed
+	case !res.Action.AppliesTo(c.TargetType):
+		return nil, ErrInvalidModerationAction
+	}
+
+	note := strings.TrimSpace(res.Note)
+	if utf8.RuneCountInString(note) > MaxModerationNote {
+		return nil, ErrReportTooLong
+	}
+
+	decision := &ModerationDecision{
+		ID:             uuid.NewString(),
+		CaseID:         c.ID,
+		TargetType:     c.TargetType,
+		TargetID:       c.TargetID,
+		TargetAuthorID: c.TargetAuthorID,
+		ModeratorID:    moderatorID,
+		Action:         res.Action,
+		Note:           note,
+		CreatedAt:      now,
+	}
+	if res.Action == ActionSuspend && !res.SuspendUntil.IsZero() {
+		if !res.SuspendUntil.After(now) {
+			return nil, ErrInvalidModerationAction
+		}
+		decision.SuspendedUntil = res.SuspendUntil.UTC()
+	}
+	if res.Action == ActionMarkSensitive {
+		warning, err := CleanContentWarning(res.ContentWarning)
+		if err != nil {
+			return nil, err
+		}
+		decision.ContentWarning = warning
+	}
+
+	c.Status = CaseResolved
+	c.Resolution = res.Action
+	c.ResolvedBy = moderatorID
+	c.ResolvedAt = now
+	return decision, nil
+}
+
+// --- Masquage (ActionHide) ---
+
+// IsHidden : masqué par la modération, seul l'auteur le voit encore (cf. IsVisibleTo)
+func (p *Post) IsHidden() bool {
+	return !p.HiddenAt.IsZero()
+}
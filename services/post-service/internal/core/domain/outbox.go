@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+const (
+	// OutboxClaimLease : un message réclamé par un relais qui tombe avant l'accusé de réception
+	// redevient dû après ce délai (publié une seconde fois, le doublon est écarté par JetStream)
+	OutboxClaimLease = 30 * time.Second
+	// OutboxMaxRetryDelay : plafond de l'attente entre deux essais (reste sous la fenêtre de dédoublonnage du stream)
+	OutboxMaxRetryDelay = 5 * time.Minute
+)
+
+// OutboxMessage : un événement prêt à publier, enregistré avec le changement d'état qui l'a produit
+type OutboxMessage struct {
+	ID        string // Nats-Msg-Id (dédoublonnage JetStream)
+	Subject   string
+	Payload   []byte
+	Headers   map[string]string // Contexte de trace W3C de la requête d'origine
+	CreatedAt time.Time
+	Attempts  int // Essais de publication, celui en cours compris
+}
+
+// OutboxRetryDelay : attente exponentielle après l'essai n° attempts (1s, 2s, 4s... plafonnée)
+func OutboxRetryDelay(attempts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempts && delay < OutboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, OutboxMaxRetryDelay)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "errors"
+
+// MaxPinnedPosts : posts épinglés en tête de profil, par auteur
+const MaxPinnedPosts = 3
+
+var (
+	ErrTooManyPinnedPosts = errors.New("too many pinned posts")
+	ErrCannotPin          = errors.New("only published posts and quotes can be pinned")
+)
+
+// IsPinned : épinglé en tête du profil de son auteur
+func (p *Post) IsPinned() bool {
+	return !p.PinnedAt.IsZero()
+}
+
+// CanBePinned : un brouillon n'est pas sur le profil, un repost pur n'a pas de contenu propre
+func (p *Post) CanBePinned() error {
+	if !p.IsPublished() || p.IsRepost() {
+		return ErrCannotPin
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Règles d'un sondage
+const (
+	MinPollOptions       = 2
+	MaxPollOptions       = 4
+	MaxPollOptionLength  = 80 // En caractères
+	MinPollDuration      = 5 * time.Minute
+	MaxPollDuration      = 7 * 24 * time.Hour
+	DefaultPollDuration  = 24 * time.Hour
+	pollClosingTolerance = time.Second // Horloge client/serveur
+)
+
+var (
+	ErrPollNotFound      = errors.New("post has no poll")
+	ErrInvalidPoll       = errors.New("a poll needs 2 to 4 distinct, non-empty options")
+	ErrInvalidPollExpiry = errors.New("poll must close between 5 minutes and 7 days after creation")
+	ErrPollClosed        = errors.New("poll is closed")
+	ErrAlreadyVoted      = errors.New("already voted in this poll")
+	ErrInvalidPollChoice = errors.New("invalid poll choice")
+)
+
+// Poll : sondage attaché à un post (au plus un par post, identifié par PostID)
+type Poll struct {
+	PostID         string
+	Options        []PollOption
+	MultipleChoice bool
+	ClosesAt       time.Time
+	ClosedAt       time.Time // Renseigné quand la clôture a été traitée (événement post.poll_closed)
+	VotersCount    int
+
+	// Contexte du lecteur (cf. ForViewer)
+	ViewerChoices  []int // Index des options choisies, vide s'il n'a pas voté
+	ResultsVisible bool  // false : les compteurs sont masqués (mis à zéro)
+}
+
+type PollOption struct {
+	Label      string
+	VotesCount int
+}
+
+// PollInput : sondage demandé à la création d'un post (validé par NewPoll)
+type PollInput struct {
+	Options        []string
+	MultipleChoice bool
+	ClosesAt       time.Time // Zéro = DefaultPollDuration
+}
+
+// NewPoll crée un sondage valide (factory)
+func NewPoll(input PollInput, now time.Time) (*Poll, error) {
+	labels, closesAt := input.Options, input.ClosesAt
+	if len(labels) < MinPollOptions || len(labels) > MaxPollOptions {
+		return nil, ErrInvalidPoll
+	}
+
+	seen := make(map[string]bool, len(labels))
+	options := make([]PollOption, len(labels))
+	for i, label := range labels {
+		label = strings.TrimSpace(label)
+		key := strings.ToLower(label)
+		if label == "" || utf8.RuneCountInString(label) > MaxPollOptionLength || seen[key] {
+			return nil, ErrInvalidPoll
+		}
+		seen[key] = true
+		options[i] = PollOption{Label: label}
+	}
+
+	if closesAt.IsZero() {
+		closesAt = now.Add(DefaultPollDuration)
+	}
+	duration := closesAt.Sub(now)
+	if duration < MinPollDuration-pollClosingTolerance || duration > MaxPollDuration+pollClosingTolerance {
+		return nil, ErrInvalidPollExpiry
+	}
+
+	return &Poll{
+		Options:        options,
+		MultipleChoice: input.MultipleChoice,
+		ClosesAt:       closesAt.UTC(),
+	}, nil
+}
+
+// IsClosedAt : un sondage est fermé dès son échéance, même si la clôture n'a pas encore été traitée
+func (p *Poll) IsClosedAt(now time.Time) bool {
+	return !p.ClosedAt.IsZero() || !now.Before(p.ClosesAt)
+}
+
+// ValidateChoices : choix unique = exactement une option ; choix multiple = au moins une, sans doublon
+func (p *Poll) ValidateChoices(choices []int) error {
+	if len(choices) == 0 || (!p.MultipleChoice && len(choices) > 1) {
+		return ErrInvalidPollChoice
+	}
+	seen := make(map[int]bool, len(choices))
+	for _, c := range choices {
+		if c < 0 || c >= len(p.Options) || seen[c] {
+			return ErrInvalidPollChoice
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// ForViewer applique la règle d'affichage : les résultats ne sont visibles qu'après avoir voté
+// ou à la clôture (pas d'influence sur le vote). Sinon les compteurs sont mis à zéro.
+func (p *Poll) ForViewer(choices []int, now time.Time) {
+	p.ViewerChoices = choices
+	if p.ViewerChoices == nil {
+		p.ViewerChoices = []int{}
+	}
+	p.ResultsVisible = len(choices) > 0 || p.IsClosedAt(now)
+	if p.ResultsVisible {
+		return
+	}
+	p.VotersCount = 0
+	for i := range p.Options {
+		p.Options[i].VotesCount = 0
+	}
+}
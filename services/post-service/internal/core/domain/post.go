@@ -1,18 +1,63 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrPostNotFound    = errors.New("post not found")
+	ErrAlreadyReposted = errors.New("post already reposted")
+	ErrRepostNotFound  = errors.New("repost not found")
+
+	ErrEmptyPost        = errors.New("post has no content and no media")
+	ErrPostTooLong      = errors.New("post content is too long")
+	ErrTooManyMedia     = errors.New("too many media attached to the post")
+	ErrInvalidMedia     = errors.New("media id is required")
+	ErrInvalidMediaType = errors.New("unknown media type")
+
+	// ErrInvalidPageToken : curseur de pagination illisible (toutes les listes)
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+// PostLimits : bornes du corps d'un post (cf. POST_MAX_LENGTH, POST_MAX_MEDIA)
+type PostLimits struct {
+	MaxContentLength int // En caractères, pas en octets
+	MaxMedia         int
+}
+
+// DefaultPostLimits : valeurs par défaut de la configuration
+var DefaultPostLimits = PostLimits{MaxContentLength: 5000, MaxMedia: 4}
 
 type MediaType string
 
 const (
 	MediaTypeImage MediaType = "image"
 	MediaTypeVideo MediaType = "video"
+	MediaTypeLink  MediaType = "link"
 )
 
+func (t MediaType) IsValid() bool {
+	switch t {
+	case MediaTypeImage, MediaTypeVideo, MediaTypeLink:
+		return true
+	}
+	return false
+}
+
 type Media struct {
 	ID   string
 	URL  string
 	Type MediaType
+
+	// Sensitive : marqué par l'auteur ou la modération, flouté pour les lecteurs qui ne l'ont pas choisi
+	Sensitive bool
+	// Blurhash : aperçu flou calculé par le client à l'upload (vide si inconnu)
+	Blurhash string
+	// Blurred : URL retirée pour ce lecteur (cf. BlurSensitiveMedia), jamais persisté
+	Blurred bool
 }
 
 type Post struct {
@@ -22,4 +67,131 @@ type Post struct {
 	Media     []Media
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	EditedAt  time.Time // Dernière édition après publication (zéro = jamais édité, cf. post_revisions)
+	DeletedAt time.Time // Suppression douce (zéro = vivant, cf. Tombstone)
+	HiddenAt  time.Time // Masquage par la modération (zéro = visible, cf. IsHidden)
+	PinnedAt  time.Time // Épinglé en tête du profil (zéro = non épinglé, cf. IsPinned)
+
+	// Status : brouillon / planifié / publié. PublishAt n'est renseigné que pour une publication programmée.
+	Status    PostStatus
+	PublishAt time.Time
+
+	// ContentWarning : avertissement affiché avant le contenu (auteur ou modération), vide si aucun
+	ContentWarning string
+	// MarkedSensitive / ModeratorWarning : décision mark_sensitive, qui survit aux éditions de l'auteur
+	// (cf. KeepModeratorFlags). ModeratorWarning vide : l'avertissement reste celui de l'auteur.
+	MarkedSensitive  bool
+	ModeratorWarning string
+
+	// Language : code ISO 639-1 ("fr", "en"), vide si inconnu. Choisit l'analyse linguistique de la recherche.
+	Language string
+
+	// Visibility : audience du post (public par défaut)
+	Visibility Visibility
+
+	// Entities : #hashtags et @mentions extraits de Content (offsets en caractères)
+	Entities []Entity
+
+	// Poll : sondage attaché (nil si aucun)
+	Poll *Poll
+
+	// Review : signalement de la modération automatique qui retient le post. Transitoire : le repository
+	// l'enregistre dans la même transaction que le post (nil si le post n'est pas retenu à cette écriture)
+	Review *Report
+
+	// LinkPreview : aperçu du premier lien de Content (nil tant qu'il n'a pas été récupéré)
+	LinkPreview *LinkPreview
+
+	// RepostedPostID : post partagé (repost pur si Content et Media sont vides, citation sinon)
+	RepostedPostID string
+
+	// Fil de discussion : ThreadID est l'ID du premier post, ThreadPosition commence à 1 (vide / 0 hors fil)
+	ThreadID       string
+	ThreadPosition int
+
+	// Compteurs dénormalisés (maintenus par la DB)
+	CommentsCount  int
+	RepostsCount   int // Reposts purs + citations
+	ReactionCounts map[ReactionKind]int
+
+	// Viewer : état propre à l'utilisateur qui lit (nil si lecture anonyme)
+	Viewer *ViewerState
+}
+
+// NewPostParams : ce que l'auteur fournit (le reste est dérivé par le service : entités, sondage...)
+type NewPostParams struct {
+	ID             string
+	UserID         string
+	Content        string
+	ContentWarning string
+	Media          []Media
+	Status         PostStatus
+	Visibility     Visibility
+	Language       string
+	RepostedPostID string
+}
+
+// NewPost crée un post valide (factory). Seul un repost pur peut n'avoir ni contenu ni média.
+func NewPost(p NewPostParams, limits PostLimits, now time.Time) (*Post, error) {
+	content, media, err := limits.CleanBody(p.Content, p.Media)
+	if err != nil {
+		return nil, err
+	}
+	if content == "" && len(media) == 0 && p.RepostedPostID == "" {
+		return nil, ErrEmptyPost
+	}
+	warning, err := CleanContentWarning(p.ContentWarning)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Post{
+		ID:             p.ID,
+		UserID:         p.UserID,
+		Content:        content,
+		ContentWarning: warning,
+		Media:          media,
+		Language:       NormalizeLanguage(p.Language),
+		Status:         p.Status,
+		Visibility:     p.Visibility,
+		RepostedPostID: p.RepostedPostID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// CleanBody valide le corps d'un post (création comme édition) : contenu rogné, médias vides = nil
+func (l PostLimits) CleanBody(content string, media []Media) (string, []Media, error) {
+	content = strings.TrimSpace(content)
+	if utf8.RuneCountInString(content) > l.MaxContentLength {
+		return "", nil, ErrPostTooLong
+	}
+	if len(media) > l.MaxMedia {
+		return "", nil, ErrTooManyMedia
+	}
+	for _, m := range media {
+		if strings.TrimSpace(m.ID) == "" {
+			return "", nil, ErrInvalidMedia
+		}
+		if !m.Type.IsValid() {
+			return "", nil, ErrInvalidMediaType
+		}
+		if m.Blurhash != "" && !validBlurhash(m.Blurhash) {
+			return "", nil, ErrInvalidBlurhash
+		}
+	}
+	if len(media) == 0 {
+		media = nil
+	}
+	return content, media, nil
+}
+
+// IsRepost : partage pur, sans contenu propre
+func (p *Post) IsRepost() bool {
+	return p.RepostedPostID != "" && p.Content == "" && len(p.Media) == 0
+}
+
+// IsQuote : nouveau contenu qui cite un autre post
+func (p *Post) IsQuote() bool {
+	return p.RepostedPostID != "" && !p.IsRepost()
 }
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCleanBody(t *testing.T) {
+	limits := PostLimits{MaxContentLength: 10, MaxMedia: 2}
+	image := Media{ID: "m1", URL: "https://cdn.example.com/m1.jpg", Type: MediaTypeImage}
+
+	tests := []struct {
+		name        string
+		content     string
+		media       []Media
+		wantContent string
+		wantMedia   int
+		wantErr     error
+	}{
+		{"contenu rogné", "  bonjour  ", nil, "bonjour", 0, nil},
+		{"limite en caractères, pas en octets", "éééééééééé", nil, "éééééééééé", 0, nil},
+		{"trop long", "onze signes", nil, "", 0, ErrPostTooLong},
+		{"médias vides = nil", "ok", []Media{}, "ok", 0, nil},
+		{"médias à la limite", "", []Media{image, image}, "", 2, nil},
+		{"trop de médias", "", []Media{image, image, image}, "", 0, ErrTooManyMedia},
+		{"média sans ID", "", []Media{{ID: " ", Type: MediaTypeImage}}, "", 0, ErrInvalidMedia},
+		{"type de média inconnu", "", []Media{{ID: "m1", Type: "gif"}}, "", 0, ErrInvalidMediaType},
+		{"blurhash invalide", "", []Media{{ID: "m1", Type: MediaTypeImage, Blurhash: "???"}}, "", 0, ErrInvalidBlurhash},
+		{"blurhash valide", "", []Media{{ID: "m1", Type: MediaTypeImage, Blurhash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj"}}, "", 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, media, err := limits.CleanBody(tt.content, tt.media)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if content != tt.wantContent {
+				t.Errorf("content = %q, want %q", content, tt.wantContent)
+			}
+			if len(media) != tt.wantMedia {
+				t.Errorf("len(media) = %d, want %d", len(media), tt.wantMedia)
+			}
+			if len(tt.media) == 0 && media != nil {
+				t.Error("des médias vides doivent devenir nil")
+			}
+		})
+	}
+}
+
+func TestNewPost(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	limits := PostLimits{MaxContentLength: 20, MaxMedia: 1}
+
+	tests := []struct {
+		name    string
+		params  NewPostParams
+		wantErr error
+	}{
+		{"post simple", NewPostParams{Content: "bonjour"}, nil},
+		{"vide", NewPostParams{Content: "   "}, ErrEmptyPost},
+		{"repost pur sans contenu", NewPostParams{RepostedPostID: "p0"}, nil},
+		{"trop long", NewPostParams{Content: strings.Repeat("a", 21)}, ErrPostTooLong},
+		{"trop de médias", NewPostParams{Media: []Media{{ID: "a", Type: MediaTypeImage}, {ID: "b", Type: MediaTypeImage}}}, ErrTooManyMedia},
+		{"avertissement trop long", NewPostParams{Content: "ok", ContentWarning: strings.Repeat("a", MaxContentWarningLength+1)}, ErrContentWarningTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post, err := NewPost(tt.params, limits, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !post.CreatedAt.Equal(now) || !post.UpdatedAt.Equal(now) {
+				t.Errorf("dates = %v / %v, want %v", post.CreatedAt, post.UpdatedAt, now)
+			}
+		})
+	}
+}
+
+func TestNewPostNormalizesFields(t *testing.T) {
+	post, err := NewPost(NewPostParams{
+		ID:             "p1",
+		Content:        "  bonjour  ",
+		ContentWarning: "  spoiler ",
+		Language:       "FR",
+	}, DefaultPostLimits, time.Now())
+	if err != nil {
+		t.Fatalf("NewPost: %v", err)
+	}
+	if post.Content != "bonjour" || post.ContentWarning != "spoiler" {
+		t.Errorf("champs non rognés : %q / %q", post.Content, post.ContentWarning)
+	}
+	if post.Language != NormalizeLanguage("FR") {
+		t.Errorf("Language = %q, want %q", post.Language, NormalizeLanguage("FR"))
+	}
+}
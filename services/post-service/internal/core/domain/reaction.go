@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidReaction = errors.New("invalid reaction kind")
+
+type ReactionKind string
+
+const (
+	ReactionLike  ReactionKind = "like"
+	ReactionLove  ReactionKind = "love"
+	ReactionHaha  ReactionKind = "haha"
+	ReactionWow   ReactionKind = "wow"
+	ReactionSad   ReactionKind = "sad"
+	ReactionAngry ReactionKind = "angry"
+)
+
+// IsValid vérifie que le type de réaction est connu
+func (k ReactionKind) IsValid() bool {
+	switch k {
+	case ReactionLike, ReactionLove, ReactionHaha, ReactionWow, ReactionSad, ReactionAngry:
+		return true
+	}
+	return false
+}
+
+// Reaction : un utilisateur a au plus UNE réaction par post
+type Reaction struct {
+	PostID    string
+	UserID    string
+	Kind      ReactionKind
+	CreatedAt time.Time
+}
+
+// ReactionSummary est l'état d'un post après un React/Unreact
+type ReactionSummary struct {
+	PostID       string
+	PostAuthorID string
+	Previous     ReactionKind // Vide si l'utilisateur n'avait pas réagi
+	Current      ReactionKind // Vide après un Unreact
+	Counts       map[ReactionKind]int
+}
+
+// Changed indique si l'opération a réellement modifié quelque chose (idempotence)
+func (s *ReactionSummary) Changed() bool {
+	return s.Previous != s.Current
+}
+
+// ViewerState porte ce qui dépend de l'utilisateur qui consulte le post
+type ViewerState struct {
+	Reaction   ReactionKind // Vide si pas de réaction
+	Bookmarked bool
+}
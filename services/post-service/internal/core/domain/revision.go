@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var ErrEditWindowExpired = errors.New("edit window has expired")
+
+// PostRevision est une version remplacée d'un post publié (content + media)
+type PostRevision struct {
+	ID         string
+	PostID     string
+	Content    string
+	Media      []Media
+	CreatedAt  time.Time // Date à laquelle cette version est devenue courante
+	ReplacedAt time.Time // Date de l'édition qui l'a remplacée
+}
+
+// IsEdited : le post a été modifié après sa publication
+func (p *Post) IsEdited() bool {
+	return !p.EditedAt.IsZero()
+}
+
+// CanBeEditedAt : un post publié n'est modifiable que pendant 'window' après sa création
+// (window <= 0 = pas de limite). Les brouillons restent toujours modifiables.
+func (p *Post) CanBeEditedAt(now time.Time, window time.Duration) bool {
+	if !p.IsPublished() || window <= 0 {
+		return true
+	}
+	return now.Sub(p.CreatedAt) <= window
+}
+
+// HasSameBody : une "édition" sans changement ne crée pas de révision
+func (p *Post) HasSameBody(content, contentWarning string, media []Media) bool {
+	return p.Content == content && p.ContentWarning == contentWarning && slices.Equal(p.Media, media)
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Limites d'une recherche (protège la DB des requêtes démesurées)
+const (
+	MaxSearchTerms      = 10
+	maxSearchWordLength = 100
+)
+
+var (
+	ErrEmptySearchQuery = errors.New("search query is empty")
+)
+
+// SearchQuery : texte saisi par l'utilisateur + filtres optionnels
+type SearchQuery struct {
+	Text      string
+	AuthorID  string
+	MediaType MediaType // Posts contenant au moins un média de ce type
+	Since     time.Time // Inclus (zéro = pas de borne)
+	Until     time.Time // Exclu (zéro = pas de borne)
+}
+
+// SearchTerm : un mot, ou une phrase (plusieurs mots consécutifs, dans l'ordre).
+// Prefix : le dernier mot est un préfixe ("recett*" trouve "recettes").
+type SearchTerm struct {
+	Words  []string
+	Prefix bool
+}
+
+// IsPhrase : plusieurs mots qui doivent se suivre
+func (t SearchTerm) IsPhrase() bool {
+	return len(t.Words) > 1
+}
+
+// SearchResult : un post trouvé, son score et un extrait surligné
+type SearchResult struct {
+	Post    *Post
+	Rank    float32
+	Snippet string // HTML échappé, termes trouvés entre <mark></mark>
+}
+
+// SearchCursor : position dans des résultats triés par (Rank, PostID) décroissants
+type SearchCursor struct {
+	Rank   float32
+	PostID string
+}
+
+// Encode : jeton opaque pour le client
+func (c SearchCursor) Encode() string {
+	raw := strconv.FormatFloat(float64(c.Rank), 'g', -1, 32) + "|" + c.PostID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeSearchCursor : inverse de Encode (ErrInvalidPageToken si le jeton est corrompu)
+func DecodeSearchCursor(token string) (*SearchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	rank, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidPageToken
+	}
+	r, err := strconv.ParseFloat(rank, 32)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return &SearchCursor{Rank: float32(r), PostID: id}, nil
+}
+
+// ParseSearchTerms découpe la saisie en termes (tous obligatoires) :
+//   - "entre guillemets" : phrase exacte
+//   - mot* : préfixe
+//   - ponctuation = séparateur ("l'arbre" est la phrase "l arbre")
+//
+// Seules les lettres et chiffres sont conservés : la syntaxe du moteur ne peut pas être injectée.
+func ParseSearchTerms(text string) []SearchTerm {
+	var terms []SearchTerm
+
+	for _, token := range splitSearchTokens(text) {
+		prefix := strings.HasSuffix(token, "*")
+		words := strings.FieldsFunc(token, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(words) == 0 {
+			continue
+		}
+		for i, w := range words {
+			if runes := []rune(w); len(runes) > maxSearchWordLength {
+				words[i] = string(runes[:maxSearchWordLength])
+			}
+		}
+
+		terms = append(terms, SearchTerm{Words: words, Prefix: prefix})
+		if len(terms) == MaxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// splitSearchTokens : les espaces séparent les tokens, sauf entre guillemets (un guillemet non fermé court jusqu'à la fin)
+func splitSearchTokens(text string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case r == '"':
+			flush()
+			inQuotes = !inQuotes
+		case unicode.IsSpace(r) && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// NormalizeLanguage : code ISO 639-1 en minuscules ("FR" -> "fr"), vide si invalide
+func NormalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if len(lang) != 2 || lang[0] < 'a' || lang[0] > 'z' || lang[1] < 'a' || lang[1] > 'z' {
+		return ""
+	}
+	return lang
+}
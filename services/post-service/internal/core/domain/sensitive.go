@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// Avertissements de contenu et médias sensibles
+const (
+	MaxContentWarningLength = 200 // En caractères
+	// Blurhash : 4 caractères d'en-tête + 2 par composante (1x1 à 9x9 composantes)
+	minBlurhashLength = 6
+	maxBlurhashLength = 4 + 2*9*9
+)
+
+var (
+	ErrContentWarningTooLong = errors.New("content warning is too long")
+	ErrInvalidBlurhash       = errors.New("invalid media blurhash")
+)
+
+// Alphabet base 83 de l'encodage Blurhash
+const blurhashAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// CleanContentWarning : avertissement libre saisi par l'auteur ou un modérateur (vide = aucun)
+func CleanContentWarning(warning string) (string, error) {
+	warning = strings.TrimSpace(warning)
+	if utf8.RuneCountInString(warning) > MaxContentWarningLength {
+		return "", ErrContentWarningTooLong
+	}
+	return warning, nil
+}
+
+// validBlurhash : contrôle de forme uniquement (le client calcule le hash à l'upload, on ne décode pas l'image)
+func validBlurhash(hash string) bool {
+	if len(hash) < minBlurhashLength || len(hash) > maxBlurhashLength {
+		return false
+	}
+	for _, c := range hash {
+		if !strings.ContainsRune(blurhashAlphabet, c) {
+			return false
+		}
+	}
+	// Le premier caractère encode le nombre de composantes, qui fixe la longueur exacte
+	size := strings.IndexByte(blurhashAlphabet, hash[0])
+	nx, ny := size%9+1, size/9+1
+	return len(hash) == 4+2*nx*ny
+}
+
+// HasSensitiveMedia : au moins un média marqué sensible
+func (p *Post) HasSensitiveMedia() bool {
+	for _, m := range p.Media {
+		if m.Sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// BlurSensitiveMedia retire l'URL des médias sensibles pour un lecteur qui ne veut pas les voir :
+// seul le Blurhash reste affichable (Media.Blurred). L'auteur voit toujours ses propres médias.
+func (p *Post) BlurSensitiveMedia(viewerID string) {
+	if p.UserID == viewerID || !p.HasSensitiveMedia() {
+		return
+	}
+	p.Media = BlurMedia(p.Media)
+}
+
+// BlurSensitiveMedia : même règle que pour le post (l'auteur voit tout). Une décision mark_sensitive
+// s'étend aux versions précédentes, même si leurs médias n'étaient pas encore marqués.
+func (r *PostRevision) BlurSensitiveMedia(post *Post, viewerID string) {
+	if post.UserID == viewerID {
+		return
+	}
+	media := r.Media
+	if post.MarkedSensitive {
+		media, _ = post.KeepModeratorFlags(media, "")
+	}
+	r.Media = BlurMedia(media)
+}
+
+// BlurMedia : copie de media où les médias sensibles n'ont plus d'URL (révisions, posts)
+func BlurMedia(media []Media) []Media {
+	blurred := make([]Media, len(media))
+	for i, m := range media {
+		if m.Sensitive {
+			m.URL = ""
+			m.Blurred = true
+		}
+		blurred[i] = m
+	}
+	return blurred
+}
+
+// KeepModeratorFlags : une édition de l'auteur ne défait pas une décision mark_sensitive.
+// Tous les médias de la nouvelle version restent sensibles et l'avertissement imposé par
+// le modérateur remplace celui que l'auteur voudrait retirer ou changer.
+func (p *Post) KeepModeratorFlags(media []Media, contentWarning string) ([]Media, string) {
+	if !p.MarkedSensitive {
+		return media, contentWarning
+	}
+	kept := make([]Media, len(media))
+	for i, m := range media {
+		m.Sensitive = true
+		kept[i] = m
+	}
+	if p.ModeratorWarning != "" {
+		contentWarning = p.ModeratorWarning
+	}
+	return kept, contentWarning
+}
@@ -0,0 +1,89 @@
+package domain
+
+import "testing"
+
+func TestKeepModeratorFlags(t *testing.T) {
+	media := []Media{{ID: "m1", URL: "https://cdn/1.jpg", Type: MediaTypeImage}}
+
+	tests := []struct {
+		name          string
+		post          Post
+		warning       string
+		wantSensitive bool
+		wantWarning   string
+	}{
+		{"post jamais marqué : version de l'auteur", Post{}, "", false, ""},
+		{"marqué sans avertissement imposé", Post{MarkedSensitive: true}, "spoiler", true, "spoiler"},
+		{"avertissement imposé conservé", Post{MarkedSensitive: true, ModeratorWarning: "violence"}, "", true, "violence"},
+		{"avertissement imposé non remplaçable", Post{MarkedSensitive: true, ModeratorWarning: "violence"}, "rien à voir", true, "violence"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMedia, gotWarning := tt.post.KeepModeratorFlags(media, tt.warning)
+			if gotMedia[0].Sensitive != tt.wantSensitive {
+				t.Errorf("Sensitive = %v, want %v", gotMedia[0].Sensitive, tt.wantSensitive)
+			}
+			if gotWarning != tt.wantWarning {
+				t.Errorf("warning = %q, want %q", gotWarning, tt.wantWarning)
+			}
+		})
+	}
+	if media[0].Sensitive {
+		t.Error("les médias de l'appelant ne doivent pas être modifiés")
+	}
+}
+
+func TestBlurSensitiveMedia(t *testing.T) {
+	post := &Post{UserID: "author", Media: []Media{
+		{ID: "m1", URL: "https://cdn/1.jpg", Sensitive: true, Blurhash: "LEHV6nWB2yk8"},
+		{ID: "m2", URL: "https://cdn/2.jpg"},
+	}}
+
+	own := *post
+	own.BlurSensitiveMedia("author")
+	if own.Media[0].Blurred || own.Media[0].URL == "" {
+		t.Error("l'auteur voit toujours ses propres médias")
+	}
+
+	other := *post
+	other.BlurSensitiveMedia("reader")
+	if !other.Media[0].Blurred || other.Media[0].URL != "" || other.Media[0].Blurhash == "" {
+		t.Errorf("média sensible = %+v, want flouté (sans URL, blurhash gardé)", other.Media[0])
+	}
+	if other.Media[1].Blurred || other.Media[1].URL == "" {
+		t.Errorf("média ordinaire = %+v, want intact", other.Media[1])
+	}
+	if post.Media[0].URL == "" {
+		t.Error("les médias du post d'origine ne doivent pas être modifiés")
+	}
+}
+
+func TestRevisionBlurSensitiveMedia(t *testing.T) {
+	revision := func() *PostRevision {
+		return &PostRevision{Media: []Media{{ID: "m1", URL: "https://cdn/1.jpg"}}}
+	}
+
+	t.Run("post marqué par la modération : historique flouté", func(t *testing.T) {
+		r := revision()
+		r.BlurSensitiveMedia(&Post{UserID: "author", MarkedSensitive: true}, "reader")
+		if !r.Media[0].Blurred || r.Media[0].URL != "" {
+			t.Errorf("média = %+v, want flouté", r.Media[0])
+		}
+	})
+
+	t.Run("l'auteur voit son historique", func(t *testing.T) {
+		r := revision()
+		r.BlurSensitiveMedia(&Post{UserID: "author", MarkedSensitive: true}, "author")
+		if r.Media[0].Blurred {
+			t.Error("l'auteur voit toujours ses propres médias")
+		}
+	})
+
+	t.Run("post non marqué : médias ordinaires intacts", func(t *testing.T) {
+		r := revision()
+		r.BlurSensitiveMedia(&Post{UserID: "author"}, "reader")
+		if r.Media[0].Blurred {
+			t.Error("un média non sensible ne doit pas être flouté")
+		}
+	})
+}
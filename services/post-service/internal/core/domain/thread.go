@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrThreadTooShort = errors.New("a thread needs at least 2 posts")
+	ErrThreadTooLong  = errors.New("too many posts in the thread")
+)
+
+// MaxThreadLength : posts d'un fil publiés en une seule fois
+const MaxThreadLength = 25
+
+// ThreadPostInput : un post du fil tel que fourni par l'auteur (pas de sondage ni de repost dans un fil)
+type ThreadPostInput struct {
+	Content        string
+	ContentWarning string
+	Media          []Media
+	Language       string
+}
+
+// ValidateThreadLength : un fil d'un seul post n'est qu'un post
+func ValidateThreadLength(n int) error {
+	if n < 2 {
+		return ErrThreadTooShort
+	}
+	if n > MaxThreadLength {
+		return ErrThreadTooLong
+	}
+	return nil
+}
+
+// LinkThread numérote les posts (à partir de 1) et les rattache à la tête.
+// Les dates sont décalées d'une microseconde (précision de Postgres) : l'ordre chronologique
+// des listes suit celui du fil.
+func LinkThread(posts []*Post) {
+	if len(posts) == 0 {
+		return
+	}
+	head := posts[0]
+	for i, p := range posts {
+		p.ThreadID = head.ID
+		p.ThreadPosition = i + 1
+		p.CreatedAt = head.CreatedAt.Add(time.Duration(i) * time.Microsecond)
+		p.UpdatedAt = p.CreatedAt
+	}
+}
+
+// IsInThread : publié dans un fil (cf. CreateThread)
+func (p *Post) IsInThread() bool {
+	return p.ThreadID != ""
+}
+
+// IsThreadHead : premier post du fil, le seul distribué dans les fils d'actualité
+func (p *Post) IsThreadHead() bool {
+	return p.IsInThread() && p.ThreadID == p.ID
+}
+
+// ThreadEntry : le post par lequel le fil entre dans les fils d'actualité, normalement sa tête.
+// Une tête rejetée par la modération (masquée ou supprimée) passe la main au post suivant encore en ligne.
+// thread : les posts non supprimés, dans l'ordre du fil (cf. FindThread).
+func ThreadEntry(thread []*Post) *Post {
+	for _, p := range thread {
+		if !p.IsHidden() {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateThreadLength(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want error
+	}{
+		{"vide", 0, ErrThreadTooShort},
+		{"un seul post", 1, ErrThreadTooShort},
+		{"minimum", 2, nil},
+		{"maximum", MaxThreadLength, nil},
+		{"au-delà du maximum", MaxThreadLength + 1, ErrThreadTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateThreadLength(tt.n); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateThreadLength(%d) = %v, want %v", tt.n, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkThread(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	posts := []*Post{
+		{ID: "head", CreatedAt: now, UpdatedAt: now},
+		{ID: "second", CreatedAt: now, UpdatedAt: now},
+		{ID: "third", CreatedAt: now, UpdatedAt: now},
+	}
+	LinkThread(posts)
+
+	for i, p := range posts {
+		if p.ThreadID != "head" {
+			t.Errorf("%s : ThreadID = %q, want %q", p.ID, p.ThreadID, "head")
+		}
+		if p.ThreadPosition != i+1 {
+			t.Errorf("%s : ThreadPosition = %d, want %d", p.ID, p.ThreadPosition, i+1)
+		}
+		want := now.Add(time.Duration(i) * time.Microsecond)
+		if !p.CreatedAt.Equal(want) || !p.UpdatedAt.Equal(want) {
+			t.Errorf("%s : CreatedAt = %v, UpdatedAt = %v, want %v", p.ID, p.CreatedAt, p.UpdatedAt, want)
+		}
+	}
+	// Les décalages survivent à la précision de Postgres (microseconde)
+	for i := 1; i < len(posts); i++ {
+		if !posts[i].CreatedAt.Truncate(time.Microsecond).After(posts[i-1].CreatedAt.Truncate(time.Microsecond)) {
+			t.Errorf("%s n'est pas strictement après %s", posts[i].ID, posts[i-1].ID)
+		}
+	}
+
+	LinkThread(nil) // Sans effet, sans panique
+}
+
+func TestIsThreadHead(t *testing.T) {
+	tests := []struct {
+		name       string
+		post       Post
+		inThread   bool
+		threadHead bool
+	}{
+		{"hors fil", Post{ID: "p1"}, false, false},
+		{"tête", Post{ID: "p1", ThreadID: "p1", ThreadPosition: 1}, true, true},
+		{"suite", Post{ID: "p2", ThreadID: "p1", ThreadPosition: 2}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.post.IsInThread(); got != tt.inThread {
+				t.Errorf("IsInThread = %v, want %v", got, tt.inThread)
+			}
+			if got := tt.post.IsThreadHead(); got != tt.threadHead {
+				t.Errorf("IsThreadHead = %v, want %v", got, tt.threadHead)
+			}
+		})
+	}
+}
+
+func TestThreadEntry(t *testing.T) {
+	hidden := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	head := &Post{ID: "head", ThreadID: "head", ThreadPosition: 1}
+	second := &Post{ID: "second", ThreadID: "head", ThreadPosition: 2}
+	hiddenHead := &Post{ID: "head", ThreadID: "head", ThreadPosition: 1, HiddenAt: hidden}
+
+	tests := []struct {
+		name   string
+		thread []*Post
+		want   *Post
+	}{
+		{"tête en ligne", []*Post{head, second}, head},
+		{"tête masquée", []*Post{hiddenHead, second}, second},
+		{"tête supprimée (absente)", []*Post{second}, second},
+		{"tout est masqué", []*Post{hiddenHead}, nil},
+		{"fil vide", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ThreadEntry(tt.thread); got != tt.want {
+				t.Errorf("ThreadEntry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrRestoreWindowExpired = errors.New("restore window has expired")
+
+// IsDeleted : post supprimé (soft delete), en attente de purge
+func (p *Post) IsDeleted() bool {
+	return !p.DeletedAt.IsZero()
+}
+
+// CanBeRestoredAt : l'auteur peut annuler une suppression pendant 'window'
+func (p *Post) CanBeRestoredAt(now time.Time, window time.Duration) bool {
+	return p.IsDeleted() && now.Sub(p.DeletedAt) <= window
+}
+
+// Tombstone : ce qu'un lecteur voit d'un post supprimé (le fait qu'il a existé, pas son contenu)
+func (p *Post) Tombstone() *Post {
+	return &Post{
+		ID:             p.ID,
+		UserID:         p.UserID,
+		Status:         p.Status,
+		Visibility:     p.Visibility,
+		RepostedPostID: p.RepostedPostID,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		DeletedAt:      p.DeletedAt,
+		Media:          []Media{},
+		Entities:       []Entity{},
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidVisibility = errors.New("invalid visibility")
+	ErrRepostNotAllowed  = errors.New("only public posts can be reposted")
+)
+
+// Visibility : audience d'un post, appliquée à CHAQUE lecture (et au Fan-out du Feed)
+type Visibility string
+
+const (
+	VisibilityPublic       Visibility = "public"
+	VisibilityFollowers    Visibility = "followers"     // Abonnés de l'auteur
+	VisibilityMentioned    Visibility = "mentioned"     // Utilisateurs @mentionnés uniquement
+	VisibilityCloseFriends Visibility = "close_friends" // Liste privée d'amis proches de l'auteur
+)
+
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityFollowers, VisibilityMentioned, VisibilityCloseFriends:
+		return true
+	}
+	return false
+}
+
+// Relation : lien entre le lecteur et l'auteur (fourni par le Graph Service)
+type Relation struct {
+	IsFollowing   bool // Le lecteur suit l'auteur
+	IsCloseFriend bool // L'auteur a mis le lecteur dans ses amis proches
+}
+
+// NeedsRelation indique si la visibilité dépend du graphe social (évite des appels inutiles)
+func (p *Post) NeedsRelation(viewerID string) bool {
+	if viewerID == "" || viewerID == p.UserID || !p.IsPublished() || p.IsHidden() {
+		return false
+	}
+	return p.Visibility == VisibilityFollowers || p.Visibility == VisibilityCloseFriends
+}
+
+// IsVisibleTo : l'auteur voit toujours ses posts (brouillons et posts masqués compris),
+// un lecteur anonyme ne voit que le public
+func (p *Post) IsVisibleTo(viewerID string, rel Relation) bool {
+	if viewerID != "" && viewerID == p.UserID {
+		return true
+	}
+	if !p.IsPublished() || p.IsHidden() {
+		return false
+	}
+
+	switch p.Visibility {
+	case VisibilityPublic, "":
+		return true
+	case VisibilityFollowers:
+		return viewerID != "" && rel.IsFollowing
+	case VisibilityCloseFriends:
+		return viewerID != "" && rel.IsCloseFriend
+	case VisibilityMentioned:
+		for _, id := range p.MentionedUserIDs() {
+			if viewerID != "" && id == viewerID {
+				return true
+			}
+		}
+	}
+	return false
+}
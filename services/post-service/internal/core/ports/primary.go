@@ -2,17 +2,121 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
 )
 
+// Toutes les lectures prennent un viewerID (vide = anonyme) : la visibilité est appliquée ici,
+// un post invisible pour le lecteur est traité comme inexistant (domain.ErrPostNotFound / filtré).
+//
+// idempotencyKey (vide = aucune) : une commande rejouée avec la même clé par le même utilisateur
+// renvoie le résultat de la première sans rien refaire (cf. domain.IdempotencyRecord).
 type PostService interface {
-	CreatePost(ctx context.Context, userID, content string, media []domain.Media) (*domain.Post, error)
-	GetPost(ctx context.Context, postID string) (*domain.Post, error)
-	UpdatePost(ctx context.Context, postID, userID, content string, media []domain.Media) (*domain.Post, error)
-	DeletePost(ctx context.Context, postID, userID string) error
+	// language : code ISO 639-1, utilisé par la recherche plein texte et le filtrage des fils (vide = détectée)
+	// poll : sondage attaché (nil = aucun)
+	// contentWarning : avertissement libre affiché avant le contenu (vide = aucun) ; Media.Sensitive floute un média
+	CreatePost(ctx context.Context, userID, content, contentWarning string, media []domain.Media, visibility domain.Visibility, language string, poll *domain.PollInput, idempotencyKey string) (*domain.Post, error)
+	// showSensitive : préférence du lecteur, prise en compte par toutes les lectures (cf. GetPosts)
+	GetPost(ctx context.Context, postID, viewerID string, showSensitive bool) (*domain.Post, error)
+	// CreateThread publie tous les posts du fil ou aucun ; seule la tête est distribuée (un post.created)
+	CreateThread(ctx context.Context, userID string, posts []domain.ThreadPostInput, visibility domain.Visibility, idempotencyKey string) ([]*domain.Post, error)
+	// GetThread : posts du fil visibles par le lecteur, dans l'ordre (threadID = ID de la tête)
+	GetThread(ctx context.Context, threadID, viewerID string, showSensitive bool) ([]*domain.Post, error)
+	// UpdatePost archive la version remplacée d'un post publié (domain.ErrEditWindowExpired hors délai)
+	UpdatePost(ctx context.Context, postID, userID, content, contentWarning string, media []domain.Media, idempotencyKey string) (*domain.Post, error)
+	// ListPostRevisions : versions précédentes, visibles par ceux qui voient le post
+	ListPostRevisions(ctx context.Context, postID, viewerID string, showSensitive bool, limit int, cursor string) ([]*domain.PostRevision, string, error)
+	// DeletePost : suppression douce, restaurable par l'auteur pendant la fenêtre de restauration
+	DeletePost(ctx context.Context, postID, userID, idempotencyKey string) error
+	RestorePost(ctx context.Context, postID, userID string) (*domain.Post, error)
+
+	// Repost : content et media vides = repost pur, sinon citation
+	Repost(ctx context.Context, userID, repostedPostID, content string, media []domain.Media, idempotencyKey string) (*domain.Post, error)
+	UndoRepost(ctx context.Context, repostedPostID, userID string) error
+
+	// Épinglage sur le profil : réservé à l'auteur, domain.MaxPinnedPosts au plus. Les deux sont idempotents.
+	PinPost(ctx context.Context, postID, userID string) (*domain.Post, error)
+	UnpinPost(ctx context.Context, postID, userID string) (*domain.Post, error)
+
+	// Brouillons : draftID vide = nouveau brouillon. Un post planifié reste planifié quand on l'édite.
+	SaveDraft(ctx context.Context, draftID, userID, content, contentWarning string, media []domain.Media, visibility domain.Visibility) (*domain.Post, error)
+	ListDrafts(ctx context.Context, userID string, limit int, cursor string) ([]*domain.Post, string, error)
+	// SchedulePost : publishAt zéro = publication au prochain passage du scheduler
+	SchedulePost(ctx context.Context, postID, userID string, publishAt time.Time) (*domain.Post, error)
+	CancelScheduledPost(ctx context.Context, postID, userID string) (*domain.Post, error)
+
+	// Sondages : un seul vote par utilisateur, résultats masqués tant que le viewer n'a pas voté
+	// et que le sondage n'est pas clos (cf. domain.Poll.ForViewer)
+	VotePoll(ctx context.Context, postID, userID string, choices []int) (*domain.Poll, error)
+	GetPollResults(ctx context.Context, postID, viewerID string) (*domain.Poll, error)
 
 	// 👇 Méthodes de lecture avancées
-	GetPosts(ctx context.Context, postIDs []string) ([]*domain.Post, error)
-	ListPostsByAuthor(ctx context.Context, authorID string, limit int, cursor string) ([]*domain.Post, string, error)
+	// viewerID (optionnel) : renseigne post.Viewer (réaction, signet du lecteur) en une requête par donnée pour tout le batch
+	// showSensitive (préférence du lecteur) : sinon les médias sensibles sont renvoyés floutés, sans URL (domain.Post.BlurSensitiveMedia)
+	GetPosts(ctx context.Context, postIDs []string, viewerID string, showSensitive bool) ([]*domain.Post, error)
+	// ListPostsByAuthor : la première page commence par les posts épinglés (hors 'limit'), jamais répétés ensuite
+	ListPostsByAuthor(ctx context.Context, authorID, viewerID string, showSensitive bool, limit int, cursor string) ([]*domain.Post, string, error)
+	ListPostsByHashtag(ctx context.Context, tag, viewerID string, showSensitive bool, limit int, cursor string) ([]*domain.Post, string, error)
+	// SearchPosts : "phrase exacte", préfixe*, filtres (auteur, type de média, période), tri par pertinence
+	SearchPosts(ctx context.Context, query domain.SearchQuery, viewerID string, showSensitive bool, limit int, cursor string) ([]*domain.SearchResult, string, error)
+
+	// ExportUserPosts : export des données personnelles (appel interne), sans filtre de visibilité ni de statut
+	ExportUserPosts(ctx context.Context, userID string, limit int, cursor string) ([]*domain.Post, string, error)
+}
+
+type CommentService interface {
+	CreateComment(ctx context.Context, postID, parentID, userID, content string) (*domain.Comment, error)
+	EditComment(ctx context.Context, commentID, userID, content string) (*domain.Comment, error)
+	DeleteComment(ctx context.Context, commentID, userID string) error
+
+	// ListComments : parentID vide = racines (avec un aperçu des réponses), sinon réponses d'une racine
+	ListComments(ctx context.Context, postID, parentID, viewerID string, limit int, cursor string) ([]*domain.Comment, string, error)
+}
+
+// ModerationService : signalements (tout utilisateur) et file de modération.
+// Les méthodes qui prennent un moderatorID sont réservées aux modérateurs : le rôle est contrôlé par le Gateway.
+type ModerationService interface {
+	// ReportContent : la cible doit exister et être visible du rapporteur (on ne signale pas son propre contenu)
+	ReportContent(ctx context.Context, reporterID string, targetType domain.ReportTarget, targetID string, reason domain.ReportReason, details string) (*domain.Report, error)
+
+	ListCases(ctx context.Context, status domain.CaseStatus, limit int, cursor string) ([]*domain.ModerationCase, string, error)
+	// GetCase renvoie le dossier avec ses signalements et l'historique des décisions sur la cible
+	GetCase(ctx context.Context, caseID string) (*domain.ModerationCase, error)
+	ClaimCase(ctx context.Context, caseID, moderatorID string) (*domain.ModerationCase, error)
+	// ResolveCase applique la décision (masquage, suppression, suspension...) puis l'archive
+	ResolveCase(ctx context.Context, caseID, moderatorID string, res domain.Resolution) (*domain.ModerationCase, error)
+}
+
+// BookmarkService : signets privés. Un post enregistré puis supprimé (ou devenu invisible pour son lecteur)
+// reste listé, sous forme de pierre tombale (Bookmark.Unavailable).
+type BookmarkService interface {
+	// Bookmark : collectionID vide = non classé. Enregistrer un post déjà enregistré le déplace.
+	Bookmark(ctx context.Context, userID, postID, collectionID string) (*domain.Bookmark, error)
+	// Unbookmark est idempotent
+	Unbookmark(ctx context.Context, userID, postID string) error
+	// ListBookmarks : collectionID vide = tous les signets, les plus récents d'abord
+	ListBookmarks(ctx context.Context, userID, collectionID string, showSensitive bool, limit int, cursor string) ([]*domain.Bookmark, string, error)
+
+	CreateCollection(ctx context.Context, userID, name string) (*domain.Collection, error)
+	RenameCollection(ctx context.Context, userID, collectionID, name string) (*domain.Collection, error)
+	// ReorderCollections : orderedIDs doit citer chaque collection de l'utilisateur exactement une fois
+	ReorderCollections(ctx context.Context, userID string, orderedIDs []string) ([]*domain.Collection, error)
+	// DeleteCollection : les signets de la collection sont conservés, non classés
+	DeleteCollection(ctx context.Context, userID, collectionID string) error
+	ListCollections(ctx context.Context, userID string) ([]*domain.Collection, error)
+}
+
+// AnalyticsService : ingestion des impressions / vues (tout lecteur) et statistiques (auteur uniquement)
+type AnalyticsService interface {
+	// RecordPostEvents met les événements en attente d'écriture (par lots) : viewerID vide = anonyme,
+	// compté par session mais pas dans les spectateurs uniques (cf. domain.ReaderKey)
+	RecordPostEvents(ctx context.Context, viewerID, sessionID string, events []domain.PostEvent) error
+	// GetPostAnalytics : from/to zéro = les 7 derniers jours (domain.AnalyticsWindow)
+	GetPostAnalytics(ctx context.Context, postID, userID string, from, to time.Time) (*domain.PostAnalytics, error)
+}
+
+type ReactionService interface {
+	React(ctx context.Context, postID, userID string, kind domain.ReactionKind) (*domain.ReactionSummary, error)
+	Unreact(ctx context.Context, postID, userID string) (*domain.ReactionSummary, error)
 }
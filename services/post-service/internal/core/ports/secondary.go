@@ -8,22 +8,286 @@ import (
 )
 
 type PostRepository interface {
-	Save(ctx context.Context, post *domain.Post) error
+	// Save incrémente aussi reposts_count de l'original pour un repost/une citation
+	// (domain.ErrPostNotFound si l'original n'existe pas, domain.ErrAlreadyReposted si repost pur en double).
+	// Les événements sont écrits dans l'outbox, dans la même transaction que le post.
+	Save(ctx context.Context, post *domain.Post, events ...*domain.OutboxMessage) error
 	FindByID(ctx context.Context, postID string) (*domain.Post, error)
-	Delete(ctx context.Context, postID string) error
+	// SaveThread insère tous les posts d'un fil (et leurs événements) dans une seule transaction
+	SaveThread(ctx context.Context, posts []*domain.Post, events ...*domain.OutboxMessage) error
+	// FindThread : posts non supprimés du fil, par position
+	FindThread(ctx context.Context, threadID string) ([]*domain.Post, error)
+	// Delete (définitif) décrémente le compteur de l'original et supprime les reposts purs du post.
+	// events (post.deleted) est enregistré dans l'outbox, dans la même transaction.
+	Delete(ctx context.Context, postID string, events ...*domain.OutboxMessage) error
+
+	// Suppression douce : le post devient une pierre tombale (FindByID et les listes l'ignorent)
+	// SoftDelete (post.ID, post.DeletedAt) supprime définitivement les reposts purs du post et enregistre
+	// dans la même transaction les événements que announce construit pour le post et ces reposts
+	SoftDelete(ctx context.Context, post *domain.Post, announce PostAnnouncer) error
+	FindDeleted(ctx context.Context, postID string) (*domain.Post, error)
+	// Restore enregistre events (re-fan-out) dans la transaction de la restauration
+	Restore(ctx context.Context, postID string, events ...*domain.OutboxMessage) error
+	// PurgeDeleted supprime définitivement les posts supprimés avant 'before' (par lots)
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error)
+
+	// FindRepost renvoie le repost pur de originalID par userID (domain.ErrRepostNotFound sinon)
+	FindRepost(ctx context.Context, userID, originalID string) (*domain.Post, error)
 
 	// Utilisé pour l'hydratation du Feed (Batch)
 	GetPosts(ctx context.Context, postIDs []string) ([]*domain.Post, error)
 
-	// Utilisé pour la pagination Profil (Cursor-based)
+	// Utilisé pour la pagination Profil (Cursor-based), posts épinglés exclus
 	// Notez qu'ici on utilise time.Time, car le repo parle "Date", pas "Token string"
 	ListByAuthor(ctx context.Context, authorID string, limit int, cursorTime time.Time) ([]*domain.Post, error)
+	// ListAllByAuthor : tous statuts et posts supprimés pas encore purgés compris (export),
+	// keyset sur (created_at, id), 'after' nil = première page
+	ListAllByAuthor(ctx context.Context, authorID string, limit int, after *domain.KeysetCursor) ([]*domain.Post, error)
+
+	// Posts épinglés : ListPinned renvoie les derniers épinglés d'abord (posts publiés)
+	ListPinned(ctx context.Context, authorID string, limit int) ([]*domain.Post, error)
+	// Pin est idempotent (un post déjà épinglé garde sa place) ; domain.ErrTooManyPinnedPosts au-delà de 'max'
+	Pin(ctx context.Context, post *domain.Post, max int) error
+	// Unpin est idempotent
+	Unpin(ctx context.Context, postID string) error
+
+	// ListByHashtag : timeline d'un hashtag (tag normalisé), même pagination que ListByAuthor
+	ListByHashtag(ctx context.Context, tag string, limit int, cursorTime time.Time) ([]*domain.Post, error)
+
+	// Brouillons & publication programmée (GetPosts/ListByAuthor/ListByHashtag ne renvoient que des posts publiés)
+	ListDrafts(ctx context.Context, authorID string, limit int, cursorTime time.Time) ([]*domain.Post, error)
+	// UpdateSchedule : domain.ErrNotADraft si le post a été publié entre-temps
+	UpdateSchedule(ctx context.Context, post *domain.Post) error
+	// PublishDue passe à "published" les posts planifiés échus (FOR UPDATE SKIP LOCKED) et les renvoie,
+	// avec dans la même transaction les événements que announce construit pour eux
+	PublishDue(ctx context.Context, now time.Time, limit int, announce PostAnnouncer) ([]*domain.Post, error)
+	// ReleaseHeld publie un post retenu par la modération automatique (domain.ErrNotHeld sinon)
+	// et enregistre events dans la même transaction (rien n'est écrit s'il n'était plus retenu)
+	ReleaseHeld(ctx context.Context, postID string, now time.Time, events ...*domain.OutboxMessage) (*domain.Post, error)
+
+	// SearchPosts : recherche plein texte (posts publiés), triée par pertinence puis ID.
+	// 'after' nil = première page.
+	SearchPosts(ctx context.Context, query domain.SearchQuery, terms []domain.SearchTerm, limit int, after *domain.SearchCursor) ([]*domain.SearchResult, error)
+
+	// ListRevisions : versions remplacées d'un post (Update les archive quand post.EditedAt est renseigné)
+	ListRevisions(ctx context.Context, postID string, limit int, cursorTime time.Time) ([]*domain.PostRevision, error)
 
 	// Si vous avez Update dans le gRPC, il le faut aussi ici
-	Update(ctx context.Context, post *domain.Post) error
+	// Save et Update réécrivent aussi post_hashtags / post_mentions à partir de post.Entities
+	// (events : post.updated, mentions, enregistrés dans la même transaction)
+	Update(ctx context.Context, post *domain.Post, events ...*domain.OutboxMessage) error
+}
+
+// PostAnnouncer construit les événements de posts qui ne sont connus que dans la transaction du repository
+// (reposts purs emportés par une suppression, posts planifiés échus, sondages clôturés) :
+// ils sont écrits dans l'outbox avec le changement d'état, tout ou rien
+type PostAnnouncer func(posts []*domain.Post) ([]*domain.OutboxMessage, error)
+
+// CommentAnnouncer construit comment.created une fois l'auteur du post lu (transaction de SaveComment)
+type CommentAnnouncer func(postAuthorID string) ([]*domain.OutboxMessage, error)
+
+// ReactionAnnouncer construit post.reacted à partir du bilan calculé dans la transaction de SetReaction
+type ReactionAnnouncer func(summary *domain.ReactionSummary) ([]*domain.OutboxMessage, error)
+
+// UserDirectory résout les @usernames en IDs (Identity Service)
+type UserDirectory interface {
+	// ResolveUsernames renvoie username (minuscules) -> userID ; les inconnus sont absents
+	ResolveUsernames(ctx context.Context, usernames []string) (map[string]string, error)
+}
+
+// CommentRepository gère les commentaires ET le compteur dénormalisé posts.comments_count.
+// Les écritures sont transactionnelles : le compteur ne dérive jamais du contenu réel.
+type CommentRepository interface {
+	// SaveComment insère le commentaire, incrémente les compteurs et enregistre les événements d'announce
+	// dans la même transaction (domain.ErrPostNotFound si le post n'existe pas)
+	SaveComment(ctx context.Context, comment *domain.Comment, announce CommentAnnouncer) error
+	FindCommentByID(ctx context.Context, commentID string) (*domain.Comment, error)
+	UpdateComment(ctx context.Context, comment *domain.Comment) error
+	// DeleteComment supprime le commentaire (et ses réponses) puis décrémente les compteurs
+	DeleteComment(ctx context.Context, comment *domain.Comment) error
+
+	// ListComments pagine les racines d'un post (parentID vide) ou les réponses d'une racine
+	ListComments(ctx context.Context, postID, parentID string, limit int, cursorTime time.Time) ([]*domain.Comment, error)
+	// ListRepliesPreview renvoie les 'perParent' premières réponses de chaque racine (une seule requête)
+	ListRepliesPreview(ctx context.Context, parentIDs []string, perParent int) ([]*domain.Comment, error)
+}
+
+// ReactionRepository maintient post_reactions ET posts.reaction_counts dans la même transaction
+type ReactionRepository interface {
+	// SetReaction crée ou remplace la réaction (domain.ErrPostNotFound si le post n'existe pas)
+	// et enregistre les événements d'announce dans la même transaction
+	SetReaction(ctx context.Context, reaction *domain.Reaction, announce ReactionAnnouncer) (*domain.ReactionSummary, error)
+	// RemoveReaction est idempotent : Previous est vide si l'utilisateur n'avait pas réagi
+	RemoveReaction(ctx context.Context, postID, userID string) (*domain.ReactionSummary, error)
+	// ViewerReactions renvoie postID -> réaction du viewer (Batch, pour l'hydratation du Feed)
+	ViewerReactions(ctx context.Context, viewerID string, postIDs []string) (map[string]domain.ReactionKind, error)
+}
+
+// BookmarkRepository : signets et collections (privés, toujours filtrés par userID)
+type BookmarkRepository interface {
+	// SaveBookmark crée le signet ou le déplace de collection (la date d'enregistrement d'origine est conservée)
+	// et renvoie le signet enregistré (domain.ErrPostNotFound si le post n'existe pas)
+	SaveBookmark(ctx context.Context, b *domain.Bookmark) (*domain.Bookmark, error)
+	// DeleteBookmark est idempotent
+	DeleteBookmark(ctx context.Context, userID, postID string) error
+	// ListBookmarks : collectionID vide = tous les signets. Bookmark.Post est rempli, posts supprimés compris
+	// (pierres tombales), du plus récent au plus ancien (pagination sur la date d'enregistrement puis le post, nil = début).
+	ListBookmarks(ctx context.Context, userID, collectionID string, limit int, cursor *domain.KeysetCursor) ([]*domain.Bookmark, error)
+	// ViewerBookmarks renvoie les posts enregistrés par le viewer parmi postIDs (Batch, pour l'hydratation du Feed)
+	ViewerBookmarks(ctx context.Context, viewerID string, postIDs []string) (map[string]bool, error)
+
+	// CreateCollection place la collection en dernier (domain.ErrTooManyCollections, domain.ErrCollectionNameTaken)
+	CreateCollection(ctx context.Context, c *domain.Collection) error
+	// FindCollection : domain.ErrCollectionNotFound si elle n'appartient pas à userID
+	FindCollection(ctx context.Context, userID, collectionID string) (*domain.Collection, error)
+	// ListCollections : dans l'ordre choisi par l'utilisateur, avec le nombre de signets
+	ListCollections(ctx context.Context, userID string) ([]*domain.Collection, error)
+	RenameCollection(ctx context.Context, c *domain.Collection) error
+	// ReorderCollections : orderedIDs est une permutation des collections de userID (validée par le service)
+	ReorderCollections(ctx context.Context, userID string, orderedIDs []string) error
+	// DeleteCollection : ses signets sont conservés, non classés
+	DeleteCollection(ctx context.Context, userID, collectionID string) error
+}
+
+// PollRepository : sondages (créés par PostRepository.Save avec leur post), votes et compteurs
+type PollRepository interface {
+	// GetPolls renvoie postID -> sondage (Batch) ; les posts sans sondage sont absents
+	GetPolls(ctx context.Context, postIDs []string) (map[string]*domain.Poll, error)
+	// ViewerChoices renvoie postID -> options choisies par le viewer (Batch)
+	ViewerChoices(ctx context.Context, viewerID string, postIDs []string) (map[string][]int, error)
+	// Vote enregistre les choix et renvoie les résultats à jour
+	// (domain.ErrPollNotFound, domain.ErrPollClosed, domain.ErrAlreadyVoted)
+	Vote(ctx context.Context, postID, userID string, choices []int, now time.Time) (*domain.Poll, error)
+	// CloseDue clôture les sondages échus (FOR UPDATE SKIP LOCKED) et renvoie leur nombre.
+	// announce reçoit les posts encore en ligne, résultats définitifs attachés (Post.Poll), dans la transaction.
+	CloseDue(ctx context.Context, now time.Time, limit int, announce PostAnnouncer) (int, error)
+}
+
+// LinkPreviewRepository : file des aperçus de liens (alimentée par Save/Update selon le premier lien du post)
+type LinkPreviewRepository interface {
+	// ClaimJobs réserve les jobs échus (FOR UPDATE SKIP LOCKED) et repousse leur prochaine tentative
+	ClaimJobs(ctx context.Context, now time.Time, backoff time.Duration, limit int) ([]domain.LinkPreviewJob, error)
+	// CompleteJob enregistre l'aperçu sur le post et retire le job (preview nil = abandon)
+	CompleteJob(ctx context.Context, job domain.LinkPreviewJob, preview *domain.LinkPreview) error
 }
 
+// LinkFetcher récupère les métadonnées d'une page distante (OpenGraph, Twitter card, oEmbed).
+// Les cibles internes (IP privées, loopback...) sont refusées : domain.ErrLinkNotAllowed.
+type LinkFetcher interface {
+	Fetch(ctx context.Context, url string) (*domain.LinkPreview, error)
+}
+
+// ModerationRepository : signalements agrégés en dossiers, et historique des décisions (append-only)
+type ModerationRepository interface {
+	// AddReport rattache le signalement au dossier ouvert de la cible (créé au besoin à partir de 'target')
+	// et met à jour ses compteurs (domain.ErrAlreadyReported si le rapporteur a déjà signalé ce dossier)
+	AddReport(ctx context.Context, target *domain.ModerationCase, report *domain.Report) (*domain.ModerationCase, error)
+	FindCase(ctx context.Context, caseID string) (*domain.ModerationCase, error)
+	// ListCases : status vide = dossiers non résolus, les plus anciens d'abord (pagination sur first_reported_at)
+	ListCases(ctx context.Context, status domain.CaseStatus, limit int, cursorTime time.Time) ([]*domain.ModerationCase, error)
+	ListReports(ctx context.Context, caseID string, limit int) ([]*domain.Report, error)
+	// ListDecisions : historique d'une cible, le plus récent d'abord
+	ListDecisions(ctx context.Context, targetType domain.ReportTarget, targetID string) ([]*domain.ModerationDecision, error)
+
+	// SaveClaim enregistre la prise en charge si personne ne l'a modifiée depuis la lecture
+	// (previousClaimedBy) : domain.ErrCaseAlreadyClaimed sinon
+	SaveClaim(ctx context.Context, c *domain.ModerationCase, previousClaimedBy string) error
+	// Resolve clôt le dossier, archive la décision et enregistre events (outbox) dans la même transaction
+	// (domain.ErrCaseNotClaimed si le modérateur a perdu la main entre-temps)
+	Resolve(ctx context.Context, c *domain.ModerationCase, decision *domain.ModerationDecision, events ...*domain.OutboxMessage) error
+
+	// HidePost / HideComment sont idempotents (le premier masquage fait foi)
+	// events n'est enregistré (même transaction) que par le masquage effectif
+	HidePost(ctx context.Context, postID string, at time.Time, events ...*domain.OutboxMessage) error
+	HideComment(ctx context.Context, commentID string, at time.Time) error
+	// MarkSensitive marque tous les médias du post sensibles ; contentWarning vide garde celui de l'auteur
+	MarkSensitive(ctx context.Context, postID, contentWarning string) error
+}
+
+// AccountModerator : sanctions de compte (Identity Service)
+type AccountModerator interface {
+	// UserExists : false si le compte est inconnu ou désactivé
+	UserExists(ctx context.Context, userID string) (bool, error)
+	// SuspendUser : until zéro = suspension définitive
+	SuspendUser(ctx context.Context, userID, moderatorID, reason string, until time.Time) error
+}
+
+// IdempotencyStore : clés d'idempotence des commandes rejouées par les clients
+type IdempotencyStore interface {
+	// Reserve enregistre la clé. Si elle est déjà prise (et ni expirée, ni abandonnée), renvoie
+	// la réservation existante et false, sans rien modifier.
+	Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error)
+	// Complete : l'opération a abouti, les prochains essais rejoueront son résultat
+	Complete(ctx context.Context, rec *domain.IdempotencyRecord) error
+	// Release libère la clé après un échec (le client pourra réessayer avec la même)
+	Release(ctx context.Context, rec *domain.IdempotencyRecord) error
+	// PurgeExpired supprime les clés expirées avant 'now' (par lots)
+	PurgeExpired(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+// AnalyticsRepository : statistiques des posts (totaux et tranches horaires)
+type AnalyticsRepository interface {
+	// ApplyDeltas ajoute les compteurs et relève les cardinalités (jamais à la baisse) dans une transaction.
+	// Les événements de posts inconnus (purgés entre-temps) sont ignorés.
+	ApplyDeltas(ctx context.Context, deltas []domain.AnalyticsDelta) error
+	// GetTotals renvoie les compteurs depuis la publication (zéro si aucun événement)
+	GetTotals(ctx context.Context, postID string) (impressions, views, uniqueViewers int, err error)
+	// ListBuckets : impressions, vues, réactions et commentaires par heure sur [from, to), heures vides omises
+	ListBuckets(ctx context.Context, postID string, from, to time.Time) ([]domain.AnalyticsBucket, error)
+}
+
+// ViewerCounter dédoublonne les spectateurs (HyperLogLog) : ajoute les ViewerIDs de chaque delta
+// et renseigne ses cardinalités à jour (BucketUniqueViewers, UniqueViewers)
+type ViewerCounter interface {
+	CountViewers(ctx context.Context, deltas []domain.AnalyticsDelta) error
+}
+
+// ContentClassifier : modération automatique, consultée avant la publication d'un post.
+// Une erreur (classifieur indisponible) est arbitrée par le service (cf. PostPolicy.ClassifierFailOpen).
+type ContentClassifier interface {
+	Classify(ctx context.Context, post *domain.Post) (domain.Classification, error)
+}
+
+// LanguageDetector : langue d'un texte (ISO 639-1), vide si elle ne peut être déterminée avec assez de confiance
+type LanguageDetector interface {
+	Detect(text string) string
+}
+
+// RelationChecker interroge le graphe social (Graph Service) pour appliquer la visibilité
+type RelationChecker interface {
+	CheckRelation(ctx context.Context, viewerID, authorID string) (domain.Relation, error)
+	// CheckRelations : variante batch (une page de posts = un appel)
+	CheckRelations(ctx context.Context, viewerID string, authorIDs []string) (map[string]domain.Relation, error)
+}
+
+// EventPublisher : les événements post.* et moderation.* ne sont pas publiés directement mais confiés à l'outbox,
+// enregistrés dans la transaction du changement d'état.
+// Le contexte de trace de ctx est capturé dans le message.
 type EventPublisher interface {
-	PublishPostCreated(ctx context.Context, post *domain.Post) error
-	PublishPostDeleted(ctx context.Context, postID string) error
+	PostCreatedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error)
+	PostUpdatedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error)
+	// PostDeletedMessage : post.ID, UserID et DeletedAt suffisent (le Feed Service retire le post des timelines,
+	// qu'il ait été supprimé ou masqué par la modération)
+	PostDeletedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error)
+	UserMentionedMessage(ctx context.Context, post *domain.Post, mentionedUserID string) (*domain.OutboxMessage, error)
+	CommentCreatedMessage(ctx context.Context, comment *domain.Comment, postAuthorID string) (*domain.OutboxMessage, error)
+	PostReactedMessage(ctx context.Context, reaction *domain.Reaction, summary *domain.ReactionSummary) (*domain.OutboxMessage, error)
+	// PollClosedMessage : post.Poll porte les résultats définitifs (l'auteur est notifié)
+	PollClosedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error)
+	// ModerationDecisionMessage : moderation.<action> (l'auteur est notifié d'un warn/suspend, l'audit garde tout)
+	ModerationDecisionMessage(ctx context.Context, decision *domain.ModerationDecision) (*domain.OutboxMessage, error)
+}
+
+// OutboxRepository : file des événements en attente de publication (cf. OutboxRelay)
+type OutboxRepository interface {
+	// ClaimPending réserve les messages dus jusqu'à leaseUntil (un seul relais les publie) et compte l'essai
+	ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.OutboxMessage, error)
+	// MarkPublished supprime le message, accusé par le stream
+	MarkPublished(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, retryAt time.Time, cause string) error
+}
+
+// OutboxPublisher : publication durable, avec accusé de réception et dédoublonnage sur msg.ID
+type OutboxPublisher interface {
+	PublishOutbox(ctx context.Context, msg *domain.OutboxMessage) error
 }
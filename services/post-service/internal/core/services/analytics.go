@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+type analyticsService struct {
+	recorder  *AnalyticsRecorder
+	analytics ports.AnalyticsRepository
+	repo      ports.PostRepository
+}
+
+func NewAnalyticsService(recorder *AnalyticsRecorder, analytics ports.AnalyticsRepository, repo ports.PostRepository) ports.AnalyticsService {
+	return &analyticsService{recorder: recorder, analytics: analytics, repo: repo}
+}
+
+// RecordPostEvents : le lot est validé en entier (un client bogué ne pollue pas les compteurs à moitié).
+// Les événements sont datés à la réception : un client ne réécrit pas l'historique.
+func (s *analyticsService) RecordPostEvents(ctx context.Context, viewerID, sessionID string, events []domain.PostEvent) error {
+	if len(events) > domain.MaxPostEventsPerBatch {
+		return domain.ErrTooManyPostEvents
+	}
+
+	now := time.Now().UTC()
+	reader := domain.ReaderKey(viewerID, sessionID)
+	for i := range events {
+		// Un ID invalide ferait échouer l'écriture de tout le lot (cast uuid côté base)
+		if _, err := uuid.Parse(events[i].PostID); err != nil || !events[i].Kind.IsValid() {
+			return domain.ErrInvalidPostEvent
+		}
+		events[i].ViewerID = viewerID
+		events[i].Reader = reader
+		events[i].At = now
+	}
+
+	s.recorder.Record(events)
+	return nil
+}
+
+// GetPostAnalytics : réservé à l'auteur. Réactions et commentaires viennent du post lui-même
+// (compteurs dénormalisés) ; le détail horaire, de leurs dates de création.
+func (s *analyticsService) GetPostAnalytics(ctx context.Context, postID, userID string, from, to time.Time) (*domain.PostAnalytics, error) {
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != userID {
+		return nil, domain.ErrPostForbidden
+	}
+
+	from, to, err = domain.AnalyticsWindow(from, to, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.PostAnalytics{PostID: postID, Comments: post.CommentsCount, From: from, To: to}
+	for _, n := range post.ReactionCounts {
+		stats.Reactions += n
+	}
+
+	if stats.Impressions, stats.Views, stats.UniqueViewers, err = s.analytics.GetTotals(ctx, postID); err != nil {
+		return nil, err
+	}
+	if stats.Buckets, err = s.analytics.ListBuckets(ctx, postID, from, to); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
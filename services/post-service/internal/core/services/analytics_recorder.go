@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// maxPendingBuckets : au-delà (base indisponible trop longtemps), les nouveaux événements sont abandonnés
+// plutôt que de faire grossir la mémoire sans limite
+const maxPendingBuckets = 100_000
+
+// maxSeenReaders : lecteurs déjà comptés sur la tranche horaire en cours (même règle que maxPendingBuckets)
+const maxSeenReaders = 1_000_000
+
+type bucketKey struct {
+	postID string
+	bucket time.Time
+}
+
+type pendingBucket struct {
+	impressions int
+	views       int
+	viewers     map[string]struct{}
+}
+
+// seenKey : un lecteur compte une fois par post, type d'événement et tranche (cf. domain.ReaderKey)
+type seenKey struct {
+	bucketKey
+	kind   domain.PostEventKind
+	reader string
+}
+
+// AnalyticsRecorder agrège les impressions et vues en mémoire, par post et par heure, et les écrit
+// par lots : une écriture par tranche modifiée et par intervalle, quel que soit le trafic.
+// Chaque réplica a son propre tampon (les écritures sont additives). Un arrêt brutal perd au plus
+// un intervalle d'événements : ce sont des statistiques, pas des données.
+// Le dédoublonnage des lecteurs est lui aussi propre au réplica : un lecteur dont les appels sont
+// répartis sur N réplicas compte au plus N fois par heure, jamais 500 fois par appel.
+type AnalyticsRecorder struct {
+	repo     ports.AnalyticsRepository
+	viewers  ports.ViewerCounter // nil = spectateurs uniques non comptés
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[bucketKey]*pendingBucket
+	seen    map[seenKey]struct{}
+	dropped int
+}
+
+func NewAnalyticsRecorder(repo ports.AnalyticsRepository, viewers ports.ViewerCounter, interval time.Duration) *AnalyticsRecorder {
+	return &AnalyticsRecorder{repo: repo, viewers: viewers, interval: interval, pending: map[bucketKey]*pendingBucket{}, seen: map[seenKey]struct{}{}}
+}
+
+// Record ne touche ni la base ni Redis : il ne ralentit jamais la requête du lecteur.
+// Les événements d'un lecteur non identifiable, ou déjà comptés sur la tranche, sont ignorés.
+func (r *AnalyticsRecorder) Record(events []domain.PostEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range events {
+		if e.Reader == "" {
+			continue
+		}
+		key := bucketKey{postID: e.PostID, bucket: domain.BucketOf(e.At)}
+		seen := seenKey{bucketKey: key, kind: e.Kind, reader: e.Reader}
+		if _, ok := r.seen[seen]; ok {
+			continue
+		}
+		if len(r.seen) >= maxSeenReaders {
+			r.dropped++
+			continue
+		}
+
+		b, ok := r.pending[key]
+		if !ok {
+			if len(r.pending) >= maxPendingBuckets {
+				r.dropped++
+				continue
+			}
+			b = &pendingBucket{viewers: map[string]struct{}{}}
+			r.pending[key] = b
+		}
+		r.seen[seen] = struct{}{}
+
+		switch e.Kind {
+		case domain.PostImpression:
+			b.impressions++
+		case domain.PostView:
+			b.views++
+			if e.ViewerID != "" {
+				b.viewers[e.ViewerID] = struct{}{}
+			}
+		}
+	}
+}
+
+// Run bloque jusqu'à l'annulation du contexte, puis écrit une dernière fois le tampon
+func (r *AnalyticsRecorder) Run(ctx context.Context) {
+	slog.Info("📈 Analytics recorder started", "interval", r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Le contexte est annulé : l'écriture finale a son propre délai
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			r.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			r.flush(ctx)
+		}
+	}
+}
+
+func (r *AnalyticsRecorder) flush(ctx context.Context) {
+	r.mu.Lock()
+	pending, dropped := r.pending, r.dropped
+	r.pending, r.dropped = map[bucketKey]*pendingBucket{}, 0
+	r.forgetReaders(domain.BucketOf(time.Now()))
+	r.mu.Unlock()
+
+	if dropped > 0 {
+		slog.Warn("Analytics buffer full, events dropped", "count", dropped)
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	deltas := make([]domain.AnalyticsDelta, 0, len(pending))
+	for key, b := range pending {
+		viewerIDs := make([]string, 0, len(b.viewers))
+		for id := range b.viewers {
+			viewerIDs = append(viewerIDs, id)
+		}
+		deltas = append(deltas, domain.AnalyticsDelta{
+			PostID:      key.postID,
+			Bucket:      key.bucket,
+			Impressions: b.impressions,
+			Views:       b.views,
+			ViewerIDs:   viewerIDs,
+		})
+	}
+
+	// Redis indisponible : les compteurs sont tout de même écrits, les cardinalités restent en l'état
+	if r.viewers != nil {
+		if err := r.viewers.CountViewers(ctx, deltas); err != nil {
+			slog.Error("Failed to count unique viewers", "error", err)
+		}
+	}
+
+	if err := r.repo.ApplyDeltas(ctx, deltas); err != nil {
+		// Remis en tampon pour le prochain essai (l'ajout aux HyperLogLog, lui, est idempotent)
+		slog.Error("Failed to write post analytics, retrying next flush", "buckets", len(deltas), "error", err)
+		r.requeue(pending)
+		return
+	}
+	slog.Debug("Post analytics flushed", "buckets", len(deltas))
+}
+
+// forgetReaders oublie les lecteurs des tranches terminées (les événements sont datés à la réception :
+// aucune ne peut plus en recevoir). Appelé sous r.mu.
+func (r *AnalyticsRecorder) forgetReaders(current time.Time) {
+	for key := range r.seen {
+		if key.bucket.Before(current) {
+			delete(r.seen, key)
+		}
+	}
+}
+
+// requeue fusionne un lot non écrit avec les événements arrivés entre-temps
+func (r *AnalyticsRecorder) requeue(batch map[bucketKey]*pendingBucket) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, b := range batch {
+		current, ok := r.pending[key]
+		if !ok {
+			if len(r.pending) >= maxPendingBuckets {
+				r.dropped += b.impressions + b.views
+				continue
+			}
+			r.pending[key] = b
+			continue
+		}
+		current.impressions += b.impressions
+		current.views += b.views
+		for id := range b.viewers {
+			current.viewers[id] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// fakeAnalyticsRepo : mémorise les lots écrits, ou échoue sur commande
+type fakeAnalyticsRepo struct {
+	ports.AnalyticsRepository
+	fail    bool
+	applied [][]domain.AnalyticsDelta
+}
+
+func (r *fakeAnalyticsRepo) ApplyDeltas(ctx context.Context, deltas []domain.AnalyticsDelta) error {
+	if r.fail {
+		return errors.New("base indisponible")
+	}
+	r.applied = append(r.applied, deltas)
+	return nil
+}
+
+func event(postID, reader string, kind domain.PostEventKind, at time.Time) domain.PostEvent {
+	return domain.PostEvent{PostID: postID, Reader: reader, Kind: kind, At: at}
+}
+
+func TestAnalyticsRecorderCountsEachReaderOncePerBucket(t *testing.T) {
+	repo := &fakeAnalyticsRepo{}
+	r := NewAnalyticsRecorder(repo, nil, time.Minute)
+	now := time.Now().UTC()
+
+	// Le même lecteur rejoue 500 impressions : une seule compte
+	batch := make([]domain.PostEvent, domain.MaxPostEventsPerBatch)
+	for i := range batch {
+		batch[i] = event("p1", "session:1", domain.PostImpression, now)
+	}
+	r.Record(batch)
+	r.Record([]domain.PostEvent{
+		event("p1", "session:1", domain.PostView, now),       // Autre type d'événement : compté
+		event("p1", "session:2", domain.PostImpression, now), // Autre lecteur : compté
+		event("p1", "", domain.PostImpression, now),          // Lecteur non identifiable : ignoré
+	})
+
+	r.flush(context.Background())
+	if len(repo.applied) != 1 || len(repo.applied[0]) != 1 {
+		t.Fatalf("lots écrits = %v, want un delta", repo.applied)
+	}
+	d := repo.applied[0][0]
+	if d.Impressions != 2 || d.Views != 1 {
+		t.Errorf("impressions=%d views=%d, want 2 et 1", d.Impressions, d.Views)
+	}
+}
+
+func TestAnalyticsRecorderForgetsReadersOfPastBuckets(t *testing.T) {
+	repo := &fakeAnalyticsRepo{}
+	r := NewAnalyticsRecorder(repo, nil, time.Minute)
+	past := time.Now().UTC().Add(-2 * time.Hour)
+
+	r.Record([]domain.PostEvent{event("p1", "user:u1", domain.PostView, past)})
+	r.flush(context.Background())
+	if len(r.seen) != 0 {
+		t.Errorf("%d lecteurs retenus après la fin de leur tranche, want 0", len(r.seen))
+	}
+}
+
+func TestAnalyticsRecorderRequeuesOnWriteFailure(t *testing.T) {
+	repo := &fakeAnalyticsRepo{fail: true}
+	r := NewAnalyticsRecorder(repo, nil, time.Minute)
+	now := time.Now().UTC()
+
+	r.Record([]domain.PostEvent{event("p1", "user:u1", domain.PostView, now)})
+	r.flush(context.Background())
+	r.Record([]domain.PostEvent{event("p1", "user:u2", domain.PostView, now)})
+
+	repo.fail = false
+	r.flush(context.Background())
+	if len(repo.applied) != 1 {
+		t.Fatalf("lots écrits = %d, want 1", len(repo.applied))
+	}
+	d := repo.applied[0][0]
+	if d.Views != 2 {
+		t.Errorf("views=%d, want 2 (le lot en échec fusionné avec le suivant)", d.Views)
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+type bookmarkService struct {
+	bookmarks ports.BookmarkRepository
+	posts     *service // Réutilise checkVisible, filterVisible et l'hydratation du lecteur
+}
+
+func NewBookmarkService(bookmarks ports.BookmarkRepository, repo ports.PostRepository, reactions ports.ReactionRepository, polls ports.PollRepository, relations ports.RelationChecker) ports.BookmarkService {
+	return &bookmarkService{
+		bookmarks: bookmarks,
+		posts:     &service{repo: repo, reactions: reactions, bookmarks: bookmarks, polls: polls, relations: relations},
+	}
+}
+
+// Bookmark : on n'enregistre que ce qu'on peut lire (post publié et visible)
+func (s *bookmarkService) Bookmark(ctx context.Context, userID, postID, collectionID string) (*domain.Bookmark, error) {
+	post, err := s.posts.interactivePost(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if collectionID != "" {
+		if _, err := s.bookmarks.FindCollection(ctx, userID, collectionID); err != nil {
+			return nil, err
+		}
+	}
+
+	bookmark, err := s.bookmarks.SaveBookmark(ctx, &domain.Bookmark{
+		UserID:       userID,
+		PostID:       postID,
+		CollectionID: collectionID,
+		CreatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	bookmark.Post = post
+	return bookmark, nil
+}
+
+func (s *bookmarkService) Unbookmark(ctx context.Context, userID, postID string) error {
+	return s.bookmarks.DeleteBookmark(ctx, userID, postID)
+}
+
+// ListBookmarks : pagination keyset sur (date d'enregistrement, post), deux signets pouvant partager leur date.
+// Un signet dont le post a été supprimé, masqué ou n'est plus visible du lecteur n'est pas une erreur :
+// il est renvoyé avec la pierre tombale du post, que l'utilisateur peut retirer de ses signets.
+func (s *bookmarkService) ListBookmarks(ctx context.Context, userID, collectionID string, showSensitive bool, limit int, cursor string) ([]*domain.Bookmark, string, error) {
+	after, err := domain.DecodeKeysetCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if collectionID != "" {
+		if _, err := s.bookmarks.FindCollection(ctx, userID, collectionID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	bookmarks, err := s.bookmarks.ListBookmarks(ctx, userID, collectionID, limit+1, after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(bookmarks) > limit {
+		bookmarks = bookmarks[:limit]
+		last := bookmarks[len(bookmarks)-1]
+		nextCursor = domain.KeysetCursor{At: last.CreatedAt, ID: last.PostID}.Encode()
+	}
+
+	if err := s.hydrate(ctx, bookmarks, userID, showSensitive); err != nil {
+		return nil, "", err
+	}
+	return bookmarks, nextCursor, nil
+}
+
+// hydrate : même chemin de lecture que les listes de posts (visibilité en batch, sondages, floutage),
+// puis contexte du lecteur, pour les seuls posts encore lisibles
+func (s *bookmarkService) hydrate(ctx context.Context, bookmarks []*domain.Bookmark, userID string, showSensitive bool) error {
+	candidates := make([]*domain.Post, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if !b.Post.IsDeleted() && b.Post.IsPublished() {
+			candidates = append(candidates, b.Post)
+		}
+	}
+
+	visible, err := s.posts.readable(ctx, candidates, userID, showSensitive)
+	if err != nil {
+		return err
+	}
+	readable := make(map[string]bool, len(visible))
+	for _, p := range visible {
+		readable[p.ID] = true
+	}
+
+	for _, b := range bookmarks {
+		if !readable[b.PostID] {
+			b.Post = b.Post.Tombstone()
+			b.Unavailable = true
+		}
+	}
+
+	return s.posts.attachViewer(ctx, visible, userID)
+}
+
+// --- Collections ---
+
+func (s *bookmarkService) CreateCollection(ctx context.Context, userID, name string) (*domain.Collection, error) {
+	c, err := domain.NewCollection(userID, name, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.bookmarks.CreateCollection(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *bookmarkService) RenameCollection(ctx context.Context, userID, collectionID, name string) (*domain.Collection, error) {
+	c, err := s.bookmarks.FindCollection(ctx, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Rename(name, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.bookmarks.RenameCollection(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ReorderCollections : l'ordre complet est exigé (pas de déplacement relatif, ambigu en cas de requêtes concurrentes)
+func (s *bookmarkService) ReorderCollections(ctx context.Context, userID string, orderedIDs []string) ([]*domain.Collection, error) {
+	existing, err := s.bookmarks.ListCollections(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateCollectionOrder(existing, orderedIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.bookmarks.ReorderCollections(ctx, userID, orderedIDs); err != nil {
+		return nil, err
+	}
+	return s.bookmarks.ListCollections(ctx, userID)
+}
+
+func (s *bookmarkService) DeleteCollection(ctx context.Context, userID, collectionID string) error {
+	return s.bookmarks.DeleteCollection(ctx, userID, collectionID)
+}
+
+func (s *bookmarkService) ListCollections(ctx context.Context, userID string) ([]*domain.Collection, error) {
+	return s.bookmarks.ListCollections(ctx, userID)
+}
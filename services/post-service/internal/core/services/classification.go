@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// screen soumet un post au classifieur avant sa publication.
+// reject : rien n'est enregistré (domain.ErrContentRejected). review : le post passe en "held" avec son
+// signalement (post.Review), que le repository enregistre avec lui : un post retenu a toujours son dossier.
+// L'approbation (dismiss/warn) le publiera, cf. moderationService.release. Sans classifieur configuré, tout passe.
+func (s *service) screen(ctx context.Context, post *domain.Post) error {
+	if s.classifier == nil {
+		return nil
+	}
+
+	verdict, err := s.classifier.Classify(ctx, post)
+	if err != nil {
+		// Classifieur indisponible : publier quand même, ou retenir par prudence (défaut)
+		if s.policy.ClassifierFailOpen {
+			slog.Warn("Content classifier unavailable, post allowed", "post_id", post.ID, "error", err)
+			return nil
+		}
+		slog.Warn("Content classifier unavailable, post held for review", "post_id", post.ID, "error", err)
+		verdict = domain.Classification{Verdict: domain.VerdictReview, Reason: "classifier unavailable"}
+	}
+
+	switch verdict.Verdict {
+	case domain.VerdictReject:
+		slog.Info("Post rejected by content classifier", "user_id", post.UserID, "labels", verdict.Labels, "reason", verdict.Reason)
+		return domain.ErrContentRejected
+	case domain.VerdictReview:
+		report, err := domain.NewReport(domain.AutomatedReporterID, verdict.ReportReason(), verdict.Reason)
+		if err != nil {
+			return err
+		}
+		post.Status = domain.PostStatusHeld
+		post.Review = report
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// RepliesPreviewSize : nombre de réponses renvoyées avec chaque commentaire racine
+const RepliesPreviewSize = 3
+
+type commentService struct {
+	comments ports.CommentRepository
+	posts    *service // Réutilise interactivePost (visibilité du post commenté)
+}
+
+func NewCommentService(comments ports.CommentRepository, repo ports.PostRepository, relations ports.RelationChecker, pub ports.EventPublisher) ports.CommentService {
+	return &commentService{comments: comments, posts: &service{repo: repo, relations: relations, publisher: pub}}
+}
+
+func (s *commentService) CreateComment(ctx context.Context, postID, parentID, userID, content string) (*domain.Comment, error) {
+	// 0. On ne commente que ce qu'on peut lire
+	if _, err := s.posts.interactivePost(ctx, postID, userID); err != nil {
+		return nil, err
+	}
+
+	// 1. Un seul niveau de réponses : une réponse à une réponse est rattachée à la racine
+	if parentID != "" {
+		parent, err := s.comments.FindCommentByID(ctx, parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.PostID != postID {
+			return nil, domain.ErrInvalidParent
+		}
+		if parent.IsReply() {
+			parentID = parent.ParentID
+		}
+	}
+
+	// 2. Validation (Factory du domaine)
+	comment, err := domain.NewComment(postID, parentID, userID, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Sauvegarde + compteurs + notification (l'auteur du post et du commentaire parent voudront
+	// être prévenus), dans la même transaction
+	err = s.comments.SaveComment(ctx, comment, func(postAuthorID string) ([]*domain.OutboxMessage, error) {
+		created, err := s.posts.publisher.CommentCreatedMessage(ctx, comment, postAuthorID)
+		if err != nil {
+			return nil, err
+		}
+		return []*domain.OutboxMessage{created}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *commentService) EditComment(ctx context.Context, commentID, userID, content string) (*domain.Comment, error) {
+	comment, err := s.comments.FindCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := comment.Edit(userID, content); err != nil {
+		return nil, err
+	}
+
+	if err := s.comments.UpdateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *commentService) DeleteComment(ctx context.Context, commentID, userID string) error {
+	comment, err := s.comments.FindCommentByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	// L'auteur du post peut modérer les commentaires sous son post
+	post, err := s.posts.repo.FindByID(ctx, comment.PostID)
+	if err != nil {
+		return err
+	}
+	if !comment.CanBeDeletedBy(userID, post.UserID) {
+		return domain.ErrCommentForbidden
+	}
+
+	return s.comments.DeleteComment(ctx, comment)
+}
+
+// ListComments : pagination keyset sur created_at (ordre chronologique, comme une conversation).
+// Les commentaires d'un post que le lecteur ne peut pas voir sont "introuvables", comme le post.
+func (s *commentService) ListComments(ctx context.Context, postID, parentID, viewerID string, limit int, cursor string) ([]*domain.Comment, string, error) {
+	if _, err := s.posts.interactivePost(ctx, postID, viewerID); err != nil {
+		return nil, "", err
+	}
+
+	var cursorTime time.Time
+	if cursor != "" {
+		t, err := time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			return nil, "", domain.ErrInvalidPageToken
+		}
+		cursorTime = t
+	}
+
+	// On demande un élément de plus pour savoir s'il existe une page suivante
+	comments, err := s.comments.ListComments(ctx, postID, parentID, limit+1, cursorTime)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(comments) > limit {
+		comments = comments[:limit]
+		nextCursor = comments[len(comments)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	// Aperçu des réponses pour les racines (une seule requête pour toute la page)
+	if parentID == "" && len(comments) > 0 {
+		if err := s.attachRepliesPreview(ctx, comments); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return comments, nextCursor, nil
+}
+
+func (s *commentService) attachRepliesPreview(ctx context.Context, roots []*domain.Comment) error {
+	parentIDs := make([]string, 0, len(roots))
+	byID := make(map[string]*domain.Comment, len(roots))
+	for _, c := range roots {
+		c.Replies = []*domain.Comment{}
+		if c.RepliesCount > 0 {
+			parentIDs = append(parentIDs, c.ID)
+			byID[c.ID] = c
+		}
+	}
+	if len(parentIDs) == 0 {
+		return nil
+	}
+
+	replies, err := s.comments.ListRepliesPreview(ctx, parentIDs, RepliesPreviewSize)
+	if err != nil {
+		return err
+	}
+	for _, r := range replies {
+		if parent, ok := byID[r.ParentID]; ok {
+			parent.Replies = append(parent.Replies, r)
+		}
+	}
+	return nil
+}
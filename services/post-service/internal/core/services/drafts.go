@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// SaveDraft crée ou met à jour un brouillon. Rien n'est publié : ni post.created, ni notification
+// de mention (elles partiront à la publication).
+func (s *service) SaveDraft(ctx context.Context, draftID, userID, content, contentWarning string, media []domain.Media, visibility domain.Visibility) (*domain.Post, error) {
+	visibility, err := resolveVisibility(visibility)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+
+	// 1. Nouveau brouillon
+	if draftID == "" {
+		draft, err := domain.NewPost(domain.NewPostParams{
+			ID:             uuid.New().String(),
+			UserID:         userID,
+			Content:        content,
+			ContentWarning: contentWarning,
+			Media:          media,
+			Status:         domain.PostStatusDraft,
+			Visibility:     visibility,
+			Language:       s.detectLanguage(content),
+		}, s.policy.Limits, now)
+		if err != nil {
+			return nil, err
+		}
+		draft.Entities = s.resolveEntities(ctx, draft.Content)
+		if err := s.repo.Save(ctx, draft); err != nil {
+			return nil, err
+		}
+		return draft, nil
+	}
+
+	// 2. Édition d'un brouillon existant (ou d'un post planifié, qui garde sa date)
+	draft, err := s.findOwnDraft(ctx, draftID, userID)
+	if err != nil {
+		return nil, err
+	}
+	content, media, err = s.policy.Limits.CleanBody(content, media)
+	if err != nil {
+		return nil, err
+	}
+	if content == "" && len(media) == 0 {
+		return nil, domain.ErrEmptyPost
+	}
+	contentWarning, err = domain.CleanContentWarning(contentWarning)
+	if err != nil {
+		return nil, err
+	}
+
+	draft.Content = content
+	draft.ContentWarning = contentWarning
+	draft.Media = media
+	draft.Visibility = visibility
+	draft.Language = s.detectLanguage(content) // Aucune langue n'est précisée pour un brouillon
+	draft.Entities = s.resolveEntities(ctx, content)
+	draft.UpdatedAt = now
+
+	// Un post planifié a déjà été classé : sa nouvelle version doit l'être aussi
+	// (retenu : Update enregistre le statut et le dossier avec le contenu)
+	if draft.Status == domain.PostStatusScheduled {
+		if err := s.screen(ctx, draft); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// ListDrafts : brouillons, posts planifiés et posts retenus de l'auteur, du plus récemment modifié au plus ancien
+func (s *service) ListDrafts(ctx context.Context, userID string, limit int, cursor string) ([]*domain.Post, string, error) {
+	var cursorTime time.Time
+	if cursor != "" {
+		t, err := time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			return nil, "", domain.ErrInvalidPageToken
+		}
+		cursorTime = t
+	}
+
+	// Un élément de plus pour savoir s'il existe une page suivante
+	drafts, err := s.repo.ListDrafts(ctx, userID, limit+1, cursorTime)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(drafts) > limit {
+		drafts = drafts[:limit]
+		nextCursor = drafts[len(drafts)-1].UpdatedAt.Format(time.RFC3339Nano)
+	}
+	return drafts, nextCursor, nil
+}
+
+// SchedulePost programme la publication. Le scheduler (cf. scheduler.go) fera le reste.
+// Le contenu est classé ici, puis à chaque édition tant que le post reste planifié (cf. SaveDraft).
+func (s *service) SchedulePost(ctx context.Context, postID, userID string, publishAt time.Time) (*domain.Post, error) {
+	post, err := s.findOwnDraft(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if publishAt.IsZero() {
+		publishAt = now
+	}
+	if err := post.Schedule(publishAt, now); err != nil {
+		return nil, err
+	}
+
+	// Retenu : il sera publié à l'approbation du modérateur, pas à PublishAt
+	if err := s.screen(ctx, post); err != nil {
+		return nil, err
+	}
+
+	// Le dossier d'un post retenu est ouvert dans la même transaction
+	if err := s.repo.UpdateSchedule(ctx, post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// CancelScheduledPost remet le post en brouillon (trop tard s'il est déjà publié : domain.ErrNotADraft)
+func (s *service) CancelScheduledPost(ctx context.Context, postID, userID string) (*domain.Post, error) {
+	post, err := s.findOwnDraft(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := post.CancelSchedule(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateSchedule(ctx, post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// findOwnDraft : le brouillon d'un autre est "introuvable" (son existence n'est pas publique)
+func (s *service) findOwnDraft(ctx context.Context, postID, userID string) (*domain.Post, error) {
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != userID {
+		if !post.IsPublished() {
+			return nil, domain.ErrPostNotFound
+		}
+		return nil, domain.ErrPostForbidden
+	}
+	if post.IsPublished() {
+		return nil, domain.ErrNotADraft
+	}
+	if post.IsHeld() {
+		return nil, domain.ErrPostHeld // Le modérateur doit approuver ce qui a été classé
+	}
+	return post, nil
+}
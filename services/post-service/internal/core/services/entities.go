@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// resolveEntities parse le contenu puis résout les @mentions via l'Identity Service.
+// Une mention inconnue n'est pas une entité (c'est du texte). Si l'annuaire est indisponible,
+// on publie quand même le post : les hashtags sont gardés, les mentions sont perdues.
+func (s *service) resolveEntities(ctx context.Context, content string) []domain.Entity {
+	parsed := domain.ParseEntities(content)
+
+	var usernames []string
+	for _, e := range parsed {
+		if e.Type == domain.EntityMention {
+			usernames = append(usernames, e.Text)
+		}
+	}
+
+	var ids map[string]string
+	if len(usernames) > 0 {
+		var err error
+		if ids, err = s.users.ResolveUsernames(ctx, usernames); err != nil {
+			slog.Error("Failed to resolve mentions", "error", err)
+		}
+	}
+
+	entities := make([]domain.Entity, 0, len(parsed))
+	for _, e := range parsed {
+		if e.Type == domain.EntityMention {
+			if e.UserID = ids[e.Text]; e.UserID == "" {
+				continue
+			}
+		}
+		entities = append(entities, e)
+	}
+	return entities
+}
+
+// mentionMessages : un post.user_mentioned pour chaque NOUVEL utilisateur mentionné
+// (alreadyNotified : mentions présentes avant une édition). On ne se notifie pas soi-même,
+// et on ne notifie pas quelqu'un qui ne peut pas voir le post (ex: mentionné dans un post "followers").
+func (s *service) mentionMessages(ctx context.Context, post *domain.Post, alreadyNotified []string) ([]*domain.OutboxMessage, error) {
+	skip := map[string]bool{post.UserID: true}
+	for _, id := range alreadyNotified {
+		skip[id] = true
+	}
+
+	var events []*domain.OutboxMessage
+	for _, userID := range post.MentionedUserIDs() {
+		if skip[userID] {
+			continue
+		}
+		if err := s.checkVisible(ctx, post, userID); err != nil {
+			continue
+		}
+		mentioned, err := s.publisher.UserMentionedMessage(ctx, post, userID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, mentioned)
+	}
+	return events, nil
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// ExportUserPosts : l'utilisateur récupère tout ce qu'il a écrit, y compris ce que personne d'autre ne voit
+// (brouillons, posts retenus ou masqués, suppressions encore restaurables). Pas de viewer : rien n'est filtré.
+func (s *service) ExportUserPosts(ctx context.Context, userID string, limit int, cursor string) ([]*domain.Post, string, error) {
+	after, err := domain.DecodeKeysetCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	posts, err := s.repo.ListAllByAuthor(ctx, userID, limit, after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Page incomplète = fin de liste (l'export parcourt tout : pas d'appel de trop)
+	nextCursor := ""
+	if len(posts) == limit {
+		last := posts[len(posts)-1]
+		nextCursor = domain.KeysetCursor{At: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	return posts, nextCursor, nil
+}
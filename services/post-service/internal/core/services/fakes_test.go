@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// fakeKeys : réservations en mémoire, retient le sort de la clé
+type fakeKeys struct {
+	ports.IdempotencyStore
+	completed, released int
+}
+
+func (k *fakeKeys) Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	return nil, true, nil
+}
+
+func (k *fakeKeys) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	k.completed++
+	return nil
+}
+
+func (k *fakeKeys) Release(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	k.released++
+	return nil
+}
+
+// fakePostRepo : posts en mémoire (seules les méthodes utiles aux tests sont implémentées)
+type fakePostRepo struct {
+	ports.PostRepository
+	posts  map[string]*domain.Post
+	events []*domain.OutboxMessage // Événements écrits avec les posts
+}
+
+func (r *fakePostRepo) Save(ctx context.Context, post *domain.Post, events ...*domain.OutboxMessage) error {
+	r.posts[post.ID] = post
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func (r *fakePostRepo) SaveThread(ctx context.Context, posts []*domain.Post, events ...*domain.OutboxMessage) error {
+	for _, p := range posts {
+		r.posts[p.ID] = p
+	}
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func (r *fakePostRepo) Update(ctx context.Context, post *domain.Post, events ...*domain.OutboxMessage) error {
+	copied := *post
+	r.posts[post.ID] = &copied
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func (r *fakePostRepo) FindThread(ctx context.Context, threadID string) ([]*domain.Post, error) {
+	var thread []*domain.Post
+	for _, p := range r.posts {
+		if p.ThreadID == threadID && !p.IsDeleted() {
+			copied := *p
+			thread = append(thread, &copied)
+		}
+	}
+	slices.SortFunc(thread, func(a, b *domain.Post) int { return a.ThreadPosition - b.ThreadPosition })
+	return thread, nil
+}
+
+func (r *fakePostRepo) FindByID(ctx context.Context, postID string) (*domain.Post, error) {
+	if p, ok := r.posts[postID]; ok {
+		copied := *p
+		return &copied, nil
+	}
+	return nil, domain.ErrPostNotFound
+}
+
+func (r *fakePostRepo) ReleaseHeld(ctx context.Context, postID string, now time.Time, events ...*domain.OutboxMessage) (*domain.Post, error) {
+	p, ok := r.posts[postID]
+	if !ok || !p.IsHeld() {
+		return nil, domain.ErrNotHeld
+	}
+	p.Status = domain.PostStatusPublished
+	r.events = append(r.events, events...)
+	copied := *p
+	return &copied, nil
+}
+
+func (r *fakePostRepo) Pin(ctx context.Context, post *domain.Post, max int) error {
+	stored := r.posts[post.ID]
+	if stored.IsPinned() {
+		return nil
+	}
+	pinned := 0
+	for _, p := range r.posts {
+		if p.UserID == post.UserID && p.IsPinned() && p.IsPublished() && !p.IsHidden() && !p.IsDeleted() {
+			pinned++
+		}
+	}
+	if pinned >= max {
+		return domain.ErrTooManyPinnedPosts
+	}
+	stored.PinnedAt = post.PinnedAt
+	return nil
+}
+
+func (r *fakePostRepo) Unpin(ctx context.Context, postID string) error {
+	r.posts[postID].PinnedAt = time.Time{}
+	return nil
+}
+
+// fakePublisher : messages d'outbox réduits à leur sujet et à l'ID du post concerné
+type fakePublisher struct {
+	ports.EventPublisher
+}
+
+func (p *fakePublisher) PostCreatedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error) {
+	return &domain.OutboxMessage{ID: post.ID, Subject: "post.created"}, nil
+}
+
+func (p *fakePublisher) UserMentionedMessage(ctx context.Context, post *domain.Post, mentionedUserID string) (*domain.OutboxMessage, error) {
+	return &domain.OutboxMessage{ID: post.ID + ":" + mentionedUserID, Subject: "post.user_mentioned"}, nil
+}
+
+func (p *fakePublisher) PostUpdatedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error) {
+	return &domain.OutboxMessage{ID: post.ID, Subject: "post.updated"}, nil
+}
+
+func (p *fakePublisher) PostDeletedMessage(ctx context.Context, post *domain.Post) (*domain.OutboxMessage, error) {
+	return &domain.OutboxMessage{ID: post.ID, Subject: "post.deleted"}, nil
+}
+
+// fakePolls : aucun post n'a de sondage
+type fakePolls struct {
+	ports.PollRepository
+}
+
+func (p *fakePolls) GetPolls(ctx context.Context, postIDs []string) (map[string]*domain.Poll, error) {
+	return map[string]*domain.Poll{}, nil
+}
+
+// fakeUsers : annuaire figé (username -> userID)
+type fakeUsers struct {
+	ports.UserDirectory
+	ids map[string]string
+}
+
+func (u *fakeUsers) ResolveUsernames(ctx context.Context, usernames []string) (map[string]string, error) {
+	found := make(map[string]string)
+	for _, name := range usernames {
+		if id, ok := u.ids[name]; ok {
+			found[name] = id
+		}
+	}
+	return found, nil
+}
+
+// fakeRelations : relations figées (viewerID -> auteurs suivis)
+type fakeRelations struct {
+	ports.RelationChecker
+	following map[string][]string
+}
+
+func (r *fakeRelations) CheckRelation(ctx context.Context, viewerID, authorID string) (domain.Relation, error) {
+	for _, id := range r.following[viewerID] {
+		if id == authorID {
+			return domain.Relation{IsFollowing: true}, nil
+		}
+	}
+	return domain.Relation{}, nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// Empreintes des requêtes : une clé rejouée avec un autre contenu est refusée (domain.ErrIdempotencyKeyReused)
+type createRequest struct {
+	Content        string
+	ContentWarning string
+	Media          []domain.Media
+	Visibility     domain.Visibility
+	Language       string
+	Poll           *domain.PollInput
+	RepostOf       string
+}
+
+type threadRequest struct {
+	Posts      []domain.ThreadPostInput
+	Visibility domain.Visibility
+}
+
+type updateRequest struct {
+	PostID         string
+	Content        string
+	ContentWarning string
+	Media          []domain.Media
+}
+
+// idempotent exécute 'run' au plus une fois par (utilisateur, opération, clé).
+// Un essai rejoué renvoie le post de la première requête dans son état actuel, sans rien refaire
+// (ni écriture, ni événement). Pendant que la première est en cours, les suivantes sont refusées
+// (domain.ErrRequestInProgress) plutôt que mises en attente. Un échec libère la clé, sauf si la création
+// a malgré tout été enregistrée (cf. committedDespite) : un nouvel essai créerait alors un doublon.
+func (s *service) idempotent(ctx context.Context, userID string, op domain.IdempotentOperation, key, postID string, request any, run func() (*domain.Post, error)) (*domain.Post, error) {
+	if key == "" {
+		return run()
+	}
+
+	rec, err := domain.NewIdempotencyRecord(userID, op, key, postID, request, time.Now().UTC(), s.policy.IdempotencyTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, reserved, err := s.keys.Reserve(ctx, rec)
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		if err := rec.Replay(existing); err != nil {
+			return nil, err
+		}
+		slog.Info("Idempotent request replayed", "user_id", userID, "operation", op, "post_id", existing.PostID)
+		if op == domain.OpDeletePost {
+			return nil, nil
+		}
+		return s.repo.FindByID(ctx, existing.PostID)
+	}
+
+	post, err := run()
+	if err != nil {
+		if post = s.committedDespite(ctx, op, postID, err); post == nil {
+			if relErr := s.keys.Release(ctx, rec); relErr != nil {
+				slog.Error("Failed to release idempotency key", "user_id", userID, "operation", op, "error", relErr)
+			}
+			return nil, err
+		}
+	}
+
+	// L'opération a abouti : un échec ici ne la rend pas moins réussie. La clé restera "en cours"
+	// jusqu'à domain.IdempotencyPendingTimeout, puis un nouvel essai pourra la reprendre.
+	if err := s.keys.Complete(ctx, rec); err != nil {
+		slog.Error("Failed to complete idempotency key", "user_id", userID, "operation", op, "error", err)
+	}
+	return post, nil
+}
+
+// committedDespite : une création en erreur a-t-elle quand même été enregistrée (erreur après le Save,
+// COMMIT dont la réponse s'est perdue) ? Si oui, renvoie le post : la requête a réussi et la clé doit
+// le rejouer. Une modification ou une suppression rejouée ne change rien : seule la création est vérifiée.
+func (s *service) committedDespite(ctx context.Context, op domain.IdempotentOperation, postID string, runErr error) *domain.Post {
+	if op != domain.OpCreatePost && op != domain.OpCreateThread {
+		return nil
+	}
+	// Le contexte de la requête peut être la cause de l'échec : la vérification ne doit pas en dépendre
+	post, err := s.repo.FindByID(context.WithoutCancel(ctx), postID)
+	if err != nil {
+		return nil
+	}
+	slog.Warn("Post saved despite a later error, idempotency key kept", "post_id", postID, "operation", op, "error", runErr)
+	return post
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+func TestIdempotentKeepsKeyWhenCreationWasCommitted(t *testing.T) {
+	repo := &fakePostRepo{posts: map[string]*domain.Post{"p1": {ID: "p1", UserID: "u1"}}}
+	keys := &fakeKeys{}
+	s := &service{repo: repo, keys: keys}
+
+	post, err := s.idempotent(context.Background(), "u1", domain.OpCreatePost, "key", "p1", "req", func() (*domain.Post, error) {
+		return nil, errors.New("connexion perdue après le COMMIT")
+	})
+	if err != nil {
+		t.Fatalf("err = %v, want nil (le post est enregistré)", err)
+	}
+	if post == nil || post.ID != "p1" {
+		t.Fatalf("post = %+v, want p1", post)
+	}
+	if keys.completed != 1 || keys.released != 0 {
+		t.Errorf("completed=%d released=%d, want la clé complétée", keys.completed, keys.released)
+	}
+}
+
+func TestIdempotentReleasesKeyWhenNothingWasWritten(t *testing.T) {
+	keys := &fakeKeys{}
+	s := &service{repo: &fakePostRepo{posts: map[string]*domain.Post{}}, keys: keys}
+
+	_, err := s.idempotent(context.Background(), "u1", domain.OpCreatePost, "key", "p1", "req", func() (*domain.Post, error) {
+		return nil, domain.ErrContentRejected
+	})
+	if !errors.Is(err, domain.ErrContentRejected) {
+		t.Fatalf("err = %v, want ErrContentRejected", err)
+	}
+	if keys.released != 1 || keys.completed != 0 {
+		t.Errorf("completed=%d released=%d, want la clé libérée", keys.completed, keys.released)
+	}
+}
+
+func TestIdempotentReleasesKeyOnFailedUpdate(t *testing.T) {
+	// Le post existe déjà : son existence ne prouve pas que la modification a été enregistrée
+	keys := &fakeKeys{}
+	s := &service{repo: &fakePostRepo{posts: map[string]*domain.Post{"p1": {ID: "p1"}}}, keys: keys}
+
+	_, err := s.idempotent(context.Background(), "u1", domain.OpUpdatePost, "key", "p1", "req", func() (*domain.Post, error) {
+		return nil, domain.ErrEditWindowExpired
+	})
+	if !errors.Is(err, domain.ErrEditWindowExpired) {
+		t.Fatalf("err = %v, want ErrEditWindowExpired", err)
+	}
+	if keys.released != 1 {
+		t.Errorf("released=%d, want 1", keys.released)
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// fakeDetector : renvoie toujours la même langue et retient le texte soumis
+type fakeDetector struct {
+	lang   string
+	sample string
+	calls  int
+}
+
+func (d *fakeDetector) Detect(text string) string {
+	d.calls++
+	d.sample = text
+	return d.lang
+}
+
+func TestDetectLanguage(t *testing.T) {
+	t.Run("échantillon sans liens ni mentions", func(t *testing.T) {
+		detector := &fakeDetector{lang: "fr"}
+		s := &service{detector: detector}
+
+		got := s.detectLanguage("Une belle randonnée ce matin en montagne https://example.com @alice #rando")
+		if got != "fr" {
+			t.Errorf("langue = %q, want %q", got, "fr")
+		}
+		if want := "Une belle randonnée ce matin en montagne"; detector.sample != want {
+			t.Errorf("échantillon = %q, want %q", detector.sample, want)
+		}
+	})
+
+	t.Run("texte trop court : pas de détection", func(t *testing.T) {
+		detector := &fakeDetector{lang: "fr"}
+		s := &service{detector: detector}
+
+		if got := s.detectLanguage("Merci !"); got != "" {
+			t.Errorf("langue = %q, want vide", got)
+		}
+		if detector.calls != 0 {
+			t.Errorf("détecteur appelé %d fois, want 0", detector.calls)
+		}
+	})
+
+	t.Run("sans détecteur", func(t *testing.T) {
+		s := &service{}
+		if got := s.detectLanguage("Une belle randonnée ce matin en montagne"); got != "" {
+			t.Errorf("langue = %q, want vide", got)
+		}
+	})
+}
+
+func TestCreatePostLanguage(t *testing.T) {
+	const content = "Une belle randonnée ce matin en montagne avec toute la famille"
+	tests := []struct {
+		name     string
+		language string
+		want     string
+		detected bool
+	}{
+		{"langue précisée par l'auteur", "EN", "en", false},
+		{"langue absente : détectée", "", "fr", true},
+		{"langue invalide : détectée", "français", "fr", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := &fakeDetector{lang: "fr"}
+			repo := &fakePostRepo{posts: map[string]*domain.Post{}}
+			s := &service{repo: repo, detector: detector, publisher: &fakePublisher{}, policy: PostPolicy{Limits: domain.DefaultPostLimits}}
+
+			post, err := s.createPost(t.Context(), "p1", "u1", content, "", nil, domain.VisibilityPublic, tt.language, nil)
+			if err != nil {
+				t.Fatalf("createPost: %v", err)
+			}
+			if post.Language != tt.want {
+				t.Errorf("langue = %q, want %q", post.Language, tt.want)
+			}
+			if got := detector.calls > 0; got != tt.detected {
+				t.Errorf("détection = %v, want %v", got, tt.detected)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// Politique de nouvelle tentative des aperçus de liens
+const (
+	linkPreviewMaxAttempts = 3
+	linkPreviewBackoff     = time.Minute // Multiplié par le numéro de la tentative
+	linkPreviewConcurrency = 8           // Requêtes sortantes simultanées par réplica
+)
+
+// LinkPreviewWorker récupère les aperçus des liens postés, hors du chemin de la requête utilisateur.
+// Comme le Scheduler, il tourne sur chaque réplica (FOR UPDATE SKIP LOCKED côté repository).
+type LinkPreviewWorker struct {
+	jobs      ports.LinkPreviewRepository
+	fetcher   ports.LinkFetcher
+	interval  time.Duration
+	batchSize int
+}
+
+func NewLinkPreviewWorker(jobs ports.LinkPreviewRepository, fetcher ports.LinkFetcher, interval time.Duration, batchSize int) *LinkPreviewWorker {
+	return &LinkPreviewWorker{jobs: jobs, fetcher: fetcher, interval: interval, batchSize: batchSize}
+}
+
+// Run bloque jusqu'à l'annulation du contexte
+func (w *LinkPreviewWorker) Run(ctx context.Context) {
+	slog.Info("🔗 Link preview worker started", "interval", w.interval, "batch_size", w.batchSize)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.processDue(ctx)
+		}
+	}
+}
+
+// processDue vide la file des jobs échus, lot par lot
+func (w *LinkPreviewWorker) processDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		jobs, err := w.jobs.ClaimJobs(ctx, time.Now().UTC(), linkPreviewBackoff, w.batchSize)
+		if err != nil {
+			slog.Error("Failed to claim link preview jobs", "error", err)
+			return
+		}
+
+		w.processBatch(ctx, jobs)
+
+		if len(jobs) < w.batchSize {
+			return
+		}
+	}
+}
+
+func (w *LinkPreviewWorker) processBatch(ctx context.Context, jobs []domain.LinkPreviewJob) {
+	sem := make(chan struct{}, linkPreviewConcurrency)
+	var wg sync.WaitGroup
+
+	for _, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(job domain.LinkPreviewJob) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			w.process(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+}
+
+func (w *LinkPreviewWorker) process(ctx context.Context, job domain.LinkPreviewJob) {
+	preview, err := w.fetcher.Fetch(ctx, job.URL)
+	if err != nil {
+		// Cible interdite ou page sans métadonnées : réessayer ne changera rien
+		permanent := errors.Is(err, domain.ErrLinkNotAllowed) || errors.Is(err, domain.ErrNoLinkPreview)
+		if !permanent && job.Attempts < linkPreviewMaxAttempts {
+			slog.Warn("Link preview fetch failed, will retry", "post_id", job.PostID, "attempt", job.Attempts, "error", err)
+			return // Le job reste en file, next_attempt_at a déjà été repoussé
+		}
+		slog.Info("Link preview abandoned", "post_id", job.PostID, "attempts", job.Attempts, "error", err)
+		preview = nil
+	}
+
+	if err := w.jobs.CompleteJob(ctx, job, preview); err != nil {
+		slog.Error("Failed to save link preview", "post_id", job.PostID, "error", err)
+	}
+}
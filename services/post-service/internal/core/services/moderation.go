@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// MaxCaseReports : signalements renvoyés avec un dossier (les compteurs restent exacts au-delà)
+const MaxCaseReports = 100
+
+type moderationService struct {
+	cases    ports.ModerationRepository
+	comments ports.CommentRepository
+	accounts ports.AccountModerator
+	posts    *service // Réutilise checkVisible et softDelete
+}
+
+func NewModerationService(cases ports.ModerationRepository, repo ports.PostRepository, comments ports.CommentRepository, accounts ports.AccountModerator, relations ports.RelationChecker, pub ports.EventPublisher) ports.ModerationService {
+	return &moderationService{
+		cases:    cases,
+		comments: comments,
+		accounts: accounts,
+		posts:    &service{repo: repo, relations: relations, publisher: pub},
+	}
+}
+
+func (s *moderationService) ReportContent(ctx context.Context, reporterID string, targetType domain.ReportTarget, targetID string, reason domain.ReportReason, details string) (*domain.Report, error) {
+	report, err := domain.NewReport(reporterID, reason, details)
+	if err != nil {
+		return nil, err
+	}
+
+	authorID, err := s.resolveTarget(ctx, targetType, targetID, reporterID)
+	if err != nil {
+		return nil, err
+	}
+	if authorID == reporterID {
+		return nil, domain.ErrCannotReportSelf
+	}
+
+	c, err := s.cases.AddReport(ctx, &domain.ModerationCase{
+		TargetType:     targetType,
+		TargetID:       targetID,
+		TargetAuthorID: authorID,
+	}, report)
+	if err != nil {
+		return nil, err
+	}
+	report.CaseID = c.ID
+	return report, nil
+}
+
+// resolveTarget renvoie l'auteur de la cible. Ce que le rapporteur ne peut pas voir n'existe pas pour lui.
+func (s *moderationService) resolveTarget(ctx context.Context, targetType domain.ReportTarget, targetID, reporterID string) (string, error) {
+	switch targetType {
+	case domain.TargetPost:
+		post, err := s.posts.repo.FindByID(ctx, targetID)
+		if err != nil {
+			return "", err
+		}
+		if err := s.posts.checkVisible(ctx, post, reporterID); err != nil {
+			return "", err
+		}
+		return post.UserID, nil
+
+	case domain.TargetComment:
+		comment, err := s.comments.FindCommentByID(ctx, targetID)
+		if err != nil {
+			return "", err
+		}
+		post, err := s.posts.repo.FindByID(ctx, comment.PostID)
+		if err != nil {
+			return "", err
+		}
+		if err := s.posts.checkVisible(ctx, post, reporterID); err != nil {
+			return "", err
+		}
+		return comment.UserID, nil
+
+	case domain.TargetUser:
+		exists, err := s.accounts.UserExists(ctx, targetID)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return "", domain.ErrInvalidReport
+		}
+		return targetID, nil
+	}
+	return "", domain.ErrInvalidReport
+}
+
+// ListCases : même pagination keyset que les autres listes (curseur = date du premier signalement)
+func (s *moderationService) ListCases(ctx context.Context, status domain.CaseStatus, limit int, cursor string) ([]*domain.ModerationCase, string, error) {
+	var cursorTime time.Time
+	if cursor != "" {
+		t, err := time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			return nil, "", domain.ErrInvalidPageToken
+		}
+		cursorTime = t
+	}
+
+	cases, err := s.cases.ListCases(ctx, status, limit+1, cursorTime)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(cases) > limit {
+		cases = cases[:limit]
+		nextCursor = cases[len(cases)-1].FirstReportedAt.Format(time.RFC3339Nano)
+	}
+	return cases, nextCursor, nil
+}
+
+func (s *moderationService) GetCase(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	c, err := s.cases.FindCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Reports, err = s.cases.ListReports(ctx, caseID, MaxCaseReports); err != nil {
+		return nil, err
+	}
+	if c.History, err = s.cases.ListDecisions(ctx, c.TargetType, c.TargetID); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *moderationService) ClaimCase(ctx context.Context, caseID, moderatorID string) (*domain.ModerationCase, error) {
+	c, err := s.cases.FindCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := c.ClaimedBy
+	if err := c.Claim(moderatorID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.cases.SaveClaim(ctx, c, previous); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ResolveCase : la sanction est appliquée AVANT l'archivage. Si l'archivage échoue, le dossier reste
+// pris en charge et le modérateur peut réessayer (les sanctions sont idempotentes).
+func (s *moderationService) ResolveCase(ctx context.Context, caseID, moderatorID string, res domain.Resolution) (*domain.ModerationCase, error) {
+	c, err := s.cases.FindCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	decision, err := c.Resolve(moderatorID, res, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apply(ctx, decision); err != nil {
+		return nil, err
+	}
+
+	// La décision part avec l'archivage (outbox, même transaction)
+	event, err := s.posts.publisher.ModerationDecisionMessage(ctx, decision)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cases.Resolve(ctx, c, decision, event); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// apply exécute la sanction. dismiss et warn n'ont pas d'autre effet que de publier un post retenu
+// par la modération automatique : l'événement suffit (la notification de l'auteur est du ressort des consommateurs).
+// mark_sensitive publie aussi un post retenu, une fois ses médias floutés.
+// Un post retenu puis masqué, supprimé ou dont l'auteur est suspendu n'est jamais publié.
+// Rejeté, il passe la main au post suivant s'il portait un fil (cf. promotionMessages).
+func (s *moderationService) apply(ctx context.Context, d *domain.ModerationDecision) error {
+	switch d.Action {
+	case domain.ActionDismiss, domain.ActionWarn:
+		if d.TargetType == domain.TargetPost {
+			return s.release(ctx, d)
+		}
+
+	case domain.ActionHide:
+		if d.TargetType == domain.TargetComment {
+			return s.cases.HideComment(ctx, d.TargetID, d.CreatedAt)
+		}
+		return s.hidePost(ctx, d)
+
+	case domain.ActionDelete:
+		return s.deleteTarget(ctx, d)
+
+	case domain.ActionSuspend:
+		return s.accounts.SuspendUser(ctx, d.TargetAuthorID, d.ModeratorID, d.Note, d.SuspendedUntil)
+
+	case domain.ActionMarkSensitive:
+		if err := s.cases.MarkSensitive(ctx, d.TargetID, d.ContentWarning); err != nil {
+			return err
+		}
+		return s.release(ctx, d)
+	}
+	return nil
+}
+
+// release publie un post retenu (approuvé par le modérateur) avec le fan-out d'un CreatePost,
+// écrit dans l'outbox par la transaction de la publication.
+// Un post qui n'était pas retenu (signalement ordinaire) ou déjà publié par un essai précédent : rien à faire.
+func (s *moderationService) release(ctx context.Context, d *domain.ModerationDecision) error {
+	post, err := s.posts.repo.FindByID(ctx, d.TargetID)
+	if errors.Is(err, domain.ErrPostNotFound) {
+		return nil // Supprimé entre-temps : jamais publié
+	}
+	if err != nil {
+		return err
+	}
+	if !post.IsHeld() {
+		return nil
+	}
+
+	// Événements du post tel qu'il sera publié (ReleaseHeld les abandonne si un essai concurrent l'a devancé)
+	post.Status = domain.PostStatusPublished
+	events, err := s.releaseMessages(ctx, post)
+	if err != nil {
+		return err
+	}
+	if _, err := s.posts.repo.ReleaseHeld(ctx, post.ID, d.CreatedAt, events...); err != nil {
+		if errors.Is(err, domain.ErrNotHeld) {
+			return nil
+		}
+		return err
+	}
+
+	slog.Info("Held post released by moderator", "post_id", post.ID, "moderator_id", d.ModeratorID)
+	return nil
+}
+
+// releaseMessages : post.created et les mentions d'un post approuvé. La suite d'un fil n'est pas
+// distribuée : elle se lit depuis son entrée (la tête, sauf rejet).
+func (s *moderationService) releaseMessages(ctx context.Context, post *domain.Post) ([]*domain.OutboxMessage, error) {
+	entry, err := s.isThreadEntry(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*domain.OutboxMessage
+	if entry {
+		created, err := s.posts.publisher.PostCreatedMessage(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, created)
+	}
+	mentions, err := s.posts.mentionMessages(ctx, post, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(events, mentions...), nil
+}
+
+// hidePost : un post publié est retiré des timelines (post.deleted) dans la transaction du masquage.
+// Un post déjà supprimé peut encore être masqué (cf. HidePost), il n'a alors plus de fil à relayer.
+func (s *moderationService) hidePost(ctx context.Context, d *domain.ModerationDecision) error {
+	post, err := s.posts.repo.FindByID(ctx, d.TargetID)
+	if err != nil && !errors.Is(err, domain.ErrPostNotFound) {
+		return err
+	}
+
+	var events []*domain.OutboxMessage
+	if post != nil {
+		if events, err = s.retractionMessages(ctx, post, d.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return s.cases.HidePost(ctx, d.TargetID, d.CreatedAt, events...)
+}
+
+// retractionMessages : post.deleted pour un post distribué (publié, pas encore masqué),
+// sinon le relais éventuel de son fil (cf. promotionMessages)
+func (s *moderationService) retractionMessages(ctx context.Context, post *domain.Post, at time.Time) ([]*domain.OutboxMessage, error) {
+	if !post.IsPublished() || post.IsHidden() {
+		return s.promotionMessages(ctx, post)
+	}
+
+	retracted := *post
+	retracted.DeletedAt = at // Date du retrait des timelines
+	deleted, err := s.posts.publisher.PostDeletedMessage(ctx, &retracted)
+	if err != nil {
+		return nil, err
+	}
+	return []*domain.OutboxMessage{deleted}, nil
+}
+
+// isThreadEntry : un post hors fil est toujours distribué ; dans un fil, seule son entrée l'est
+func (s *moderationService) isThreadEntry(ctx context.Context, post *domain.Post) (bool, error) {
+	if !post.IsInThread() {
+		return true, nil
+	}
+	thread, err := s.posts.repo.FindThread(ctx, post.ThreadID)
+	if err != nil {
+		return false, err
+	}
+	entry := domain.ThreadEntry(thread)
+	return entry != nil && entry.ID == post.ID, nil
+}
+
+// promotionMessages : l'entrée retenue d'un fil va être rejetée (masquée ou supprimée) sans avoir jamais
+// été distribuée. Le post suivant encore en ligne prend le relais : déjà publié, il est annoncé avec le rejet
+// (même transaction) ; retenu lui aussi, il le sera à son approbation (cf. release). Sans cela, le reste
+// du fil, publié, n'atteindrait jamais les fils d'actualité.
+func (s *moderationService) promotionMessages(ctx context.Context, rejected *domain.Post) ([]*domain.OutboxMessage, error) {
+	if !rejected.IsHeld() || !rejected.IsInThread() {
+		return nil, nil
+	}
+	thread, err := s.posts.repo.FindThread(ctx, rejected.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Le fil tel qu'il sera après le rejet. Une entrée située avant le post rejeté : ce n'était pas lui qui portait le fil.
+	remaining := slices.DeleteFunc(thread, func(p *domain.Post) bool { return p.ID == rejected.ID })
+	entry := domain.ThreadEntry(remaining)
+	if entry == nil || entry.ThreadPosition < rejected.ThreadPosition || entry.IsHeld() {
+		return nil, nil
+	}
+
+	slog.Info("Thread entry promoted", "thread_id", rejected.ThreadID, "post_id", entry.ID, "rejected_post_id", rejected.ID)
+	created, err := s.posts.publisher.PostCreatedMessage(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	return []*domain.OutboxMessage{created}, nil
+}
+
+// deleteTarget : un contenu déjà supprimé (par son auteur entre-temps) est considéré comme traité
+func (s *moderationService) deleteTarget(ctx context.Context, d *domain.ModerationDecision) error {
+	if d.TargetType == domain.TargetComment {
+		comment, err := s.comments.FindCommentByID(ctx, d.TargetID)
+		if errors.Is(err, domain.ErrCommentNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return s.comments.DeleteComment(ctx, comment)
+	}
+
+	post, err := s.posts.repo.FindByID(ctx, d.TargetID)
+	if errors.Is(err, domain.ErrPostNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if post.IsRepost() {
+		return s.posts.deleteRepost(ctx, post)
+	}
+	events, err := s.promotionMessages(ctx, post)
+	if err != nil {
+		return err
+	}
+	return s.posts.softDelete(ctx, post, events...)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// OutboxRelay publie les événements de l'outbox sur JetStream et ne les supprime qu'une fois
+// accusés par le stream. Chaque réplica en fait tourner un (réclamation FOR UPDATE SKIP LOCKED) ;
+// un message publié deux fois (accusé perdu, bail expiré) est écarté par JetStream grâce à son ID.
+type OutboxRelay struct {
+	outbox    ports.OutboxRepository
+	stream    ports.OutboxPublisher
+	interval  time.Duration
+	batchSize int
+}
+
+func NewOutboxRelay(outbox ports.OutboxRepository, stream ports.OutboxPublisher, interval time.Duration, batchSize int) *OutboxRelay {
+	return &OutboxRelay{outbox: outbox, stream: stream, interval: interval, batchSize: batchSize}
+}
+
+// Run bloque jusqu'à l'annulation du contexte
+func (r *OutboxRelay) Run(ctx context.Context) {
+	slog.Info("📮 Outbox relay started", "interval", r.interval, "batch_size", r.batchSize)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.relay(ctx)
+		}
+	}
+}
+
+// relay vide la file des messages dus, lot par lot. Un échec n'arrête pas le lot :
+// le message est retenté plus tard (attente exponentielle), jusqu'à l'accusé du stream.
+func (r *OutboxRelay) relay(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		messages, err := r.outbox.ClaimPending(ctx, now, now.Add(domain.OutboxClaimLease), r.batchSize)
+		if err != nil {
+			slog.Error("Failed to claim outbox messages", "error", err)
+			return
+		}
+
+		for _, m := range messages {
+			r.publish(ctx, m)
+		}
+
+		if len(messages) < r.batchSize {
+			return
+		}
+	}
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, m *domain.OutboxMessage) {
+	if err := r.stream.PublishOutbox(ctx, m); err != nil {
+		retryAt := time.Now().UTC().Add(domain.OutboxRetryDelay(m.Attempts))
+		slog.Warn("Outbox publish failed, will retry", "msg_id", m.ID, "subject", m.Subject, "attempts", m.Attempts, "retry_at", retryAt, "error", err)
+		if err := r.outbox.MarkFailed(ctx, m.ID, retryAt, err.Error()); err != nil {
+			slog.Error("Failed to reschedule outbox message", "msg_id", m.ID, "error", err) // Repris à l'expiration du bail
+		}
+		return
+	}
+
+	if err := r.outbox.MarkPublished(ctx, m.ID); err != nil {
+		slog.Error("Failed to delete published outbox message", "msg_id", m.ID, "error", err) // Republié puis écarté comme doublon
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// PinPost : seul l'auteur épingle ses posts (domain.MaxPinnedPosts au plus)
+func (s *service) PinPost(ctx context.Context, postID, userID string) (*domain.Post, error) {
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != userID {
+		return nil, domain.ErrPostForbidden
+	}
+	if err := post.CanBePinned(); err != nil {
+		return nil, err
+	}
+
+	if !post.IsPinned() {
+		post.PinnedAt = time.Now().UTC()
+	}
+	if err := s.repo.Pin(ctx, post, domain.MaxPinnedPosts); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// UnpinPost : le post reprend sa place chronologique dans le profil
+func (s *service) UnpinPost(ctx context.Context, postID, userID string) (*domain.Post, error) {
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != userID {
+		return nil, domain.ErrPostForbidden
+	}
+	if !post.IsPinned() {
+		return post, nil
+	}
+
+	if err := s.repo.Unpin(ctx, postID); err != nil {
+		return nil, err
+	}
+	post.PinnedAt = time.Time{}
+	return post, nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+func TestPinPost(t *testing.T) {
+	earlier := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		posts   map[string]*domain.Post
+		postID  string
+		userID  string
+		wantErr error
+	}{
+		{
+			name:   "post publié de l'auteur",
+			posts:  map[string]*domain.Post{"p1": {ID: "p1", UserID: "u1", Content: "a"}},
+			postID: "p1", userID: "u1",
+		},
+		{
+			name:   "post d'un autre auteur",
+			posts:  map[string]*domain.Post{"p1": {ID: "p1", UserID: "u2", Content: "a"}},
+			postID: "p1", userID: "u1",
+			wantErr: domain.ErrPostForbidden,
+		},
+		{
+			name:   "post inconnu",
+			posts:  map[string]*domain.Post{},
+			postID: "p1", userID: "u1",
+			wantErr: domain.ErrPostNotFound,
+		},
+		{
+			name:   "brouillon",
+			posts:  map[string]*domain.Post{"p1": {ID: "p1", UserID: "u1", Content: "a", Status: domain.PostStatusDraft}},
+			postID: "p1", userID: "u1",
+			wantErr: domain.ErrCannotPin,
+		},
+		{
+			name:   "repost pur",
+			posts:  map[string]*domain.Post{"p1": {ID: "p1", UserID: "u1", RepostedPostID: "p0"}},
+			postID: "p1", userID: "u1",
+			wantErr: domain.ErrCannotPin,
+		},
+		{
+			name: "limite atteinte",
+			posts: map[string]*domain.Post{
+				"a":  {ID: "a", UserID: "u1", Content: "a", PinnedAt: earlier},
+				"b":  {ID: "b", UserID: "u1", Content: "b", PinnedAt: earlier},
+				"c":  {ID: "c", UserID: "u1", Content: "c", PinnedAt: earlier},
+				"p1": {ID: "p1", UserID: "u1", Content: "d"},
+			},
+			postID: "p1", userID: "u1",
+			wantErr: domain.ErrTooManyPinnedPosts,
+		},
+		{
+			name: "épinglés masqués ou supprimés hors limite",
+			posts: map[string]*domain.Post{
+				"a":  {ID: "a", UserID: "u1", Content: "a", PinnedAt: earlier},
+				"b":  {ID: "b", UserID: "u1", Content: "b", PinnedAt: earlier, HiddenAt: earlier},
+				"c":  {ID: "c", UserID: "u1", Content: "c", PinnedAt: earlier, DeletedAt: earlier},
+				"p1": {ID: "p1", UserID: "u1", Content: "d"},
+			},
+			postID: "p1", userID: "u1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &service{repo: &fakePostRepo{posts: tt.posts}}
+
+			post, err := s.PinPost(context.Background(), tt.postID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !post.IsPinned() || !tt.posts[tt.postID].IsPinned() {
+				t.Error("le post doit être épinglé")
+			}
+		})
+	}
+}
+
+func TestPinPostKeepsOriginalPinDate(t *testing.T) {
+	pinnedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	repo := &fakePostRepo{posts: map[string]*domain.Post{"p1": {ID: "p1", UserID: "u1", Content: "a", PinnedAt: pinnedAt}}}
+	s := &service{repo: repo}
+
+	post, err := s.PinPost(context.Background(), "p1", "u1")
+	if err != nil {
+		t.Fatalf("PinPost: %v", err)
+	}
+	if !post.PinnedAt.Equal(pinnedAt) {
+		t.Errorf("PinnedAt = %v, want %v (un post déjà épinglé garde sa place)", post.PinnedAt, pinnedAt)
+	}
+}
+
+func TestUnpinPost(t *testing.T) {
+	repo := &fakePostRepo{posts: map[string]*domain.Post{
+		"p1": {ID: "p1", UserID: "u1", Content: "a", PinnedAt: time.Now()},
+	}}
+	s := &service{repo: repo}
+
+	if _, err := s.UnpinPost(context.Background(), "p1", "u2"); !errors.Is(err, domain.ErrPostForbidden) {
+		t.Fatalf("UnpinPost par un autre utilisateur : err = %v, want ErrPostForbidden", err)
+	}
+
+	post, err := s.UnpinPost(context.Background(), "p1", "u1")
+	if err != nil {
+		t.Fatalf("UnpinPost: %v", err)
+	}
+	if post.IsPinned() || repo.posts["p1"].IsPinned() {
+		t.Error("le post doit être désépinglé")
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// VotePoll : on ne vote que sur un sondage qu'on peut voir (même règle que la lecture du post)
+func (s *service) VotePoll(ctx context.Context, postID, userID string, choices []int) (*domain.Poll, error) {
+	post, err := s.getPost(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachPolls(ctx, []*domain.Post{post}, userID); err != nil {
+		return nil, err
+	}
+	if post.Poll == nil || post.IsDeleted() {
+		return nil, domain.ErrPollNotFound
+	}
+
+	now := time.Now().UTC()
+	if post.Poll.IsClosedAt(now) {
+		return nil, domain.ErrPollClosed
+	}
+	if len(post.Poll.ViewerChoices) > 0 {
+		return nil, domain.ErrAlreadyVoted
+	}
+	if err := post.Poll.ValidateChoices(choices); err != nil {
+		return nil, err
+	}
+
+	// Le repository revérifie la clôture et le vote unique sous verrou (requêtes concurrentes)
+	poll, err := s.polls.Vote(ctx, postID, userID, choices, now)
+	if err != nil {
+		return nil, err
+	}
+	poll.ForViewer(choices, now)
+	return poll, nil
+}
+
+// GetPollResults : compteurs masqués tant que le viewer n'a pas voté et que le sondage est ouvert
+func (s *service) GetPollResults(ctx context.Context, postID, viewerID string) (*domain.Poll, error) {
+	post, err := s.getPost(ctx, postID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachPolls(ctx, []*domain.Post{post}, viewerID); err != nil {
+		return nil, err
+	}
+	if post.Poll == nil {
+		return nil, domain.ErrPollNotFound
+	}
+	return post.Poll, nil
+}
+
+// attachPolls : sondages (et choix du viewer) d'une page de posts, en deux requêtes au plus
+func (s *service) attachPolls(ctx context.Context, posts []*domain.Post, viewerID string) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(posts))
+	for i, p := range posts {
+		ids[i] = p.ID
+	}
+
+	polls, err := s.polls.GetPolls(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(polls) == 0 {
+		return nil
+	}
+
+	choices := map[string][]int{}
+	if viewerID != "" {
+		if choices, err = s.polls.ViewerChoices(ctx, viewerID, ids); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, p := range posts {
+		if poll, ok := polls[p.ID]; ok {
+			poll.ForViewer(choices[p.ID], now)
+			p.Poll = poll
+		}
+	}
+	return nil
+}
@@ -11,64 +11,276 @@ import (
 )
 
 type service struct {
-	repo      ports.PostRepository
-	publisher ports.EventPublisher
+	repo       ports.PostRepository
+	reactions  ports.ReactionRepository
+	bookmarks  ports.BookmarkRepository
+	polls      ports.PollRepository
+	users      ports.UserDirectory
+	relations  ports.RelationChecker
+	publisher  ports.EventPublisher
+	classifier ports.ContentClassifier // nil = pas de modération automatique
+	detector   ports.LanguageDetector  // nil = pas de détection de langue
+	keys       ports.IdempotencyStore
+	policy     PostPolicy
 }
 
-func NewPostService(repo ports.PostRepository, pub ports.EventPublisher) ports.PostService {
-	return &service{repo: repo, publisher: pub}
+// PostPolicy : délais configurables du cycle de vie d'un post
+type PostPolicy struct {
+	EditWindow    time.Duration // Durée pendant laquelle un post publié reste modifiable (0 = illimitée)
+	RestoreWindow time.Duration // Durée pendant laquelle un post supprimé peut être restauré (puis purgé)
+	Limits        domain.PostLimits
+	// ClassifierFailOpen : si le classifieur est indisponible, publier quand même (sinon le post est retenu)
+	ClassifierFailOpen bool
+	IdempotencyTTL     time.Duration // Durée pendant laquelle une clé d'idempotence rejoue la première requête
 }
 
-func (s *service) CreatePost(ctx context.Context, userID, content string, media []domain.Media) (*domain.Post, error) {
-	post := &domain.Post{
-		ID:        uuid.New().String(),
-		UserID:    userID,
-		Content:   content,
-		Media:     media,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
+func NewPostService(repo ports.PostRepository, reactions ports.ReactionRepository, bookmarks ports.BookmarkRepository, polls ports.PollRepository, users ports.UserDirectory, relations ports.RelationChecker, pub ports.EventPublisher, classifier ports.ContentClassifier, detector ports.LanguageDetector, keys ports.IdempotencyStore, policy PostPolicy) ports.PostService {
+	if policy.Limits == (domain.PostLimits{}) {
+		policy.Limits = domain.DefaultPostLimits
 	}
+	return &service{repo: repo, reactions: reactions, bookmarks: bookmarks, polls: polls, users: users, relations: relations, publisher: pub, classifier: classifier, detector: detector, keys: keys, policy: policy}
+}
+
+func (s *service) CreatePost(ctx context.Context, userID, content, contentWarning string, media []domain.Media, visibility domain.Visibility, language string, pollInput *domain.PollInput, idempotencyKey string) (*domain.Post, error) {
+	postID := uuid.New().String() // Tiré avant la réservation de la clé, qui le mémorise
+	request := createRequest{Content: content, ContentWarning: contentWarning, Media: media, Visibility: visibility, Language: language, Poll: pollInput}
+	return s.idempotent(ctx, userID, domain.OpCreatePost, idempotencyKey, postID, request, func() (*domain.Post, error) {
+		return s.createPost(ctx, postID, userID, content, contentWarning, media, visibility, language, pollInput)
+	})
+}
 
-	// 1. Sauvegarde DB (Source of Truth)
-	if err := s.repo.Save(ctx, post); err != nil {
+func (s *service) createPost(ctx context.Context, postID, userID, content, contentWarning string, media []domain.Media, visibility domain.Visibility, language string, pollInput *domain.PollInput) (*domain.Post, error) {
+	visibility, err := resolveVisibility(visibility)
+	if err != nil {
 		return nil, err
 	}
 
-	// 2. Publication Événement (Fan-out Trigger)
-	// Note: En architecture experte "Critique", on utiliserait le pattern "Transactional Outbox"
-	// Pour l'instant, un appel direct suffit, mais gardez l'Outbox en tête pour la v2.
-	if err := s.publisher.PublishPostCreated(ctx, post); err != nil {
-		// Log error, mais ne pas faire échouer la requête utilisateur car la donnée est sauvée.
-		// Idéalement : Background retry.
+	now := time.Now().UTC()
+	var poll *domain.Poll
+	if pollInput != nil {
+		if poll, err = domain.NewPoll(*pollInput, now); err != nil {
+			return nil, err
+		}
+	}
+
+	// 0. Construction et modération automatique (un post retenu est enregistré avec son dossier,
+	// mais ni distribué ni annoncé)
+	post, err := s.newPost(ctx, domain.NewPostParams{
+		ID:             postID,
+		UserID:         userID,
+		Content:        content,
+		ContentWarning: contentWarning,
+		Media:          media,
+		Visibility:     visibility,
+		Language:       language,
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+	post.Poll = poll
+	if poll != nil {
+		poll.PostID = post.ID
+		poll.ForViewer(nil, now) // L'auteur n'a pas voté : compteurs masqués comme pour tout le monde
 	}
 
+	// 1. Événements (Fan-out Trigger, mentions), sauf pour un post retenu : annoncé à sa validation
+	events, err := s.creationMessages(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Sauvegarde DB (Source of Truth) et outbox dans la même transaction :
+	// l'OutboxRelay publiera post.created même si NATS est indisponible en ce moment
+	if err := s.repo.Save(ctx, post, events...); err != nil {
+		return nil, err
+	}
 	return post, nil
 }
 
-func (s *service) GetPost(ctx context.Context, postID string) (*domain.Post, error) {
-	return s.repo.FindByID(ctx, postID)
+// newPost construit un post publié à partir de la saisie de l'auteur (CreatePost, CreateThread) :
+// langue, entités, puis passage au classifieur (cf. screen), qui peut le retenir ou le refuser.
+func (s *service) newPost(ctx context.Context, params domain.NewPostParams, now time.Time) (*domain.Post, error) {
+	// La langue précisée par l'auteur prime sur la détection
+	if domain.NormalizeLanguage(params.Language) == "" {
+		params.Language = s.detectLanguage(params.Content)
+	}
+	params.Status = domain.PostStatusPublished
+
+	post, err := domain.NewPost(params, s.policy.Limits, now)
+	if err != nil {
+		return nil, err
+	}
+	post.Entities = s.resolveEntities(ctx, post.Content)
+
+	if err := s.screen(ctx, post); err != nil {
+		return nil, err
+	}
+	return post, nil
 }
 
-func (s *service) DeletePost(ctx context.Context, postID, userID string) error {
-	post, err := s.repo.FindByID(ctx, postID)
+// creationMessages : post.created et les mentions d'un nouveau post, rien pour un post retenu.
+// La suite d'un fil n'a pas de post.created : elle se lit depuis la tête, seule distribuée.
+func (s *service) creationMessages(ctx context.Context, post *domain.Post) ([]*domain.OutboxMessage, error) {
+	if post.IsHeld() {
+		return nil, nil
+	}
+	var events []*domain.OutboxMessage
+	if !post.IsInThread() || post.IsThreadHead() {
+		created, err := s.publisher.PostCreatedMessage(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, created)
+	}
+	mentions, err := s.mentionMessages(ctx, post, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(events, mentions...), nil
+}
+
+func (s *service) Repost(ctx context.Context, userID, repostedPostID, content string, media []domain.Media, idempotencyKey string) (*domain.Post, error) {
+	postID := uuid.New().String()
+	request := createRequest{Content: content, Media: media, RepostOf: repostedPostID}
+	return s.idempotent(ctx, userID, domain.OpCreatePost, idempotencyKey, postID, request, func() (*domain.Post, error) {
+		return s.repost(ctx, postID, userID, repostedPostID, content, media)
+	})
+}
+
+func (s *service) repost(ctx context.Context, postID, userID, repostedPostID, content string, media []domain.Media) (*domain.Post, error) {
+	original, err := s.repo.FindByID(ctx, repostedPostID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repartager un repost pur = repartager l'original (pas de chaînes de reposts vides)
+	if original.IsRepost() {
+		if original, err = s.repo.FindByID(ctx, original.RepostedPostID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Un repost élargirait l'audience d'un post restreint : seuls les posts publics se partagent
+	if err := s.checkVisible(ctx, original, userID); err != nil {
+		return nil, err
+	}
+	if !original.IsPublished() {
+		return nil, domain.ErrPostNotFound // Même son propre brouillon ne se partage pas
+	}
+	if original.Visibility != domain.VisibilityPublic {
+		return nil, domain.ErrRepostNotAllowed
+	}
+
+	// Un repost pur affiche l'original : il en prend la langue (filtrage des fils)
+	language := original.Language
+	if content != "" {
+		language = s.detectLanguage(content)
+	}
+
+	post, err := domain.NewPost(domain.NewPostParams{
+		ID:             postID,
+		UserID:         userID,
+		Content:        content,
+		Media:          media,
+		Status:         domain.PostStatusPublished,
+		Visibility:     domain.VisibilityPublic,
+		Language:       language,
+		RepostedPostID: original.ID,
+	}, s.policy.Limits, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	post.Entities = s.resolveEntities(ctx, post.Content)
+
+	// Une citation apporte son propre contenu : classée comme un CreatePost (un repost pur n'a rien à classer)
+	if post.IsQuote() {
+		if err := s.screen(ctx, post); err != nil {
+			return nil, err
+		}
+	}
+
+	// Le Feed Service distingue les reposts purs (type "repost") pour dédoublonner les timelines
+	events, err := s.creationMessages(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, post, events...); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *service) UndoRepost(ctx context.Context, repostedPostID, userID string) error {
+	repost, err := s.repo.FindRepost(ctx, userID, repostedPostID)
 	if err != nil {
 		return err
 	}
-	if post.UserID != userID {
-		return errors.New("unauthorized")
+
+	return s.deleteRepost(ctx, repost)
+}
+
+// deleteRepost : un repost pur n'a pas de contenu à restaurer, il est supprimé définitivement
+func (s *service) deleteRepost(ctx context.Context, repost *domain.Post) error {
+	repost.DeletedAt = time.Now().UTC()
+	deleted, err := s.publisher.PostDeletedMessage(ctx, repost)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, repost.ID, deleted)
+}
+
+func (s *service) GetPost(ctx context.Context, postID, viewerID string, showSensitive bool) (*domain.Post, error) {
+	post, err := s.getPost(ctx, postID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.present(ctx, []*domain.Post{post}, viewerID, showSensitive); err != nil {
+		return nil, err
 	}
+	return post, nil
+}
 
-	if err := s.repo.Delete(ctx, postID); err != nil {
+// getPost : le post (ou sa pierre tombale) s'il est visible du lecteur, sans sondage ni floutage
+func (s *service) getPost(ctx context.Context, postID, viewerID string) (*domain.Post, error) {
+	post, err := s.repo.FindByID(ctx, postID)
+	if errors.Is(err, domain.ErrPostNotFound) {
+		return s.getTombstone(ctx, postID, viewerID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkVisible(ctx, post, viewerID); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *service) DeletePost(ctx context.Context, postID, userID, idempotencyKey string) error {
+	_, err := s.idempotent(ctx, userID, domain.OpDeletePost, idempotencyKey, postID, postID, func() (*domain.Post, error) {
+		return nil, s.deletePost(ctx, postID, userID)
+	})
+	return err
+}
+
+func (s *service) deletePost(ctx context.Context, postID, userID string) error {
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
 		return err
 	}
+	if post.UserID != userID {
+		return domain.ErrPostForbidden
+	}
 
-	_ = s.publisher.PublishPostDeleted(ctx, postID)
-	return nil
+	if post.IsRepost() {
+		return s.deleteRepost(ctx, post)
+	}
+	return s.softDelete(ctx, post)
 }
 
 // Exemple à ajouter dans service.go plus tard :
 // ListPostsByAuthor (Logique de Pagination Experte)
-func (s *service) ListPostsByAuthor(ctx context.Context, authorID string, limit int, cursor string) ([]*domain.Post, string, error) {
+func (s *service) ListPostsByAuthor(ctx context.Context, authorID, viewerID string, showSensitive bool, limit int, cursor string) ([]*domain.Post, string, error) {
 	var cursorTime time.Time
 	var err error
 
@@ -79,11 +291,11 @@ func (s *service) ListPostsByAuthor(ctx context.Context, authorID string, limit
 		if err != nil {
 			// Si le token est corrompu, on renvoie une erreur ou on repart du début.
 			// Ici, on est strict.
-			return nil, "", errors.New("invalid page token")
+			return nil, "", domain.ErrInvalidPageToken
 		}
 	}
 
-	// 2. Appel au Repository
+	// 2. Appel au Repository (les posts épinglés n'y figurent pas : ils ne se répètent jamais d'une page à l'autre)
 	posts, err := s.repo.ListByAuthor(ctx, authorID, limit, cursorTime)
 	if err != nil {
 		return nil, "", err
@@ -98,11 +310,67 @@ func (s *service) ListPostsByAuthor(ctx context.Context, authorID string, limit
 		nextCursor = lastPost.CreatedAt.Format(time.RFC3339Nano)
 	}
 
+	// 3b. Posts épinglés en tête de la première page (en plus de 'limit')
+	if cursor == "" {
+		pinned, err := s.repo.ListPinned(ctx, authorID, domain.MaxPinnedPosts)
+		if err != nil {
+			return nil, "", err
+		}
+		posts = append(pinned, posts...)
+	}
+
+	// 4. Visibilité : APRÈS le calcul du curseur (une page filtrée peut être plus courte que 'limit')
+	posts, err = s.readable(ctx, posts, viewerID, showSensitive)
+	if err != nil {
+		return nil, "", err
+	}
+	return posts, nextCursor, nil
+}
+
+// ListPostsByHashtag : même pagination keyset que ListPostsByAuthor
+func (s *service) ListPostsByHashtag(ctx context.Context, tag, viewerID string, showSensitive bool, limit int, cursor string) ([]*domain.Post, string, error) {
+	var cursorTime time.Time
+	if cursor != "" {
+		t, err := time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			return nil, "", domain.ErrInvalidPageToken
+		}
+		cursorTime = t
+	}
+
+	tag = domain.NormalizeHashtag(tag)
+	if tag == "" {
+		return []*domain.Post{}, "", nil
+	}
+
+	// Un élément de plus pour savoir s'il existe une page suivante
+	posts, err := s.repo.ListByHashtag(ctx, tag, limit+1, cursorTime)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(posts) > limit {
+		posts = posts[:limit]
+		nextCursor = posts[len(posts)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	posts, err = s.readable(ctx, posts, viewerID, showSensitive)
+	if err != nil {
+		return nil, "", err
+	}
 	return posts, nextCursor, nil
 }
 
 // UpdatePost (Si demandé par le gRPC)
-func (s *service) UpdatePost(ctx context.Context, postID, userID, content string, media []domain.Media) (*domain.Post, error) {
+func (s *service) UpdatePost(ctx context.Context, postID, userID, content, contentWarning string, media []domain.Media, idempotencyKey string) (*domain.Post, error) {
+	request := updateRequest{PostID: postID, Content: content, ContentWarning: contentWarning, Media: media}
+	return s.idempotent(ctx, userID, domain.OpUpdatePost, idempotencyKey, postID, request, func() (*domain.Post, error) {
+		return s.updatePost(ctx, postID, userID, content, contentWarning, media)
+	})
+}
+
+func (s *service) updatePost(ctx context.Context, postID, userID, content, contentWarning string, media []domain.Media) (*domain.Post, error) {
 	// 1. Récupérer l'existant
 	post, err := s.repo.FindByID(ctx, postID)
 	if err != nil {
@@ -111,29 +379,132 @@ func (s *service) UpdatePost(ctx context.Context, postID, userID, content string
 
 	// 2. Vérification de propriété (Seul l'auteur peut modifier)
 	if post.UserID != userID {
-		return nil, errors.New("unauthorized")
+		return nil, domain.ErrPostForbidden
+	}
+	// Le modérateur approuve le contenu qui a été classé, pas une version réécrite entre-temps
+	if post.IsHeld() {
+		return nil, domain.ErrPostHeld
+	}
+
+	now := time.Now().UTC()
+	if !post.CanBeEditedAt(now, s.policy.EditWindow) {
+		return nil, domain.ErrEditWindowExpired
+	}
+	content, media, err = s.policy.Limits.CleanBody(content, media)
+	if err != nil {
+		return nil, err
+	}
+	if content == "" && len(media) == 0 {
+		return nil, domain.ErrEmptyPost // Une citation vidée deviendrait un repost pur
+	}
+	contentWarning, err = domain.CleanContentWarning(contentWarning)
+	if err != nil {
+		return nil, err
+	}
+	// Un post marqué sensible par la modération le reste, quoi que l'auteur envoie
+	media, contentWarning = post.KeepModeratorFlags(media, contentWarning)
+	if post.HasSameBody(content, contentWarning, media) {
+		return post, nil // Rien à archiver ni à annoncer
 	}
 
 	// 3. Mise à jour des champs
+	previousMentions := post.MentionedUserIDs()
 	post.Content = content
+	post.ContentWarning = contentWarning
 	post.Media = media
-	post.UpdatedAt = time.Now().UTC()
+	post.Entities = s.resolveEntities(ctx, content)
+	post.UpdatedAt = now
+	if post.IsPublished() {
+		post.EditedAt = now // Le repository archive la version remplacée (post_revisions)
+	}
 
-	// 4. Sauvegarde
-	if err := s.repo.Update(ctx, post); err != nil {
-		return nil, err
+	// 4. Modération automatique de la nouvelle version (un brouillon ne l'est qu'à sa programmation).
+	// Retenu : le post quitte les lectures jusqu'à l'approbation, qui le republiera.
+	if post.IsPublished() || post.Status == domain.PostStatusScheduled {
+		if err := s.screen(ctx, post); err != nil {
+			return nil, err
+		}
 	}
 
-	// Optionnel: Publier un event PostUpdated si besoin
+	// 5. Caches et index de recherche se rafraîchissent sur post.updated ; seules les personnes
+	// ajoutées par l'édition sont notifiées. Un brouillon n'a encore ni lecteurs ni mentionnés,
+	// un post retenu plus de lecteurs.
+	var events []*domain.OutboxMessage
+	if post.IsPublished() {
+		updated, err := s.publisher.PostUpdatedMessage(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		mentions, err := s.mentionMessages(ctx, post, previousMentions)
+		if err != nil {
+			return nil, err
+		}
+		events = append([]*domain.OutboxMessage{updated}, mentions...)
+	}
 
+	// 6. Sauvegarde, dossier de modération d'un post retenu et outbox dans la même transaction
+	if err := s.repo.Update(ctx, post, events...); err != nil {
+		return nil, err
+	}
 	return post, nil
 }
 
 // GetPosts (Batch pour le Feed)
-func (s *service) GetPosts(ctx context.Context, postIDs []string) ([]*domain.Post, error) {
+func (s *service) GetPosts(ctx context.Context, postIDs []string, viewerID string, showSensitive bool) ([]*domain.Post, error) {
 	// Petite optimisation : si vide, on ne dérange pas la DB
 	if len(postIDs) == 0 {
 		return []*domain.Post{}, nil
 	}
-	return s.repo.GetPosts(ctx, postIDs)
+
+	posts, err := s.repo.GetPosts(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Les posts invisibles pour ce lecteur sont simplement absents de la réponse
+	posts, err = s.readable(ctx, posts, viewerID, showSensitive)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachViewer(ctx, posts, viewerID); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// attachViewer : contexte du lecteur, UNE requête par donnée pour tout le batch (pas de N+1 côté Gateway)
+func (s *service) attachViewer(ctx context.Context, posts []*domain.Post, viewerID string) error {
+	if viewerID == "" || len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(posts))
+	for i, p := range posts {
+		ids[i] = p.ID
+	}
+
+	reactions, err := s.reactions.ViewerReactions(ctx, viewerID, ids)
+	if err != nil {
+		return err
+	}
+	bookmarked, err := s.bookmarks.ViewerBookmarks(ctx, viewerID, ids)
+	if err != nil {
+		return err
+	}
+	for _, p := range posts {
+		p.Viewer = &domain.ViewerState{Reaction: reactions[p.ID], Bookmarked: bookmarked[p.ID]}
+	}
+	return nil
+}
+
+// detectLanguage : détection hors ligne, vide si le texte est trop court ou la langue incertaine
+func (s *service) detectLanguage(content string) string {
+	if s.detector == nil {
+		return ""
+	}
+	sample := domain.LanguageSample(content)
+	if sample == "" {
+		return ""
+	}
+	return s.detector.Detect(sample)
 }
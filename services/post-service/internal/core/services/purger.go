@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// Purger supprime définitivement les posts dont la fenêtre de restauration est dépassée,
+// ainsi que les clés d'idempotence expirées.
+// Comme le Scheduler, il tourne sur chaque réplica (FOR UPDATE SKIP LOCKED côté repository).
+type Purger struct {
+	repo          ports.PostRepository
+	keys          ports.IdempotencyStore
+	restoreWindow time.Duration
+	interval      time.Duration
+	batchSize     int
+}
+
+func NewPurger(repo ports.PostRepository, keys ports.IdempotencyStore, restoreWindow, interval time.Duration, batchSize int) *Purger {
+	return &Purger{repo: repo, keys: keys, restoreWindow: restoreWindow, interval: interval, batchSize: batchSize}
+}
+
+// Run bloque jusqu'à l'annulation du contexte
+func (p *Purger) Run(ctx context.Context) {
+	slog.Info("🧹 Post purger started", "interval", p.interval, "restore_window", p.restoreWindow)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.purge(ctx)
+			p.purgeKeys(ctx)
+		}
+	}
+}
+
+// purge vide la file des posts expirés, lot par lot
+func (p *Purger) purge(ctx context.Context) {
+	before := time.Now().UTC().Add(-p.restoreWindow)
+	total := 0
+
+	for ctx.Err() == nil {
+		n, err := p.repo.PurgeDeleted(ctx, before, p.batchSize)
+		if err != nil {
+			slog.Error("Failed to purge deleted posts", "error", err)
+			break
+		}
+		total += n
+		if n < p.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		slog.Info("Deleted posts purged", "count", total)
+	}
+}
+
+// purgeKeys : une clé expirée ne rejoue plus rien, la ligne ne sert plus
+func (p *Purger) purgeKeys(ctx context.Context) {
+	now := time.Now().UTC()
+	total := 0
+
+	for ctx.Err() == nil {
+		n, err := p.keys.PurgeExpired(ctx, now, p.batchSize)
+		if err != nil {
+			slog.Error("Failed to purge idempotency keys", "error", err)
+			break
+		}
+		total += n
+		if n < p.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		slog.Debug("Expired idempotency keys purged", "count", total)
+	}
+}
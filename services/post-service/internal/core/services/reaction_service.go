@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+type reactionService struct {
+	reactions ports.ReactionRepository
+	posts     *service // Réutilise interactivePost (visibilité du post)
+}
+
+func NewReactionService(reactions ports.ReactionRepository, repo ports.PostRepository, relations ports.RelationChecker, pub ports.EventPublisher) ports.ReactionService {
+	return &reactionService{reactions: reactions, posts: &service{repo: repo, relations: relations, publisher: pub}}
+}
+
+func (s *reactionService) React(ctx context.Context, postID, userID string, kind domain.ReactionKind) (*domain.ReactionSummary, error) {
+	if !kind.IsValid() {
+		return nil, domain.ErrInvalidReaction
+	}
+	// On ne réagit qu'à ce qu'on peut lire
+	if _, err := s.posts.interactivePost(ctx, postID, userID); err != nil {
+		return nil, err
+	}
+
+	reaction := &domain.Reaction{
+		PostID:    postID,
+		UserID:    userID,
+		Kind:      kind,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	return s.reactions.SetReaction(ctx, reaction, func(summary *domain.ReactionSummary) ([]*domain.OutboxMessage, error) {
+		// Idempotence : re-cliquer sur la même réaction ne renotifie pas l'auteur
+		if !summary.Changed() {
+			return nil, nil
+		}
+		reacted, err := s.posts.publisher.PostReactedMessage(ctx, reaction, summary)
+		if err != nil {
+			return nil, err
+		}
+		return []*domain.OutboxMessage{reacted}, nil
+	})
+}
+
+func (s *reactionService) Unreact(ctx context.Context, postID, userID string) (*domain.ReactionSummary, error) {
+	if _, err := s.posts.interactivePost(ctx, postID, userID); err != nil {
+		return nil, err
+	}
+	return s.reactions.RemoveReaction(ctx, postID, userID)
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// ListPostRevisions : l'historique suit la visibilité du post (un post invisible n'a pas d'historique)
+func (s *service) ListPostRevisions(ctx context.Context, postID, viewerID string, showSensitive bool, limit int, cursor string) ([]*domain.PostRevision, string, error) {
+	var cursorTime time.Time
+	if cursor != "" {
+		t, err := time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			return nil, "", domain.ErrInvalidPageToken
+		}
+		cursorTime = t
+	}
+
+	post, err := s.getPost(ctx, postID, viewerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if post.IsDeleted() {
+		return nil, "", domain.ErrPostNotFound // Une pierre tombale n'a pas d'historique
+	}
+
+	// Un élément de plus pour savoir s'il existe une page suivante
+	revisions, err := s.repo.ListRevisions(ctx, postID, limit+1, cursorTime)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(revisions) > limit {
+		revisions = revisions[:limit]
+		nextCursor = revisions[len(revisions)-1].ReplacedAt.Format(time.RFC3339Nano)
+	}
+
+	// Les anciennes versions sont floutées comme le post lui-même
+	if !showSensitive {
+		for _, r := range revisions {
+			r.BlurSensitiveMedia(post, viewerID)
+		}
+	}
+	return revisions, nextCursor, nil
+}
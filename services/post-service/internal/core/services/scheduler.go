@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// Scheduler publie les posts planifiés arrivés à échéance et clôture les sondages échus.
+// Chaque réplica du Post Service en fait tourner un : la réclamation des lignes (FOR UPDATE SKIP LOCKED)
+// garantit qu'un post n'est publié (un sondage clôturé) qu'une fois ; ses événements sont écrits dans l'outbox
+// par la même transaction.
+type Scheduler struct {
+	posts     *service // Réutilise creationMessages (mentions, checkVisible)
+	interval  time.Duration
+	batchSize int
+}
+
+func NewScheduler(repo ports.PostRepository, polls ports.PollRepository, relations ports.RelationChecker, pub ports.EventPublisher, interval time.Duration, batchSize int) *Scheduler {
+	return &Scheduler{
+		posts:     &service{repo: repo, polls: polls, relations: relations, publisher: pub},
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run bloque jusqu'à l'annulation du contexte
+func (sc *Scheduler) Run(ctx context.Context) {
+	slog.Info("⏰ Post scheduler started", "interval", sc.interval, "batch_size", sc.batchSize)
+
+	ticker := time.NewTicker(sc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sc.publishDue(ctx)
+			sc.closeDuePolls(ctx)
+		}
+	}
+}
+
+// publishDue vide la file des posts échus, lot par lot
+func (sc *Scheduler) publishDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		posts, err := sc.posts.repo.PublishDue(ctx, time.Now().UTC(), sc.batchSize, sc.announcePublished(ctx))
+		if err != nil {
+			slog.Error("Failed to publish scheduled posts", "error", err)
+			return
+		}
+
+		for _, post := range posts {
+			slog.Info("Scheduled post published", "post_id", post.ID, "publish_at", post.PublishAt)
+		}
+
+		if len(posts) < sc.batchSize {
+			return
+		}
+	}
+}
+
+// announcePublished : même fan-out qu'un CreatePost immédiat, écrit dans la transaction de la publication
+func (sc *Scheduler) announcePublished(ctx context.Context) ports.PostAnnouncer {
+	return func(posts []*domain.Post) ([]*domain.OutboxMessage, error) {
+		var events []*domain.OutboxMessage
+		for _, post := range posts {
+			messages, err := sc.posts.creationMessages(ctx, post)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, messages...)
+		}
+		return events, nil
+	}
+}
+
+// closeDuePolls clôture les sondages échus, lot par lot, et annonce leurs résultats définitifs
+// dans la transaction de la clôture
+func (sc *Scheduler) closeDuePolls(ctx context.Context) {
+	for ctx.Err() == nil {
+		closed, err := sc.posts.polls.CloseDue(ctx, time.Now().UTC(), sc.batchSize, func(posts []*domain.Post) ([]*domain.OutboxMessage, error) {
+			events := make([]*domain.OutboxMessage, 0, len(posts))
+			for _, post := range posts {
+				if post.Poll == nil {
+					continue
+				}
+				event, err := sc.posts.publisher.PollClosedMessage(ctx, post)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+			}
+			return events, nil
+		})
+		if err != nil {
+			slog.Error("Failed to close polls", "error", err)
+			return
+		}
+
+		if closed < sc.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// SearchPosts : pagination keyset sur (rank, id). Comme pour les autres listes, la visibilité est
+// appliquée APRÈS le calcul du curseur (une page filtrée peut être plus courte que 'limit').
+func (s *service) SearchPosts(ctx context.Context, query domain.SearchQuery, viewerID string, showSensitive bool, limit int, cursor string) ([]*domain.SearchResult, string, error) {
+	var after *domain.SearchCursor
+	if cursor != "" {
+		c, err := domain.DecodeSearchCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		after = c
+	}
+
+	terms := domain.ParseSearchTerms(query.Text)
+	if len(terms) == 0 {
+		return nil, "", domain.ErrEmptySearchQuery
+	}
+
+	// Un élément de plus pour savoir s'il existe une page suivante
+	results, err := s.repo.SearchPosts(ctx, query, terms, limit+1, after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(results) > limit {
+		results = results[:limit]
+		last := results[len(results)-1]
+		nextCursor = domain.SearchCursor{Rank: last.Rank, PostID: last.Post.ID}.Encode()
+	}
+
+	// Visibilité : un seul appel au Graph Service pour toute la page
+	posts := make([]*domain.Post, len(results))
+	for i, r := range results {
+		posts[i] = r.Post
+	}
+	visible := make(map[string]bool, len(results))
+	for _, p := range s.filterVisible(ctx, posts, viewerID) {
+		visible[p.ID] = true
+	}
+
+	filtered := make([]*domain.SearchResult, 0, len(results))
+	filteredPosts := make([]*domain.Post, 0, len(results))
+	for _, r := range results {
+		if visible[r.Post.ID] {
+			filtered = append(filtered, r)
+			filteredPosts = append(filteredPosts, r.Post)
+		}
+	}
+	if err := s.present(ctx, filteredPosts, viewerID, showSensitive); err != nil {
+		return nil, "", err
+	}
+	return filtered, nextCursor, nil
+}
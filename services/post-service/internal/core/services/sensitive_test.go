@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+func TestUpdatePostKeepsModeratorFlags(t *testing.T) {
+	repo := &fakePostRepo{posts: map[string]*domain.Post{"p1": {
+		ID: "p1", UserID: "author", Content: "avant", Status: domain.PostStatusPublished, Visibility: domain.VisibilityPublic,
+		Media:           []domain.Media{{ID: "m1", URL: "https://cdn.example.com/1.jpg", Type: domain.MediaTypeImage, Sensitive: true}},
+		ContentWarning:  "violence",
+		MarkedSensitive: true, ModeratorWarning: "violence",
+		CreatedAt: time.Now().UTC(),
+	}}}
+	s := &service{repo: repo, publisher: &fakePublisher{}, policy: PostPolicy{Limits: domain.DefaultPostLimits}}
+
+	// L'auteur retire l'avertissement et remplace le média par un média non marqué
+	media := []domain.Media{{ID: "m2", URL: "https://cdn.example.com/2.jpg", Type: domain.MediaTypeImage}}
+	post, err := s.updatePost(t.Context(), "p1", "author", "après", "", media)
+	if err != nil {
+		t.Fatalf("updatePost: %v", err)
+	}
+
+	stored := repo.posts["p1"]
+	if !stored.Media[0].Sensitive {
+		t.Error("le nouveau média doit rester sensible")
+	}
+	if stored.ContentWarning != "violence" {
+		t.Errorf("avertissement = %q, want %q", stored.ContentWarning, "violence")
+	}
+	if post.Content != "après" {
+		t.Errorf("contenu = %q, want %q", post.Content, "après")
+	}
+}
+
+func TestGetThreadBlursSensitiveMedia(t *testing.T) {
+	sensitive := []domain.Media{{ID: "m1", URL: "https://cdn.example.com/1.jpg", Type: domain.MediaTypeImage, Sensitive: true}}
+	repo := &fakePostRepo{posts: map[string]*domain.Post{
+		"h": {ID: "h", UserID: "author", ThreadID: "h", ThreadPosition: 1, Status: domain.PostStatusPublished, Visibility: domain.VisibilityPublic, Media: sensitive},
+		"n": {ID: "n", UserID: "author", ThreadID: "h", ThreadPosition: 2, Status: domain.PostStatusPublished, Visibility: domain.VisibilityPublic, Media: sensitive},
+	}}
+	s := &service{repo: repo, polls: &fakePolls{}}
+
+	tests := []struct {
+		name          string
+		viewerID      string
+		showSensitive bool
+		wantBlurred   bool
+	}{
+		{"lecteur sans préférence", "reader", false, true},
+		{"lecteur qui choisit de voir", "reader", true, false},
+		{"auteur", "author", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thread, err := s.GetThread(t.Context(), "h", tt.viewerID, tt.showSensitive)
+			if err != nil {
+				t.Fatalf("GetThread: %v", err)
+			}
+			if len(thread) != 2 {
+				t.Fatalf("fil de %d posts, want 2", len(thread))
+			}
+			for _, p := range thread {
+				if p.Media[0].Blurred != tt.wantBlurred || (p.Media[0].URL == "") != tt.wantBlurred {
+					t.Errorf("post %s : média = %+v, want flouté = %v", p.ID, p.Media[0], tt.wantBlurred)
+				}
+			}
+		})
+	}
+}
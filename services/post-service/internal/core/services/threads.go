@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+func (s *service) CreateThread(ctx context.Context, userID string, inputs []domain.ThreadPostInput, visibility domain.Visibility, idempotencyKey string) ([]*domain.Post, error) {
+	headID := uuid.New().String() // La clé d'idempotence mémorise la tête du fil
+	request := threadRequest{Posts: inputs, Visibility: visibility}
+
+	var thread []*domain.Post
+	head, err := s.idempotent(ctx, userID, domain.OpCreateThread, idempotencyKey, headID, request, func() (*domain.Post, error) {
+		var err error
+		if thread, err = s.createThread(ctx, headID, userID, inputs, visibility); err != nil {
+			return nil, err
+		}
+		return thread[0], nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Rejeu : le fil du premier essai, dans son état actuel
+	if thread == nil {
+		return s.repo.FindThread(ctx, head.ID)
+	}
+	return thread, nil
+}
+
+func (s *service) createThread(ctx context.Context, headID, userID string, inputs []domain.ThreadPostInput, visibility domain.Visibility) ([]*domain.Post, error) {
+	if err := domain.ValidateThreadLength(len(inputs)); err != nil {
+		return nil, err
+	}
+	visibility, err := resolveVisibility(visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	// 0. Construction et modération automatique, post par post : un rejet annule tout le fil,
+	// un post retenu l'est seul (les autres paraissent, il rejoindra le fil à son approbation)
+	now := time.Now().UTC()
+	thread := make([]*domain.Post, len(inputs))
+	for i, in := range inputs {
+		id := headID
+		if i > 0 {
+			id = uuid.New().String()
+		}
+		post, err := s.newPost(ctx, domain.NewPostParams{
+			ID:             id,
+			UserID:         userID,
+			Content:        in.Content,
+			ContentWarning: in.ContentWarning,
+			Media:          in.Media,
+			Visibility:     visibility,
+			Language:       in.Language,
+		}, now)
+		if err != nil {
+			return nil, err
+		}
+		thread[i] = post
+	}
+	domain.LinkThread(thread)
+
+	// 1. Un seul événement de fan-out, pour la tête : la suite se lit depuis celle-ci.
+	// Les mentions, elles, sont notifiées post par post.
+	var events []*domain.OutboxMessage
+	for _, post := range thread {
+		messages, err := s.creationMessages(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, messages...)
+	}
+
+	// 2. Tout le fil, les dossiers des posts retenus et l'outbox dans la même transaction (tout ou rien)
+	if err := s.repo.SaveThread(ctx, thread, events...); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+func (s *service) GetThread(ctx context.Context, threadID, viewerID string, showSensitive bool) ([]*domain.Post, error) {
+	posts, err := s.repo.FindThread(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return s.readable(ctx, posts, viewerID, showSensitive)
+}
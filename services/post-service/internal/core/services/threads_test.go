@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/ports"
+)
+
+// fakeClassifier : retient les posts "douteux", refuse les posts "interdits"
+type fakeClassifier struct{}
+
+func (fakeClassifier) Classify(ctx context.Context, post *domain.Post) (domain.Classification, error) {
+	switch {
+	case strings.Contains(post.Content, "interdit"):
+		return domain.Classification{Verdict: domain.VerdictReject, Labels: []string{"spam"}}, nil
+	case strings.Contains(post.Content, "douteux"):
+		return domain.Classification{Verdict: domain.VerdictReview, Labels: []string{"spam"}, Reason: "test"}, nil
+	}
+	return domain.Allowed(), nil
+}
+
+func newThreadService(repo *fakePostRepo) *service {
+	return &service{
+		repo:       repo,
+		users:      &fakeUsers{ids: map[string]string{"bob": "user-bob", "carol": "user-carol"}},
+		publisher:  &fakePublisher{},
+		classifier: fakeClassifier{},
+		detector:   &fakeDetector{},
+		policy:     PostPolicy{Limits: domain.DefaultPostLimits},
+	}
+}
+
+func threadInputs(contents ...string) []domain.ThreadPostInput {
+	inputs := make([]domain.ThreadPostInput, len(contents))
+	for i, c := range contents {
+		inputs[i] = domain.ThreadPostInput{Content: c, Language: "fr"}
+	}
+	return inputs
+}
+
+func subjects(events []*domain.OutboxMessage) map[string][]string {
+	bySubject := make(map[string][]string)
+	for _, e := range events {
+		bySubject[e.Subject] = append(bySubject[e.Subject], e.ID)
+	}
+	return bySubject
+}
+
+func TestCreateThreadSingleFanOutEvent(t *testing.T) {
+	repo := &fakePostRepo{posts: map[string]*domain.Post{}}
+	s := newThreadService(repo)
+
+	thread, err := s.createThread(context.Background(), "head", "user-alice", threadInputs("un", "deux @bob", "trois @carol"), "")
+	if err != nil {
+		t.Fatalf("createThread: %v", err)
+	}
+	if len(thread) != 3 || len(repo.posts) != 3 {
+		t.Fatalf("fil = %d posts, enregistrés = %d, want 3", len(thread), len(repo.posts))
+	}
+
+	got := subjects(repo.events)
+	if created := got["post.created"]; len(created) != 1 || created[0] != "head" {
+		t.Errorf("post.created = %v, want [head]", created)
+	}
+	if mentions := got["post.user_mentioned"]; len(mentions) != 2 {
+		t.Errorf("post.user_mentioned = %v, want une mention par post", mentions)
+	}
+}
+
+func TestCreateThreadHeldPost(t *testing.T) {
+	repo := &fakePostRepo{posts: map[string]*domain.Post{}}
+	s := newThreadService(repo)
+
+	thread, err := s.createThread(context.Background(), "head", "user-alice", threadInputs("un", "deux douteux @bob", "trois"), "")
+	if err != nil {
+		t.Fatalf("createThread: %v", err)
+	}
+
+	held := thread[1]
+	if !held.IsHeld() || held.Review == nil {
+		t.Fatalf("post retenu : Status = %v, Review = %v", held.Status, held.Review)
+	}
+	if thread[0].IsHeld() || thread[2].IsHeld() {
+		t.Error("seul le post douteux doit être retenu")
+	}
+
+	got := subjects(repo.events)
+	if created := got["post.created"]; len(created) != 1 || created[0] != "head" {
+		t.Errorf("post.created = %v, want [head]", created)
+	}
+	if mentions := got["post.user_mentioned"]; len(mentions) != 0 {
+		t.Errorf("mentions d'un post retenu annoncées : %v", mentions)
+	}
+}
+
+func TestCreateThreadHeldHead(t *testing.T) {
+	repo := &fakePostRepo{posts: map[string]*domain.Post{}}
+	s := newThreadService(repo)
+
+	if _, err := s.createThread(context.Background(), "head", "user-alice", threadInputs("un douteux", "deux"), ""); err != nil {
+		t.Fatalf("createThread: %v", err)
+	}
+	// La tête retenue porte le fil : rien n'est distribué avant la décision du modérateur
+	if created := subjects(repo.events)["post.created"]; len(created) != 0 {
+		t.Errorf("post.created = %v, want aucun", created)
+	}
+}
+
+func TestCreateThreadRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		want     error
+	}{
+		{"un post refusé annule le fil", []string{"un", "deux interdit"}, domain.ErrContentRejected},
+		{"fil trop court", []string{"un"}, domain.ErrThreadTooShort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePostRepo{posts: map[string]*domain.Post{}}
+			s := newThreadService(repo)
+
+			_, err := s.createThread(context.Background(), "head", "user-alice", threadInputs(tt.contents...), "")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("createThread error = %v, want %v", err, tt.want)
+			}
+			if len(repo.posts) != 0 || len(repo.events) != 0 {
+				t.Errorf("enregistré malgré l'erreur : %d posts, %d événements", len(repo.posts), len(repo.events))
+			}
+		})
+	}
+}
+
+// fakeCases : masquage en mémoire, sur les posts du fakePostRepo
+type fakeCases struct {
+	ports.ModerationRepository
+	repo *fakePostRepo
+}
+
+func (c *fakeCases) HidePost(ctx context.Context, postID string, at time.Time, events ...*domain.OutboxMessage) error {
+	if p, ok := c.repo.posts[postID]; ok && !p.IsHidden() {
+		p.HiddenAt = at
+		c.repo.events = append(c.repo.events, events...)
+	}
+	return nil
+}
+
+func TestModerationHeldThreadPost(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		contents []string
+		action   domain.ModerationAction
+		target   int   // Position (0 = tête) du post retenu visé par la décision
+		want     []int // Positions annoncées (post.created) après la décision
+	}{
+		{"tête approuvée", []string{"un douteux", "deux"}, domain.ActionDismiss, 0, []int{0}},
+		{"tête masquée : le post suivant prend le relais", []string{"un douteux", "deux"}, domain.ActionHide, 0, []int{1}},
+		{"tête masquée, suite retenue : annoncée à son approbation", []string{"un douteux", "deux douteux"}, domain.ActionHide, 0, nil},
+		{"suite approuvée : lue depuis la tête", []string{"un", "deux douteux"}, domain.ActionDismiss, 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePostRepo{posts: map[string]*domain.Post{}}
+			thread, err := newThreadService(repo).createThread(ctx, "head", "user-alice", threadInputs(tt.contents...), "")
+			if err != nil {
+				t.Fatalf("createThread: %v", err)
+			}
+
+			written := len(repo.events) // Seuls comptent les événements écrits par la décision
+			m := &moderationService{
+				cases: &fakeCases{repo: repo},
+				posts: &service{repo: repo, publisher: &fakePublisher{}},
+			}
+			d := &domain.ModerationDecision{Action: tt.action, TargetType: domain.TargetPost, TargetID: thread[tt.target].ID, CreatedAt: time.Now().UTC()}
+			if err := m.apply(ctx, d); err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+
+			var want []string
+			for _, pos := range tt.want {
+				want = append(want, thread[pos].ID)
+			}
+			if got := subjects(repo.events[written:])["post.created"]; !slices.Equal(got, want) {
+				t.Errorf("post.created = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestModerationHidePublishedPost(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakePostRepo{posts: map[string]*domain.Post{"p1": {ID: "p1", UserID: "user-alice", Content: "a"}}}
+	m := &moderationService{
+		cases: &fakeCases{repo: repo},
+		posts: &service{repo: repo, publisher: &fakePublisher{}},
+	}
+
+	// Le second masquage ne retire rien de plus
+	for range 2 {
+		d := &domain.ModerationDecision{Action: domain.ActionHide, TargetType: domain.TargetPost, TargetID: "p1", CreatedAt: time.Now().UTC()}
+		if err := m.apply(ctx, d); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+
+	if got, want := subjects(repo.events)["post.deleted"], []string{"p1"}; !slices.Equal(got, want) {
+		t.Errorf("post.deleted = %v, want %v", got, want)
+	}
+	if !repo.posts["p1"].IsHidden() {
+		t.Error("post non masqué")
+	}
+}
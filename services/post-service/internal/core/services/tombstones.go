@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// softDelete : le post devient une pierre tombale, retirée des timelines via post.deleted.
+// extra : autres événements dus à la suppression (ex: relais d'un fil, cf. moderationService.promotionMessages)
+func (s *service) softDelete(ctx context.Context, post *domain.Post, extra ...*domain.OutboxMessage) error {
+	post.DeletedAt = time.Now().UTC()
+
+	// Le Feed Service retire le post ET les reposts purs emportés avec lui (outbox, même transaction)
+	return s.repo.SoftDelete(ctx, post, func(deleted []*domain.Post) ([]*domain.OutboxMessage, error) {
+		events := make([]*domain.OutboxMessage, len(deleted), len(deleted)+len(extra))
+		for i, p := range deleted {
+			var err error
+			if events[i], err = s.publisher.PostDeletedMessage(ctx, p); err != nil {
+				return nil, err
+			}
+		}
+		return append(events, extra...), nil
+	})
+}
+
+// RestorePost : seul l'auteur, et seulement pendant la fenêtre de restauration
+func (s *service) RestorePost(ctx context.Context, postID, userID string) (*domain.Post, error) {
+	post, err := s.repo.FindDeleted(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != userID {
+		return nil, domain.ErrPostNotFound // On ne révèle pas les suppressions des autres
+	}
+	if !post.CanBeRestoredAt(time.Now().UTC(), s.policy.RestoreWindow) {
+		return nil, domain.ErrRestoreWindowExpired
+	}
+
+	post.DeletedAt = time.Time{}
+
+	// Re-fan-out (même transaction) : le post retrouve sa place (created_at d'origine) dans les timelines.
+	// Pas de nouvelles notifications de mention : elles sont déjà parties à la publication.
+	// Un post masqué par la modération pendant sa suppression reste hors des timelines.
+	var events []*domain.OutboxMessage
+	if post.IsPublished() && !post.IsHidden() {
+		created, err := s.publisher.PostCreatedMessage(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, created)
+	}
+
+	if err := s.repo.Restore(ctx, postID, events...); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// getTombstone : un post supprimé reste "visible" comme pierre tombale (fil de discussion, citation),
+// avec les mêmes règles d'audience que le post d'origine
+func (s *service) getTombstone(ctx context.Context, postID, viewerID string) (*domain.Post, error) {
+	post, err := s.repo.FindDeleted(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkVisible(ctx, post, viewerID); err != nil {
+		return nil, err
+	}
+	return post.Tombstone(), nil
+}
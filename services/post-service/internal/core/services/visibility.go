@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+// resolveVisibility : public par défaut
+func resolveVisibility(v domain.Visibility) (domain.Visibility, error) {
+	if v == "" {
+		return domain.VisibilityPublic, nil
+	}
+	if !v.IsValid() {
+		return "", domain.ErrInvalidVisibility
+	}
+	return v, nil
+}
+
+// checkVisible : lecture unitaire (GetPost). Un post invisible est "introuvable" (on ne révèle pas son existence).
+func (s *service) checkVisible(ctx context.Context, post *domain.Post, viewerID string) error {
+	var rel domain.Relation
+	if post.NeedsRelation(viewerID) {
+		var err error
+		if rel, err = s.relations.CheckRelation(ctx, viewerID, post.UserID); err != nil {
+			return err
+		}
+	}
+
+	if !post.IsVisibleTo(viewerID, rel) {
+		return domain.ErrPostNotFound
+	}
+	return nil
+}
+
+// interactivePost : un post qu'on peut enregistrer, commenter ou sur lequel on peut réagir (publié et visible).
+// Brouillon, post planifié, retenu ou invisible : "introuvable", comme pour GetPost.
+func (s *service) interactivePost(ctx context.Context, postID, viewerID string) (*domain.Post, error) {
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPublished() {
+		return nil, domain.ErrPostNotFound
+	}
+	if err := s.checkVisible(ctx, post, viewerID); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// filterVisible : lectures en batch. UN SEUL appel au Graph Service, uniquement pour les auteurs
+// dont les posts en dépendent. Si le graphe est indisponible, on dégrade en masquant ces posts
+// (fail closed) plutôt que de casser tout le Feed.
+func (s *service) filterVisible(ctx context.Context, posts []*domain.Post, viewerID string) []*domain.Post {
+	var authorIDs []string
+	seen := make(map[string]bool)
+	for _, p := range posts {
+		if p.NeedsRelation(viewerID) && !seen[p.UserID] {
+			seen[p.UserID] = true
+			authorIDs = append(authorIDs, p.UserID)
+		}
+	}
+
+	relations := map[string]domain.Relation{}
+	if len(authorIDs) > 0 {
+		var err error
+		if relations, err = s.relations.CheckRelations(ctx, viewerID, authorIDs); err != nil {
+			slog.Error("Relation check failed, hiding restricted posts", "viewer_id", viewerID, "error", err)
+			relations = map[string]domain.Relation{}
+		}
+	}
+
+	visible := make([]*domain.Post, 0, len(posts))
+	for _, p := range posts {
+		if p.IsVisibleTo(viewerID, relations[p.UserID]) {
+			visible = append(visible, p)
+		}
+	}
+	return visible
+}
+
+// readable : chemin de lecture commun à toutes les listes de posts (visibilité en batch, puis present)
+func (s *service) readable(ctx context.Context, posts []*domain.Post, viewerID string, showSensitive bool) ([]*domain.Post, error) {
+	posts = s.filterVisible(ctx, posts, viewerID)
+	if err := s.present(ctx, posts, viewerID, showSensitive); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// present : des posts lisibles tels que le lecteur les voit (sondages, médias sensibles floutés
+// sauf s'il a choisi de les voir, cf. domain.Post.BlurSensitiveMedia)
+func (s *service) present(ctx context.Context, posts []*domain.Post, viewerID string, showSensitive bool) error {
+	if err := s.attachPolls(ctx, posts, viewerID); err != nil {
+		return err
+	}
+	if !showSensitive {
+		for _, p := range posts {
+			p.BlurSensitiveMedia(viewerID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jupiterclapton/cenackle/services/post-service/internal/core/domain"
+)
+
+func TestInteractivePost(t *testing.T) {
+	hiddenAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		post     *domain.Post
+		viewerID string
+		wantErr  error
+	}{
+		{"post public", &domain.Post{ID: "p1", UserID: "alice"}, "bob", nil},
+		{"post public, lecteur anonyme", &domain.Post{ID: "p1", UserID: "alice"}, "", nil},
+		{"abonnés, lecteur abonné", &domain.Post{ID: "p1", UserID: "alice", Visibility: domain.VisibilityFollowers}, "carol", nil},
+		{"abonnés, lecteur non abonné", &domain.Post{ID: "p1", UserID: "alice", Visibility: domain.VisibilityFollowers}, "bob", domain.ErrPostNotFound},
+		{"masqué par la modération", &domain.Post{ID: "p1", UserID: "alice", HiddenAt: hiddenAt}, "bob", domain.ErrPostNotFound},
+		{"retenu", &domain.Post{ID: "p1", UserID: "alice", Status: domain.PostStatusHeld}, "bob", domain.ErrPostNotFound},
+		{"brouillon de l'auteur", &domain.Post{ID: "p1", UserID: "alice", Status: domain.PostStatusDraft}, "alice", domain.ErrPostNotFound},
+		{"post inconnu", nil, "bob", domain.ErrPostNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePostRepo{posts: map[string]*domain.Post{}}
+			if tt.post != nil {
+				repo.posts[tt.post.ID] = tt.post
+			}
+			s := &service{repo: repo, relations: &fakeRelations{following: map[string][]string{"carol": {"alice"}}}}
+
+			_, err := s.interactivePost(context.Background(), "p1", tt.viewerID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommentsOfInvisiblePost(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakePostRepo{posts: map[string]*domain.Post{
+		"p1": {ID: "p1", UserID: "alice", Visibility: domain.VisibilityFollowers},
+	}}
+	comments := &commentService{posts: &service{repo: repo, relations: &fakeRelations{}}}
+	reactions := &reactionService{posts: comments.posts}
+
+	if _, _, err := comments.ListComments(ctx, "p1", "", "bob", 20, ""); !errors.Is(err, domain.ErrPostNotFound) {
+		t.Errorf("ListComments err = %v, want %v", err, domain.ErrPostNotFound)
+	}
+	if _, err := comments.CreateComment(ctx, "p1", "", "bob", "bonjour"); !errors.Is(err, domain.ErrPostNotFound) {
+		t.Errorf("CreateComment err = %v, want %v", err, domain.ErrPostNotFound)
+	}
+	if _, err := reactions.React(ctx, "p1", "bob", domain.ReactionLike); !errors.Is(err, domain.ErrPostNotFound) {
+		t.Errorf("React err = %v, want %v", err, domain.ErrPostNotFound)
+	}
+	if _, err := reactions.Unreact(ctx, "p1", "bob"); !errors.Is(err, domain.ErrPostNotFound) {
+		t.Errorf("Unreact err = %v, want %v", err, domain.ErrPostNotFound)
+	}
+}